@@ -5,11 +5,13 @@ go 1.24
 toolchain go1.24.10
 
 require (
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/websocket v1.5.3
 	github.com/hibiken/asynq v0.24.1
+	github.com/pkoukk/tiktoken-go v0.1.8
 	github.com/rs/zerolog v1.33.0
-	github.com/sashabaranov/go-openai v1.26.0
+	github.com/sashabaranov/go-openai v1.41.2
 	github.com/spf13/viper v1.19.0
 	google.golang.org/genai v1.36.0
 	google.golang.org/grpc v1.66.2
@@ -23,7 +25,7 @@ require (
 	cloud.google.com/go/compute/metadata v0.5.0 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
-	github.com/fsnotify/fsnotify v1.7.0 // indirect
+	github.com/dlclark/regexp2 v1.10.0 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/google/go-cmp v0.6.0 // indirect
@@ -0,0 +1,139 @@
+package audio
+
+import (
+	"encoding/binary"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+const (
+	mulawBias = 0x84 // 132, added before encoding so every segment has a leading one
+	mulawClip = 32635
+)
+
+// MulawToPCM16 decodes G.711 μ-law audio to 16-bit little-endian PCM
+func MulawToPCM16(mulaw []byte) []byte {
+	pcm := make([]byte, len(mulaw)*2)
+	for i, b := range mulaw {
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(decodeMulaw(b)))
+	}
+	return pcm
+}
+
+// AlawToPCM16 decodes G.711 A-law audio to 16-bit little-endian PCM
+func AlawToPCM16(alaw []byte) []byte {
+	pcm := make([]byte, len(alaw)*2)
+	for i, b := range alaw {
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(decodeAlaw(b)))
+	}
+	return pcm
+}
+
+// PCM16ToMulaw encodes 16-bit little-endian PCM as G.711 μ-law. A trailing odd byte is dropped.
+func PCM16ToMulaw(pcm []byte) []byte {
+	out := make([]byte, len(pcm)/2)
+	for i := range out {
+		out[i] = encodeMulaw(int16(binary.LittleEndian.Uint16(pcm[i*2:])))
+	}
+	return out
+}
+
+// PCM16ToAlaw encodes 16-bit little-endian PCM as G.711 A-law. A trailing odd byte is dropped.
+func PCM16ToAlaw(pcm []byte) []byte {
+	out := make([]byte, len(pcm)/2)
+	for i := range out {
+		out[i] = encodeAlaw(int16(binary.LittleEndian.Uint16(pcm[i*2:])))
+	}
+	return out
+}
+
+// G711Decoder returns the PCM16 decoder for a μ-law or A-law encoding name, or nil for any other encoding
+func G711Decoder(encoding string) func([]byte) []byte {
+	parsed, err := models.ParseAudioEncoding(encoding)
+	if err != nil {
+		return nil
+	}
+
+	switch parsed {
+	case models.AudioEncodingMulaw:
+		return MulawToPCM16
+	case models.AudioEncodingAlaw:
+		return AlawToPCM16
+	default:
+		return nil
+	}
+}
+
+// decodeMulaw expands one μ-law byte to a linear sample
+func decodeMulaw(b byte) int16 {
+	b = ^b
+	exponent := (b >> 4) & 0x07
+	mantissa := int(b & 0x0F)
+	sample := ((mantissa << 3) + mulawBias) << exponent
+	sample -= mulawBias
+	if b&0x80 != 0 {
+		return int16(-sample)
+	}
+	return int16(sample)
+}
+
+// decodeAlaw expands one A-law byte to a linear sample
+func decodeAlaw(b byte) int16 {
+	b ^= 0x55
+	exponent := (b >> 4) & 0x07
+	mantissa := int(b & 0x0F)
+
+	var sample int
+	if exponent == 0 {
+		sample = (mantissa << 4) + 8
+	} else {
+		sample = ((mantissa << 4) + 0x108) << (exponent - 1)
+	}
+	if b&0x80 == 0 {
+		return int16(-sample)
+	}
+	return int16(sample)
+}
+
+// encodeMulaw compresses a linear sample to μ-law
+func encodeMulaw(sample int16) byte {
+	value := int(sample)
+	sign := 0
+	if value < 0 {
+		value = -value
+		sign = 0x80
+	}
+	value = min(value, mulawClip) + mulawBias
+
+	exponent := 7
+	for mask := 0x4000; value&mask == 0 && exponent > 0; mask >>= 1 {
+		exponent--
+	}
+	mantissa := (value >> (exponent + 3)) & 0x0F
+
+	return ^byte(sign | exponent<<4 | mantissa)
+}
+
+// encodeAlaw compresses a linear sample to A-law
+func encodeAlaw(sample int16) byte {
+	value := int(sample)
+	sign := 0x80
+	if value < 0 {
+		value = -value - 1
+		sign = 0
+	}
+	value = min(value, 0x7FFF)
+
+	var b int
+	if value < 256 {
+		b = value >> 4
+	} else {
+		exponent := 1
+		for v := value >> 8; v > 1; v >>= 1 {
+			exponent++
+		}
+		b = exponent<<4 | (value>>(exponent+3))&0x0F
+	}
+
+	return byte(sign|b) ^ 0x55
+}
@@ -0,0 +1,113 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+const (
+	// DefaultGainTarget is the target RMS level as a fraction of full scale (about -20 dBFS)
+	DefaultGainTarget = 0.1
+
+	// maxGain caps amplification so silence and background noise are not blown up
+	maxGain = 20.0
+
+	// silenceRMS is the RMS level below which a buffer is treated as silence and left untouched
+	silenceRMS = 1e-4
+
+	pcm16FullScale = 32767.0
+)
+
+// GainNormalizer amplifies quiet 16-bit little-endian PCM toward a target RMS level
+// without clipping. It is stateless and cheap enough to run on every audio chunk.
+type GainNormalizer struct {
+	// Target is the desired RMS level as a fraction of full scale (0, 1]
+	Target float64
+}
+
+// NewGainNormalizer creates a gain normalizer for the given target level.
+// A target outside (0, 1] falls back to DefaultGainTarget.
+func NewGainNormalizer(target float64) *GainNormalizer {
+	if target <= 0 || target > 1 {
+		target = DefaultGainTarget
+	}
+	return &GainNormalizer{Target: target}
+}
+
+// NormalizeGainOption describes the "normalize_gain" STT option read by GainNormalizerFromOption
+var NormalizeGainOption = models.OptionDescriptor{
+	Name:        "normalize_gain",
+	Type:        models.OptionTypeAny,
+	Scope:       models.OptionScopeSTT,
+	Description: "true to normalize input loudness to the default level, or a number setting the target level",
+}
+
+// GainNormalizerFromOption builds a normalizer from a "normalize_gain" option value.
+// true enables the default target, a number sets the target level, anything else disables it.
+func GainNormalizerFromOption(value any) *GainNormalizer {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return NewGainNormalizer(DefaultGainTarget)
+		}
+	case float64:
+		if v > 0 {
+			return NewGainNormalizer(v)
+		}
+	case int:
+		// Treat integers as a percentage of full scale
+		if v > 0 {
+			return NewGainNormalizer(float64(v) / 100)
+		}
+	}
+	return nil
+}
+
+// Apply returns a copy of pcm amplified toward the target level.
+// Buffers that are already at or above the target, silent, or malformed are returned unchanged.
+func (n *GainNormalizer) Apply(pcm []byte) []byte {
+	samples := len(pcm) / 2
+	if n == nil || samples == 0 {
+		return pcm
+	}
+
+	var sumSquares float64
+	var peak float64
+	for i := 0; i < samples; i++ {
+		sample := float64(int16(binary.LittleEndian.Uint16(pcm[i*2:]))) / pcm16FullScale
+		sumSquares += sample * sample
+		if abs := math.Abs(sample); abs > peak {
+			peak = abs
+		}
+	}
+
+	rms := math.Sqrt(sumSquares / float64(samples))
+	if rms < silenceRMS {
+		return pcm
+	}
+
+	// Never push the peak past full scale
+	gain := math.Min(n.Target/rms, maxGain)
+	gain = math.Min(gain, 1/peak)
+	if gain <= 1 {
+		return pcm
+	}
+
+	out := make([]byte, len(pcm))
+	copy(out, pcm)
+	for i := 0; i < samples; i++ {
+		sample := float64(int16(binary.LittleEndian.Uint16(pcm[i*2:]))) * gain
+		sample = math.Max(math.Min(math.Round(sample), pcm16FullScale), -pcm16FullScale-1)
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(int16(sample)))
+	}
+
+	return out
+}
+
+// IsPCM16 reports whether an encoding name denotes 16-bit little-endian PCM
+func IsPCM16(encoding string) bool {
+	parsed, err := models.ParseAudioEncoding(encoding)
+	return err == nil && parsed == models.AudioEncodingPCM16
+}
@@ -0,0 +1,118 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// scale returns a copy of 16-bit PCM with every sample multiplied by factor
+func scale(pcm []byte, factor float64) []byte {
+	out := make([]byte, len(pcm))
+	for i := 0; i+1 < len(pcm); i += 2 {
+		sample := float64(int16(binary.LittleEndian.Uint16(pcm[i:]))) * factor
+		binary.LittleEndian.PutUint16(out[i:], uint16(int16(sample)))
+	}
+	return out
+}
+
+// peak returns the largest absolute 16-bit PCM sample
+func peak(pcm []byte) float64 {
+	var max float64
+	for i := 0; i+1 < len(pcm); i += 2 {
+		max = math.Max(max, math.Abs(float64(int16(binary.LittleEndian.Uint16(pcm[i:])))))
+	}
+	return max
+}
+
+func TestGainNormalizerAmplifiesQuietAudio(t *testing.T) {
+	normalizer := NewGainNormalizer(DefaultGainTarget)
+	quiet := scale(sine(16000, 1, 0.1), 0.05)
+
+	out := normalizer.Apply(quiet)
+
+	target := DefaultGainTarget * pcm16FullScale
+	if got := rms(out); math.Abs(got-target)/target > 0.01 {
+		t.Errorf("expected RMS near %.0f, got %.0f (input %.0f)", target, got, rms(quiet))
+	}
+	if peak(out) > pcm16FullScale {
+		t.Errorf("output clipped: peak %.0f", peak(out))
+	}
+	if !bytes.Equal(quiet, scale(sine(16000, 1, 0.1), 0.05)) {
+		t.Error("the input was modified")
+	}
+}
+
+func TestGainNormalizerDoesNotClip(t *testing.T) {
+	// A quiet buffer with one loud transient can only be raised until the transient hits full scale
+	pcm := scale(sine(16000, 1, 0.1), 0.02)
+	binary.LittleEndian.PutUint16(pcm[100:], uint16(int16(16000)))
+
+	out := NewGainNormalizer(DefaultGainTarget).Apply(pcm)
+
+	if got := peak(out); got > pcm16FullScale || got < pcm16FullScale-2 {
+		t.Errorf("expected the transient at full scale, got peak %.0f", got)
+	}
+	if rms(out) <= rms(pcm) {
+		t.Errorf("expected some amplification, got RMS %.0f from %.0f", rms(out), rms(pcm))
+	}
+}
+
+func TestGainNormalizerLeavesAudioUnchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		pcm  []byte
+	}{
+		{name: "already loud", pcm: sine(16000, 1, 0.1)},
+		{name: "silence", pcm: make([]byte, 3200)},
+		{name: "empty", pcm: nil},
+		{name: "single byte", pcm: []byte{1}},
+	}
+
+	normalizer := NewGainNormalizer(DefaultGainTarget)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if out := normalizer.Apply(tt.pcm); !bytes.Equal(out, tt.pcm) {
+				t.Error("expected the buffer unchanged")
+			}
+		})
+	}
+
+	var disabled *GainNormalizer
+	quiet := scale(sine(16000, 1, 0.1), 0.05)
+	if out := disabled.Apply(quiet); !bytes.Equal(out, quiet) {
+		t.Error("a nil normalizer should pass audio through")
+	}
+}
+
+func TestGainNormalizerFromOption(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		target float64
+	}{
+		{name: "true", value: true, target: DefaultGainTarget},
+		{name: "false", value: false},
+		{name: "level", value: 0.2, target: 0.2},
+		{name: "level out of range", value: 3.0, target: DefaultGainTarget},
+		{name: "percentage", value: 25, target: 0.25},
+		{name: "unset", value: nil},
+		{name: "string", value: "on"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalizer := GainNormalizerFromOption(tt.value)
+			if tt.target == 0 {
+				if normalizer != nil {
+					t.Errorf("expected no normalizer, got target %v", normalizer.Target)
+				}
+				return
+			}
+			if normalizer == nil || normalizer.Target != tt.target {
+				t.Errorf("expected target %v, got %+v", tt.target, normalizer)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// Resampler converts interleaved 16-bit little-endian PCM between sample rates by linear
+// interpolation. It keeps the last frame of each chunk so consecutive chunks of a stream join
+// without clicks. A nil Resampler passes audio through unchanged. It is not safe for concurrent use.
+type Resampler struct {
+	inRate   int
+	outRate  int
+	channels int
+	step     float64 // input frames advanced per output frame
+	pos      float64 // position of the next output frame, in input frames from the start of the next chunk
+	last     []int16 // last input frame of the previous chunk, nil before the first chunk
+	partial  []byte  // bytes of an incomplete frame at the end of the previous chunk
+}
+
+// NewResampler creates a resampler from inRate to outRate Hz. Channels default to 1.
+func NewResampler(inRate, outRate, channels int) (*Resampler, error) {
+	if inRate <= 0 || outRate <= 0 {
+		return nil, fmt.Errorf("invalid sample rates: %d Hz to %d Hz", inRate, outRate)
+	}
+	if channels <= 0 {
+		channels = 1
+	}
+
+	return &Resampler{
+		inRate:   inRate,
+		outRate:  outRate,
+		channels: channels,
+		step:     float64(inRate) / float64(outRate),
+	}, nil
+}
+
+// AutoResampleOption describes the "auto_resample" STT option read by ResamplerFromOption
+var AutoResampleOption = models.OptionDescriptor{
+	Name:        "auto_resample",
+	Type:        models.OptionTypeInt,
+	Scope:       models.OptionScopeSTT,
+	Description: "sample rate of the PCM16 audio being sent; it is resampled to the configured sample rate",
+}
+
+// ResamplerFromOption builds a resampler from an "auto_resample" option value holding the sample
+// rate of the incoming audio. It returns nil when the option is unset or already matches outRate.
+func ResamplerFromOption(value any, outRate, channels int) *Resampler {
+	var inRate int
+	switch v := value.(type) {
+	case int:
+		inRate = v
+	case float64:
+		inRate = int(v)
+	}
+
+	if inRate <= 0 || inRate == outRate {
+		return nil
+	}
+
+	resampler, err := NewResampler(inRate, outRate, channels)
+	if err != nil {
+		return nil
+	}
+	return resampler
+}
+
+// Resample converts a complete buffer of 16-bit PCM from inRate to outRate Hz.
+// A trailing partial frame is dropped.
+func Resample(pcm []byte, inRate, outRate, channels int) ([]byte, error) {
+	resampler, err := NewResampler(inRate, outRate, channels)
+	if err != nil {
+		return nil, err
+	}
+	if inRate == outRate {
+		return pcm, nil
+	}
+	return resampler.process(pcm, true), nil
+}
+
+// Apply resamples the next chunk of a stream. A trailing partial frame is held back and completed
+// by the start of the next chunk, so chunks may be split at any byte.
+func (r *Resampler) Apply(pcm []byte) []byte {
+	if r == nil || r.inRate == r.outRate {
+		return pcm
+	}
+	return r.process(pcm, false)
+}
+
+// process interpolates the output frames that fall within pcm. Mid-stream, frames between the last
+// input frame and the next chunk are deferred until that chunk arrives, as are the bytes of a
+// trailing partial frame; a final chunk holds its last frame instead and drops a partial one.
+func (r *Resampler) process(pcm []byte, final bool) []byte {
+	frameBytes := 2 * r.channels
+	if len(r.partial) > 0 {
+		pcm = append(r.partial, pcm...)
+		r.partial = nil
+	}
+	whole := len(pcm) - len(pcm)%frameBytes
+	if !final && whole < len(pcm) {
+		r.partial = append([]byte(nil), pcm[whole:]...)
+	}
+	pcm = pcm[:whole]
+
+	frames := whole / frameBytes
+	if frames == 0 {
+		return nil
+	}
+
+	sample := func(frame, channel int) float64 {
+		if frame < 0 {
+			return float64(r.last[channel])
+		}
+		frame = min(frame, frames-1)
+		return float64(int16(binary.LittleEndian.Uint16(pcm[(frame*r.channels+channel)*2:])))
+	}
+
+	limit := frames - 1
+	if final {
+		limit = frames
+	}
+
+	out := make([]byte, 0, (int(float64(frames)/r.step)+1)*frameBytes)
+	// The epsilon keeps accumulated rounding in pos from emitting an extra frame at the boundary
+	for r.pos < float64(limit)-1e-9 {
+		index := math.Floor(r.pos)
+		frac := r.pos - index
+		for channel := 0; channel < r.channels; channel++ {
+			s0 := sample(int(index), channel)
+			s1 := sample(int(index)+1, channel)
+			value := math.Max(math.Min(math.Round(s0+(s1-s0)*frac), pcm16FullScale), -pcm16FullScale-1)
+			out = binary.LittleEndian.AppendUint16(out, uint16(int16(value)))
+		}
+		r.pos += r.step
+	}
+
+	r.pos -= float64(frames)
+	if r.last == nil {
+		r.last = make([]int16, r.channels)
+	}
+	for channel := range r.last {
+		r.last[channel] = int16(binary.LittleEndian.Uint16(pcm[((frames-1)*r.channels+channel)*2:]))
+	}
+
+	return out
+}
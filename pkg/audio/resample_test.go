@@ -0,0 +1,97 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// sine returns seconds of a 440 Hz tone as interleaved 16-bit PCM with the same signal on every channel
+func sine(rate, channels int, seconds float64) []byte {
+	frames := int(float64(rate) * seconds)
+	pcm := make([]byte, 0, frames*channels*2)
+	for i := range frames {
+		value := int16(10000 * math.Sin(2*math.Pi*440*float64(i)/float64(rate)))
+		for range channels {
+			pcm = binary.LittleEndian.AppendUint16(pcm, uint16(value))
+		}
+	}
+	return pcm
+}
+
+// rms returns the root mean square of 16-bit PCM samples
+func rms(pcm []byte) float64 {
+	var sum float64
+	samples := len(pcm) / 2
+	for i := range samples {
+		sample := float64(int16(binary.LittleEndian.Uint16(pcm[i*2:])))
+		sum += sample * sample
+	}
+	return math.Sqrt(sum / float64(samples))
+}
+
+func TestResample(t *testing.T) {
+	tests := []struct {
+		inRate, outRate, channels int
+	}{
+		{44100, 16000, 1},
+		{22050, 16000, 1},
+		{32000, 8000, 1},
+		{8000, 16000, 1},
+		{48000, 16000, 2},
+	}
+
+	for _, tt := range tests {
+		input := sine(tt.inRate, tt.channels, 1)
+		output, err := Resample(input, tt.inRate, tt.outRate, tt.channels)
+		if err != nil {
+			t.Fatalf("%d->%d: %v", tt.inRate, tt.outRate, err)
+		}
+
+		if len(output)%(2*tt.channels) != 0 {
+			t.Errorf("%d->%d: output of %d bytes holds a partial frame", tt.inRate, tt.outRate, len(output))
+		}
+		inFrames := len(input) / (2 * tt.channels)
+		outFrames := len(output) / (2 * tt.channels)
+		wantFrames := float64(inFrames) * float64(tt.outRate) / float64(tt.inRate)
+		if math.Abs(float64(outFrames)-wantFrames) > 1 {
+			t.Errorf("%d->%d: got %d frames, want about %.0f", tt.inRate, tt.outRate, outFrames, wantFrames)
+		}
+
+		if before, after := rms(input), rms(output); math.Abs(after-before)/before > 0.05 {
+			t.Errorf("%d->%d: energy changed from %.1f to %.1f RMS", tt.inRate, tt.outRate, before, after)
+		}
+	}
+}
+
+func TestResamplerStreamSplitAtAnyByte(t *testing.T) {
+	input := sine(44100, 2, 0.5)
+
+	whole, _ := NewResampler(44100, 16000, 2)
+	want := whole.Apply(input)
+
+	// Chunk sizes that split frames, and samples, at every offset
+	for _, size := range []int{1, 3, 333, 1001} {
+		chunked, _ := NewResampler(44100, 16000, 2)
+		var got []byte
+		for start := 0; start < len(input); start += size {
+			got = append(got, chunked.Apply(input[start:min(start+size, len(input))])...)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%d-byte chunks: got %d bytes, want %d", size, len(got), len(want))
+		}
+		// Chunk boundaries only change how the interpolation position is rounded
+		for i := 0; i < len(got); i += 2 {
+			diff := int(int16(binary.LittleEndian.Uint16(got[i:]))) - int(int16(binary.LittleEndian.Uint16(want[i:])))
+			if diff < -1 || diff > 1 {
+				t.Fatalf("%d-byte chunks: sample %d differs from the unsplit stream by %d", size, i/2, diff)
+			}
+		}
+	}
+}
+
+func TestResampleRejectsInvalidRates(t *testing.T) {
+	if _, err := Resample(sine(16000, 1, 0.1), 0, 16000, 1); err == nil {
+		t.Error("expected an error for a zero input rate")
+	}
+}
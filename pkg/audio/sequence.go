@@ -0,0 +1,198 @@
+package audio
+
+import (
+	"sync"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// DefaultGapTolerance is how far the provider's view of the audio may drift from
+// what was sent before the difference is reported as a gap
+const DefaultGapTolerance = 250 * time.Millisecond
+
+// Gap is a stretch of audio, in seconds from the start of the stream, that the provider never saw
+type Gap struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Duration returns the length of the gap in seconds
+func (g Gap) Duration() float64 {
+	return g.End - g.Start
+}
+
+// SequenceTracker counts the audio sent to a streaming STT provider and compares it with the
+// offsets the provider reports, so that frames lost on the way are detected instead of silently
+// degrading recognition. A nil tracker is valid and records nothing.
+type SequenceTracker struct {
+	// Tolerance bounds the drift accepted before a gap is reported
+	Tolerance time.Duration
+
+	mu             sync.Mutex
+	bytesPerSecond float64 // zero when the encoding has no fixed byte rate
+	frames         int64
+	bytes          int64
+	received       float64 // latest received-audio offset reported by the provider, in seconds
+	timelineEnd    float64 // end of the last final result, in seconds
+	gaps           []Gap
+}
+
+// NewSequenceTracker creates a tracker for audio in the given encoding.
+// Sent durations are only known for PCM16, mu-law and A-law; other encodings count frames and bytes only.
+func NewSequenceTracker(encoding string, sampleRate, channels int) *SequenceTracker {
+	if channels <= 0 {
+		channels = 1
+	}
+
+	var bytesPerSample float64
+	if parsed, err := models.ParseAudioEncoding(encoding); err == nil {
+		switch parsed {
+		case models.AudioEncodingPCM16:
+			bytesPerSample = 2
+		case models.AudioEncodingMulaw, models.AudioEncodingAlaw:
+			bytesPerSample = 1
+		}
+	}
+
+	return &SequenceTracker{
+		Tolerance:      DefaultGapTolerance,
+		bytesPerSecond: float64(sampleRate*channels) * bytesPerSample,
+	}
+}
+
+// TrackSequenceOption describes the "track_sequence" STT option read by SequenceTrackerFromOption
+var TrackSequenceOption = models.OptionDescriptor{
+	Name:        "track_sequence",
+	Type:        models.OptionTypeBool,
+	Scope:       models.OptionScopeSTT,
+	Description: "annotate results with the amount of audio sent and report gaps against the provider timeline",
+}
+
+// SequenceTrackerFromOption builds a tracker when the "track_sequence" option is true and returns nil otherwise
+func SequenceTrackerFromOption(value any, encoding string, sampleRate, channels int) *SequenceTracker {
+	if enabled, ok := value.(bool); ok && enabled {
+		return NewSequenceTracker(encoding, sampleRate, channels)
+	}
+	return nil
+}
+
+// RecordSend counts a frame of n bytes handed to the provider
+func (t *SequenceTracker) RecordSend(n int) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.frames++
+	t.bytes += int64(n)
+}
+
+// SentDuration returns the seconds of audio sent so far, or zero when the encoding has no fixed byte rate
+func (t *SequenceTracker) SentDuration() float64 {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.sentDuration()
+}
+
+// ObserveFinal checks a final result against the end of the previous one. Providers whose final
+// results tile the stream report a discontinuity when audio went missing between them.
+func (t *SequenceTracker) ObserveFinal(start, end float64) *Gap {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var gap *Gap
+	if start-t.timelineEnd > t.Tolerance.Seconds() {
+		gap = &Gap{Start: t.timelineEnd, End: start}
+		t.gaps = append(t.gaps, *gap)
+	}
+	if end > t.timelineEnd {
+		t.timelineEnd = end
+	}
+
+	return gap
+}
+
+// ObserveReceived records the amount of audio, in seconds, the provider reports having received
+func (t *SequenceTracker) ObserveReceived(offset float64) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if offset > t.received {
+		t.received = offset
+	}
+}
+
+// Finish compares the audio sent with the provider's final received offset once the stream is over.
+// It returns the missing tail when the provider received noticeably less than was sent.
+func (t *SequenceTracker) Finish() *Gap {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sent := t.sentDuration()
+	if sent == 0 || t.received == 0 || sent-t.received <= t.Tolerance.Seconds() {
+		return nil
+	}
+
+	gap := Gap{Start: t.received, End: sent}
+	t.gaps = append(t.gaps, gap)
+	return &gap
+}
+
+// Gaps returns every gap detected so far
+func (t *SequenceTracker) Gaps() []Gap {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]Gap(nil), t.gaps...)
+}
+
+// Annotate adds the sent-audio counters to result metadata
+func (t *SequenceTracker) Annotate(metadata map[string]any) {
+	if t == nil || metadata == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	metadata["audio_frames_sent"] = t.frames
+	metadata["audio_bytes_sent"] = t.bytes
+	if sent := t.sentDuration(); sent > 0 {
+		metadata["audio_sent_secs"] = sent
+	}
+	if len(t.gaps) > 0 {
+		metadata["audio_gaps"] = len(t.gaps)
+	}
+}
+
+// sentDuration converts the bytes sent to seconds; callers must hold mu
+func (t *SequenceTracker) sentDuration() float64 {
+	if t.bytesPerSecond == 0 {
+		return 0
+	}
+	return float64(t.bytes) / t.bytesPerSecond
+}
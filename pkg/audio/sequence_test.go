@@ -0,0 +1,137 @@
+package audio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSequenceTrackerSentDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		rate     int
+		channels int
+		want     float64
+	}{
+		{name: "pcm16 mono", encoding: "linear16", rate: 16000, channels: 1, want: 1},
+		{name: "pcm16 stereo", encoding: "pcm_s16le", rate: 16000, channels: 2, want: 0.5},
+		{name: "mulaw", encoding: "mulaw", rate: 8000, channels: 0, want: 4},
+		{name: "compressed", encoding: "opus", rate: 48000, channels: 1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewSequenceTracker(tt.encoding, tt.rate, tt.channels)
+			for range 4 {
+				tracker.RecordSend(8000)
+			}
+			if got := tracker.SentDuration(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("got %v seconds, want %v", got, tt.want)
+			}
+
+			metadata := map[string]any{}
+			tracker.Annotate(metadata)
+			if metadata["audio_frames_sent"] != int64(4) || metadata["audio_bytes_sent"] != int64(32000) {
+				t.Errorf("unexpected counters: %v", metadata)
+			}
+			if _, ok := metadata["audio_sent_secs"]; ok != (tt.want > 0) {
+				t.Errorf("audio_sent_secs present %v for %v seconds: %v", ok, tt.want, metadata)
+			}
+		})
+	}
+}
+
+func TestSequenceTrackerObserveFinal(t *testing.T) {
+	tracker := NewSequenceTracker("linear16", 16000, 1)
+
+	// Adjacent results and drift within the tolerance are not gaps
+	for _, result := range [][2]float64{{0, 1.5}, {1.5, 3}, {3.2, 4}} {
+		if gap := tracker.ObserveFinal(result[0], result[1]); gap != nil {
+			t.Errorf("result %v: unexpected gap %v", result, *gap)
+		}
+	}
+
+	gap := tracker.ObserveFinal(5, 6)
+	if gap == nil || gap.Start != 4 || gap.End != 5 || gap.Duration() != 1 {
+		t.Fatalf("expected a one second gap from 4 to 5, got %v", gap)
+	}
+
+	// A result that overlaps earlier audio does not move the timeline back
+	if gap := tracker.ObserveFinal(5.5, 5.8); gap != nil {
+		t.Errorf("unexpected gap for an overlapping result: %v", *gap)
+	}
+	if gap := tracker.ObserveFinal(6.1, 7); gap != nil {
+		t.Errorf("unexpected gap after an overlapping result: %v", *gap)
+	}
+
+	if gaps := tracker.Gaps(); len(gaps) != 1 || gaps[0] != *gap {
+		t.Errorf("expected the single gap to be recorded, got %v", gaps)
+	}
+	metadata := map[string]any{}
+	tracker.Annotate(metadata)
+	if metadata["audio_gaps"] != 1 {
+		t.Errorf("expected one gap in the metadata, got %v", metadata)
+	}
+}
+
+func TestSequenceTrackerFinish(t *testing.T) {
+	tests := []struct {
+		name     string
+		sent     int // bytes of 16 kHz PCM16, 32000 per second
+		received []float64
+		want     *Gap
+	}{
+		{name: "fully received", sent: 64000, received: []float64{1, 2}},
+		{name: "within tolerance", sent: 64000, received: []float64{1.9}},
+		{name: "missing tail", sent: 96000, received: []float64{2, 1.5}, want: &Gap{Start: 2, End: 3}},
+		{name: "no received offset", sent: 64000},
+		{name: "nothing sent", received: []float64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewSequenceTracker("linear16", 16000, 1)
+			if tt.sent > 0 {
+				tracker.RecordSend(tt.sent)
+			}
+			for _, offset := range tt.received {
+				tracker.ObserveReceived(offset)
+			}
+
+			gap := tracker.Finish()
+			switch {
+			case tt.want == nil && gap != nil:
+				t.Errorf("unexpected gap %v", *gap)
+			case tt.want != nil && (gap == nil || *gap != *tt.want):
+				t.Errorf("expected gap %v, got %v", *tt.want, gap)
+			}
+			wantRecorded := 0
+			if tt.want != nil {
+				wantRecorded = 1
+			}
+			if gaps := tracker.Gaps(); len(gaps) != wantRecorded {
+				t.Errorf("expected %d recorded gaps, got %v", wantRecorded, gaps)
+			}
+		})
+	}
+}
+
+func TestSequenceTrackerFromOption(t *testing.T) {
+	if tracker := SequenceTrackerFromOption(true, "linear16", 16000, 1); tracker == nil {
+		t.Error("expected a tracker when the option is true")
+	}
+	for _, value := range []any{nil, false, "true"} {
+		if tracker := SequenceTrackerFromOption(value, "linear16", 16000, 1); tracker != nil {
+			t.Errorf("%v: expected no tracker", value)
+		}
+	}
+
+	// A nil tracker is safe to use
+	var tracker *SequenceTracker
+	tracker.RecordSend(100)
+	tracker.ObserveReceived(1)
+	tracker.Annotate(map[string]any{})
+	if tracker.ObserveFinal(0, 10) != nil || tracker.Finish() != nil || tracker.Gaps() != nil || tracker.SentDuration() != 0 {
+		t.Error("expected a nil tracker to record nothing")
+	}
+}
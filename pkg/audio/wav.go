@@ -0,0 +1,95 @@
+package audio
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// wavHeaderSize is the size of a canonical 44-byte RIFF/WAVE header
+const wavHeaderSize = 44
+
+// DefaultWAVSampleRate is the sample rate SynthesizeWAV requests when the config leaves it unset
+const DefaultWAVSampleRate = 16000
+
+// WrapPCMAsWAV prepends a RIFF/WAVE header to raw little-endian PCM so it can be saved as a playable file.
+// Channels default to 1 and bits per sample to 16. A trailing partial sample frame, such as the last
+// byte of odd-length 16-bit audio, is dropped so the data chunk holds whole frames only.
+func WrapPCMAsWAV(pcm []byte, sampleRate, channels, bitsPerSample int) []byte {
+	if channels <= 0 {
+		channels = 1
+	}
+	if bitsPerSample <= 0 {
+		bitsPerSample = 16
+	}
+
+	blockAlign := channels * ((bitsPerSample + 7) / 8)
+	dataSize := len(pcm) - len(pcm)%blockAlign
+
+	wav := make([]byte, wavHeaderSize+dataSize)
+	copy(wav[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(wav[4:8], uint32(wavHeaderSize-8+dataSize))
+	copy(wav[8:12], "WAVE")
+
+	copy(wav[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(wav[16:20], 16) // fmt chunk size for PCM
+	binary.LittleEndian.PutUint16(wav[20:22], 1)  // PCM format
+	binary.LittleEndian.PutUint16(wav[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(wav[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(wav[28:32], uint32(sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(wav[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(wav[34:36], uint16(bitsPerSample))
+
+	copy(wav[36:40], "data")
+	binary.LittleEndian.PutUint32(wav[40:44], uint32(dataSize))
+	copy(wav[wavHeaderSize:], pcm[:dataSize])
+
+	return wav
+}
+
+// SynthesizeWAV synthesizes text as 16-bit PCM and returns it wrapped in a WAV header.
+// The sample rate defaults to DefaultWAVSampleRate and the channel count is read from
+// Options["channels"] (mono when unset), so the header matches what the provider produced.
+func SynthesizeWAV(ctx context.Context, service interfaces.TTSService, text string, config models.TTSConfig) ([]byte, error) {
+	if config.Encoding == "" {
+		config.Encoding = string(models.AudioEncodingPCM16)
+	}
+	encoding, err := models.ParseAudioEncoding(config.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	if encoding != models.AudioEncodingPCM16 {
+		return nil, fmt.Errorf("WAV output requires %s audio, got %s", models.AudioEncodingPCM16, config.Encoding)
+	}
+	if container, ok := config.Options["container"].(string); ok && container != "" && container != "raw" {
+		return nil, fmt.Errorf("WAV output requires the raw container, got %s", container)
+	}
+	if config.SampleRate == 0 {
+		config.SampleRate = DefaultWAVSampleRate
+	}
+
+	pcm, err := service.Synthesize(ctx, text, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return WrapPCMAsWAV(pcm, config.SampleRate, channelsOption(config.Options), 16), nil
+}
+
+// channelsOption reads the "channels" option, returning 1 when it is unset
+func channelsOption(options map[string]any) int {
+	switch v := options["channels"].(type) {
+	case int:
+		if v > 0 {
+			return v
+		}
+	case float64:
+		if v > 0 {
+			return int(v)
+		}
+	}
+	return 1
+}
@@ -0,0 +1,96 @@
+package audio
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// stubTTS returns fixed PCM from Synthesize and records the config it was called with
+type stubTTS struct {
+	interfaces.TTSService
+	pcm    []byte
+	config models.TTSConfig
+}
+
+func (s *stubTTS) Synthesize(_ context.Context, _ string, config models.TTSConfig) ([]byte, error) {
+	s.config = config
+	return s.pcm, nil
+}
+
+// wavFormat is the format chunk of a WAV header
+type wavFormat struct {
+	channels   int
+	sampleRate int
+	byteRate   int
+	blockAlign int
+	dataSize   int
+}
+
+func parseWAVHeader(t *testing.T, wav []byte) wavFormat {
+	t.Helper()
+
+	if len(wav) < wavHeaderSize || string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" || string(wav[36:40]) != "data" {
+		t.Fatalf("not a WAV header: %q", wav[:min(len(wav), wavHeaderSize)])
+	}
+	if riffSize := int(binary.LittleEndian.Uint32(wav[4:8])); riffSize != len(wav)-8 {
+		t.Errorf("RIFF size %d does not match the file size %d", riffSize, len(wav))
+	}
+	return wavFormat{
+		channels:   int(binary.LittleEndian.Uint16(wav[22:24])),
+		sampleRate: int(binary.LittleEndian.Uint32(wav[24:28])),
+		byteRate:   int(binary.LittleEndian.Uint32(wav[28:32])),
+		blockAlign: int(binary.LittleEndian.Uint16(wav[32:34])),
+		dataSize:   int(binary.LittleEndian.Uint32(wav[40:44])),
+	}
+}
+
+func TestWrapPCMAsWAV(t *testing.T) {
+	for _, rate := range []int{16000, 22050, 32000} {
+		format := parseWAVHeader(t, WrapPCMAsWAV(make([]byte, 101), rate, 1, 16))
+		want := wavFormat{channels: 1, sampleRate: rate, byteRate: rate * 2, blockAlign: 2, dataSize: 100}
+		if format != want {
+			t.Errorf("%d Hz: got %+v, want %+v", rate, format, want)
+		}
+	}
+}
+
+func TestSynthesizeWAVUsesConfiguredChannels(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]any
+		channels int
+	}{
+		{name: "mono by default", channels: 1},
+		{name: "stereo", options: map[string]any{"channels": 2}, channels: 2},
+		{name: "stereo from JSON", options: map[string]any{"channels": float64(2)}, channels: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &stubTTS{pcm: make([]byte, 802)}
+			wav, err := SynthesizeWAV(context.Background(), service, "hello", models.TTSConfig{SampleRate: 24000, Options: tt.options})
+			if err != nil {
+				t.Fatalf("SynthesizeWAV: %v", err)
+			}
+
+			blockAlign := 2 * tt.channels
+			want := wavFormat{
+				channels:   tt.channels,
+				sampleRate: 24000,
+				byteRate:   24000 * blockAlign,
+				blockAlign: blockAlign,
+				dataSize:   802 - 802%blockAlign,
+			}
+			if format := parseWAVHeader(t, wav); format != want {
+				t.Errorf("got %+v, want %+v", format, want)
+			}
+			if service.config.Encoding != string(models.AudioEncodingPCM16) {
+				t.Errorf("expected PCM to be requested, got %q", service.config.Encoding)
+			}
+		})
+	}
+}
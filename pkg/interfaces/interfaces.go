@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/creastat/common-go/pkg/models"
 	"github.com/creastat/common-go/pkg/types"
@@ -20,6 +21,23 @@ type BaseProvider interface {
 // Provider is an alias for BaseProvider for backward compatibility
 type Provider = BaseProvider
 
+// CapabilityDescriber is implemented by providers and services that describe their capabilities
+// in detail, such as which of them can stream
+type CapabilityDescriber interface {
+	DescribeCapabilities() *models.ProviderCapabilities
+}
+
+// CapabilityHealthChecker is implemented by providers that can check each capability separately,
+// so an outage of one capability is detected while the others are healthy
+type CapabilityHealthChecker interface {
+	CheckCapabilityHealth(ctx context.Context, capability types.Capability) error
+}
+
+// OptionDescriber is implemented by providers that describe the Options keys they honor
+type OptionDescriber interface {
+	SupportedOptions() []models.OptionDescriptor
+}
+
 // AIProvider defines interface for AI models (LLM, Embedding)
 type AIProvider interface {
 	BaseProvider
@@ -59,8 +77,16 @@ type ChatChunk struct {
 	Content      string `json:"content"`
 	Done         bool   `json:"done"`
 	FinishReason string `json:"finish_reason,omitempty"`
+	// ToolCalls holds tool-call fragments as they stream in; on the final Done chunk
+	// it holds the complete tool calls with their arguments assembled
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// Usage is set on the final Done chunk when the provider reports token usage
+	Usage *models.TokenUsage `json:"usage,omitempty"`
 }
 
+// ToolCall is a function call requested by the model
+type ToolCall = types.ToolCall
+
 // ChatStream represents a streaming chat response handler
 type ChatStream interface {
 	Send(chunk ChatChunk) error
@@ -70,6 +96,8 @@ type ChatStream interface {
 // EmbeddingService provides embedding generation functionality
 type EmbeddingService interface {
 	GenerateEmbedding(ctx context.Context, text string) ([]float32, error)
+	// GenerateEmbeddings returns one embedding per text, in input order, using as few requests as the provider allows
+	GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
 }
 
 // STTService provides speech-to-text functionality
@@ -77,6 +105,8 @@ type STTService interface {
 	Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error)
 	StreamTranscribe(ctx context.Context, audioStream <-chan []byte, options map[string]any) (<-chan string, <-chan error)
 	NewSTTClient(ctx context.Context, config models.STTConfig) (STTClient, error)
+	// SupportedLanguages returns the normalized primary language codes the service can transcribe
+	SupportedLanguages() []string
 }
 
 // TTSService provides text-to-speech functionality
@@ -85,6 +115,10 @@ type TTSService interface {
 	StreamSynthesize(ctx context.Context, textStream <-chan string, config models.TTSConfig) (<-chan []byte, <-chan error)
 	NewTTSClient(ctx context.Context, config models.TTSConfig) (TTSClient, error)
 	GetVoices(ctx context.Context) ([]models.Voice, error)
+	// GetVoicesByLanguage returns the voices for a language; "ru" matches "ru-RU"
+	GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error)
+	// SupportedLanguages returns the normalized primary language codes the service can synthesize
+	SupportedLanguages() []string
 }
 
 // TTSClient represents a TTS client interface
@@ -100,4 +134,28 @@ type STTClient interface {
 	Close() error
 	Send(ctx context.Context, audioData []byte) error
 	Receive(ctx context.Context) (*models.STTResult, error)
+	// StreamTo invokes handler for each result until end of stream, returning the terminal error.
+	// Returning an error from handler stops and finalizes the stream.
+	StreamTo(ctx context.Context, handler func(*models.STTResult) error) error
+	// StreamStartTime returns when the stream was opened; result and word offsets are relative to it
+	StreamStartTime() time.Time
 }
+
+// DuplexStream is a streaming session that accepts inputs and yields outputs, so pipeline code can
+// drive STT and TTS clients alike. Receive returns io.EOF once the session has ended.
+type DuplexStream[In, Out any] interface {
+	Send(ctx context.Context, input In) error
+	Receive(ctx context.Context) (Out, error)
+	Close() error
+}
+
+// STTStream is an STT client seen as a stream of audio in and results out
+type STTStream = DuplexStream[[]byte, *models.STTResult]
+
+// TTSStream is a TTS client seen as a stream of text in and audio out
+type TTSStream = DuplexStream[string, []byte]
+
+var (
+	_ STTStream = STTClient(nil)
+	_ TTSStream = TTSClient(nil)
+)
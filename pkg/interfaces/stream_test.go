@@ -0,0 +1,70 @@
+package interfaces_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/mock"
+)
+
+// pump is pipeline plumbing written once against DuplexStream: it sends every input, closes the
+// stream and collects the outputs until end of stream
+func pump[In, Out any](ctx context.Context, stream interfaces.DuplexStream[In, Out], inputs []In) ([]Out, error) {
+	for _, input := range inputs {
+		if err := stream.Send(ctx, input); err != nil {
+			return nil, err
+		}
+	}
+	if err := stream.Close(); err != nil {
+		return nil, err
+	}
+
+	var outputs []Out
+	for {
+		output, err := stream.Receive(ctx)
+		if err == io.EOF {
+			return outputs, nil
+		}
+		if err != nil {
+			return outputs, err
+		}
+		outputs = append(outputs, output)
+	}
+}
+
+func TestClientsSatisfyDuplexStream(t *testing.T) {
+	ctx := context.Background()
+	provider := mock.NewMockProvider(mock.Config{
+		STTResults: []*models.STTResult{{Text: "hello", IsFinal: true}},
+		Audio:      []byte{1, 2},
+	})
+
+	sttClient, err := provider.NewSTTClient(ctx, models.STTConfig{})
+	if err != nil {
+		t.Fatalf("NewSTTClient: %v", err)
+	}
+	var stt interfaces.STTStream = sttClient
+	results, err := pump(ctx, stt, [][]byte{{0, 0}, {0, 0}})
+	if err != nil {
+		t.Fatalf("STT stream: %v", err)
+	}
+	if len(results) != 1 || results[0].Text != "hello" {
+		t.Errorf("expected the single STT result, got %v", results)
+	}
+
+	ttsClient, err := provider.NewTTSClient(ctx, models.TTSConfig{})
+	if err != nil {
+		t.Fatalf("NewTTSClient: %v", err)
+	}
+	var tts interfaces.TTSStream = ttsClient
+	chunks, err := pump(ctx, tts, []string{"one", "two"})
+	if err != nil {
+		t.Fatalf("TTS stream: %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Errorf("expected one audio chunk per text, got %d", len(chunks))
+	}
+}
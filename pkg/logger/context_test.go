@@ -0,0 +1,48 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/logger"
+)
+
+// foreignKey is a context key type of another library that happens to use the same names
+type foreignKey string
+
+func TestContextKeysDoNotCollide(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(context.Context, string) context.Context
+		get  func(context.Context) string
+	}{
+		{name: "request_id", set: logger.ContextWithRequestID, get: logger.GetRequestIDFromContext},
+		{name: "session_id", set: logger.ContextWithSessionID, get: logger.GetSessionIDFromContext},
+		{name: "user_id", set: logger.ContextWithUserID, get: logger.GetUserIDFromContext},
+		{name: "provider_id", set: logger.ContextWithProviderID, get: logger.GetProviderIDFromContext},
+		{name: "capability", set: logger.ContextWithCapability, get: logger.GetCapabilityFromContext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), foreignKey(tt.name), "foreign")
+			// A plain string key is what careless code elsewhere would use
+			ctx = context.WithValue(ctx, tt.name, "plain")
+
+			if got := tt.get(ctx); got != "" {
+				t.Errorf("read %q set under another package's key", got)
+			}
+
+			ctx = tt.set(ctx, "ours")
+			if got := tt.get(ctx); got != "ours" {
+				t.Errorf("got %q, want the value set by this package", got)
+			}
+			if got := ctx.Value(foreignKey(tt.name)); got != "foreign" {
+				t.Errorf("the foreign key now reads %v", got)
+			}
+			if got := ctx.Value(tt.name); got != "plain" {
+				t.Errorf("the plain string key now reads %v", got)
+			}
+		})
+	}
+}
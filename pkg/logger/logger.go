@@ -4,25 +4,23 @@ import (
 	"context"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
 )
 
-// ContextKey represents a key for context values
-type ContextKey string
+// contextKey is the type of the context keys set by this package. It is unexported so values
+// stored under a same-named key by other packages never collide with them; use the ContextWith*
+// and Get*FromContext helpers to set and read them.
+type contextKey string
 
 const (
-	// ContextKeyRequestID is the key for request ID in context
-	ContextKeyRequestID ContextKey = "request_id"
-	// ContextKeySessionID is the key for session ID in context
-	ContextKeySessionID ContextKey = "session_id"
-	// ContextKeyUserID is the key for user ID in context
-	ContextKeyUserID ContextKey = "user_id"
-	// ContextKeyProviderID is the key for provider ID in context
-	ContextKeyProviderID ContextKey = "provider_id"
-	// ContextKeyCapability is the key for capability in context
-	ContextKeyCapability ContextKey = "capability"
+	contextKeyRequestID  contextKey = "request_id"
+	contextKeySessionID  contextKey = "session_id"
+	contextKeyUserID     contextKey = "user_id"
+	contextKeyProviderID contextKey = "provider_id"
+	contextKeyCapability contextKey = "capability"
 )
 
 // Logger defines the interface for structured logging
@@ -116,7 +114,8 @@ type Config struct {
 	// Level is the minimum log level (debug, info, warn, error, fatal)
 	Level string
 
-	// Format is the log format (json, console)
+	// Format is the log format (json, console). When empty it is detected: console in
+	// development or when stdout is a terminal, JSON otherwise.
 	Format string
 
 	// EnableCaller enables caller information in logs
@@ -136,7 +135,7 @@ func New(config Config) Logger {
 
 	// Set up output writer
 	var output io.Writer = os.Stdout
-	if config.Format == "console" || config.Format == "text" {
+	if format := resolveFormat(config); format == "console" || format == "text" {
 		output = zerolog.ConsoleWriter{
 			Out:        os.Stdout,
 			TimeFormat: time.RFC3339,
@@ -215,31 +214,31 @@ func (l *zerologLogger) WithContext(ctx context.Context) Logger {
 	logger := l.logger
 
 	// Extract correlation IDs from context
-	if requestID := ctx.Value(ContextKeyRequestID); requestID != nil {
+	if requestID := ctx.Value(contextKeyRequestID); requestID != nil {
 		if id, ok := requestID.(string); ok {
 			logger = logger.With().Str("request_id", id).Logger()
 		}
 	}
 
-	if sessionID := ctx.Value(ContextKeySessionID); sessionID != nil {
+	if sessionID := ctx.Value(contextKeySessionID); sessionID != nil {
 		if id, ok := sessionID.(string); ok {
 			logger = logger.With().Str("session_id", id).Logger()
 		}
 	}
 
-	if userID := ctx.Value(ContextKeyUserID); userID != nil {
+	if userID := ctx.Value(contextKeyUserID); userID != nil {
 		if id, ok := userID.(string); ok {
 			logger = logger.With().Str("user_id", id).Logger()
 		}
 	}
 
-	if providerID := ctx.Value(ContextKeyProviderID); providerID != nil {
+	if providerID := ctx.Value(contextKeyProviderID); providerID != nil {
 		if id, ok := providerID.(string); ok {
 			logger = logger.With().Str("provider_id", id).Logger()
 		}
 	}
 
-	if capability := ctx.Value(ContextKeyCapability); capability != nil {
+	if capability := ctx.Value(contextKeyCapability); capability != nil {
 		if cap, ok := capability.(string); ok {
 			logger = logger.With().Str("capability", cap).Logger()
 		}
@@ -309,45 +308,76 @@ func parseLogLevel(level string) zerolog.Level {
 	}
 }
 
-// Default creates a logger with default configuration
+// isTerminal reports whether f is an interactive terminal; replaceable for tests
+var isTerminal = func(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// resolveFormat returns the configured format, or detects one when it is unset: readable console
+// output for development and interactive terminals, machine-parseable JSON everywhere else
+func resolveFormat(config Config) string {
+	if config.Format != "" {
+		return config.Format
+	}
+
+	switch strings.ToLower(config.Environment) {
+	case "development", "dev", "local":
+		return "console"
+	}
+	if isTerminal(os.Stdout) {
+		return "console"
+	}
+	return "json"
+}
+
+// EnvironmentVariable names the environment variable Default reads the deployment environment from
+const EnvironmentVariable = "ENVIRONMENT"
+
+// Default creates a logger with default configuration. The environment is read from
+// $ENVIRONMENT; when it is unset the format follows stdout: console on a terminal, JSON otherwise.
 func Default() Logger {
-	return New(Config{
+	return New(defaultConfig())
+}
+
+// defaultConfig returns the configuration Default uses
+func defaultConfig() Config {
+	return Config{
 		Level:        "info",
-		Format:       "json",
 		EnableCaller: false,
 		ServiceName:  "github.com/creastat/common-go",
-		Environment:  "development",
-	})
+		Environment:  os.Getenv(EnvironmentVariable),
+	}
 }
 
 // ContextWithRequestID adds a request ID to the context
 func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
-	return context.WithValue(ctx, ContextKeyRequestID, requestID)
+	return context.WithValue(ctx, contextKeyRequestID, requestID)
 }
 
 // ContextWithSessionID adds a session ID to the context
 func ContextWithSessionID(ctx context.Context, sessionID string) context.Context {
-	return context.WithValue(ctx, ContextKeySessionID, sessionID)
+	return context.WithValue(ctx, contextKeySessionID, sessionID)
 }
 
 // ContextWithUserID adds a user ID to the context
 func ContextWithUserID(ctx context.Context, userID string) context.Context {
-	return context.WithValue(ctx, ContextKeyUserID, userID)
+	return context.WithValue(ctx, contextKeyUserID, userID)
 }
 
 // ContextWithProviderID adds a provider ID to the context
 func ContextWithProviderID(ctx context.Context, providerID string) context.Context {
-	return context.WithValue(ctx, ContextKeyProviderID, providerID)
+	return context.WithValue(ctx, contextKeyProviderID, providerID)
 }
 
 // ContextWithCapability adds a capability to the context
 func ContextWithCapability(ctx context.Context, capability string) context.Context {
-	return context.WithValue(ctx, ContextKeyCapability, capability)
+	return context.WithValue(ctx, contextKeyCapability, capability)
 }
 
 // GetRequestIDFromContext extracts the request ID from context
 func GetRequestIDFromContext(ctx context.Context) string {
-	if requestID := ctx.Value(ContextKeyRequestID); requestID != nil {
+	if requestID := ctx.Value(contextKeyRequestID); requestID != nil {
 		if id, ok := requestID.(string); ok {
 			return id
 		}
@@ -357,10 +387,40 @@ func GetRequestIDFromContext(ctx context.Context) string {
 
 // GetSessionIDFromContext extracts the session ID from context
 func GetSessionIDFromContext(ctx context.Context) string {
-	if sessionID := ctx.Value(ContextKeySessionID); sessionID != nil {
+	if sessionID := ctx.Value(contextKeySessionID); sessionID != nil {
 		if id, ok := sessionID.(string); ok {
 			return id
 		}
 	}
 	return ""
 }
+
+// GetUserIDFromContext extracts the user ID from context
+func GetUserIDFromContext(ctx context.Context) string {
+	if userID := ctx.Value(contextKeyUserID); userID != nil {
+		if id, ok := userID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// GetProviderIDFromContext extracts the provider ID from context
+func GetProviderIDFromContext(ctx context.Context) string {
+	if providerID := ctx.Value(contextKeyProviderID); providerID != nil {
+		if id, ok := providerID.(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// GetCapabilityFromContext extracts the capability from context
+func GetCapabilityFromContext(ctx context.Context) string {
+	if capability := ctx.Value(contextKeyCapability); capability != nil {
+		if cap, ok := capability.(string); ok {
+			return cap
+		}
+	}
+	return ""
+}
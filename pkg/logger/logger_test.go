@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+// withTerminal makes stdout look like a terminal, or not, for the rest of the test
+func withTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+
+	original := isTerminal
+	isTerminal = func(*os.File) bool { return terminal }
+	t.Cleanup(func() { isTerminal = original })
+}
+
+func TestResolveFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		terminal bool
+		want     string
+	}{
+		{name: "development", config: Config{Environment: "development"}, want: "console"},
+		{name: "dev alias", config: Config{Environment: "Dev"}, want: "console"},
+		{name: "production", config: Config{Environment: "production"}, want: "json"},
+		{name: "production on a terminal", config: Config{Environment: "production"}, terminal: true, want: "console"},
+		{name: "no environment", want: "json"},
+		{name: "no environment on a terminal", terminal: true, want: "console"},
+		{name: "explicit format wins", config: Config{Environment: "development", Format: "json"}, terminal: true, want: "json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTerminal(t, tt.terminal)
+			if got := resolveFormat(tt.config); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultReadsEnvironment(t *testing.T) {
+	withTerminal(t, false)
+
+	t.Setenv(EnvironmentVariable, "production")
+	if config := defaultConfig(); config.Environment != "production" || resolveFormat(config) != "json" {
+		t.Errorf("production: got environment %q and format %q", config.Environment, resolveFormat(config))
+	}
+
+	t.Setenv(EnvironmentVariable, "development")
+	if format := resolveFormat(defaultConfig()); format != "console" {
+		t.Errorf("development: got format %q", format)
+	}
+
+	t.Setenv(EnvironmentVariable, "")
+	if config := defaultConfig(); config.Environment != "" || resolveFormat(config) != "json" {
+		t.Errorf("unset: got environment %q and format %q", config.Environment, resolveFormat(config))
+	}
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AudioEncoding is a canonical, provider-independent audio encoding
+type AudioEncoding string
+
+const (
+	AudioEncodingPCM16 AudioEncoding = "linear16" // 16-bit signed little-endian PCM
+	AudioEncodingPCM24 AudioEncoding = "linear24" // 24-bit signed little-endian PCM
+	AudioEncodingOpus  AudioEncoding = "opus"
+	AudioEncodingMP3   AudioEncoding = "mp3"
+	AudioEncodingFLAC  AudioEncoding = "flac"
+	AudioEncodingWAV   AudioEncoding = "wav"
+	AudioEncodingMulaw AudioEncoding = "mulaw"
+	AudioEncodingAlaw  AudioEncoding = "alaw"
+)
+
+// audioEncodingAliases maps accepted encoding names to their canonical encoding
+var audioEncodingAliases = map[string]AudioEncoding{
+	"linear16":  AudioEncodingPCM16,
+	"pcm_s16le": AudioEncodingPCM16,
+	"pcm":       AudioEncodingPCM16,
+	"raw":       AudioEncodingPCM16,
+	"linear24":  AudioEncodingPCM24,
+	"pcm_s24le": AudioEncodingPCM24,
+	"opus":      AudioEncodingOpus,
+	"ogg_opus":  AudioEncodingOpus,
+	"mp3":       AudioEncodingMP3,
+	"flac":      AudioEncodingFLAC,
+	"wav":       AudioEncodingWAV,
+	"mulaw":     AudioEncodingMulaw,
+	"ulaw":      AudioEncodingMulaw,
+	"pcm_mulaw": AudioEncodingMulaw,
+	"alaw":      AudioEncodingAlaw,
+	"pcm_alaw":  AudioEncodingAlaw,
+}
+
+// providerAudioEncodings maps canonical encodings to each provider's native value
+var providerAudioEncodings = map[ProviderType]map[AudioEncoding]string{
+	ProviderTypeDeepgram: {
+		AudioEncodingPCM16: "linear16",
+		AudioEncodingOpus:  "opus",
+		AudioEncodingFLAC:  "flac",
+		AudioEncodingMulaw: "mulaw",
+		AudioEncodingAlaw:  "alaw",
+	},
+	ProviderTypeCartesia: {
+		AudioEncodingPCM16: "pcm_s16le",
+		AudioEncodingMulaw: "pcm_mulaw",
+		AudioEncodingAlaw:  "pcm_alaw",
+	},
+	ProviderTypeYandex: {
+		AudioEncodingPCM16: "linear16",
+		AudioEncodingOpus:  "opus",
+	},
+	ProviderTypeMinimax: {
+		AudioEncodingPCM16: "pcm",
+		AudioEncodingMP3:   "mp3",
+		AudioEncodingFLAC:  "flac",
+		AudioEncodingWAV:   "wav",
+	},
+}
+
+// ParseAudioEncoding resolves an encoding name or alias to its canonical encoding
+func ParseAudioEncoding(name string) (AudioEncoding, error) {
+	encoding, ok := audioEncodingAliases[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", fmt.Errorf("unknown audio encoding: %q", name)
+	}
+	return encoding, nil
+}
+
+// ProviderValue returns the provider-native name for the encoding
+func (e AudioEncoding) ProviderValue(provider ProviderType) (string, error) {
+	value, ok := providerAudioEncodings[provider][e]
+	if !ok {
+		return "", fmt.Errorf("audio encoding %s is not supported by %s", e, provider)
+	}
+	return value, nil
+}
+
+// ResolveAudioFormat combines an encoding name with a bit depth and companding into a canonical
+// encoding name. Companding selects 8-bit G.711 audio; a bit depth of 16 or 24 selects linear PCM.
+// With neither set the encoding is returned unchanged. Contradicting combinations are rejected.
+func ResolveAudioFormat(encoding string, bitDepth int, companding string) (string, error) {
+	if bitDepth == 0 && companding == "" {
+		return encoding, nil
+	}
+
+	var requested AudioEncoding
+	if encoding != "" {
+		parsed, err := ParseAudioEncoding(encoding)
+		if err != nil {
+			return "", err
+		}
+		requested = parsed
+	}
+
+	if companding != "" {
+		companded, err := ParseAudioEncoding(companding)
+		if err != nil || (companded != AudioEncodingMulaw && companded != AudioEncodingAlaw) {
+			return "", fmt.Errorf("unknown companding: %q (supported: mulaw, alaw)", companding)
+		}
+		if bitDepth != 0 && bitDepth != 8 {
+			return "", fmt.Errorf("%s companding requires 8-bit audio, got %d-bit", companded, bitDepth)
+		}
+		if requested != "" && requested != companded {
+			return "", fmt.Errorf("%s companding conflicts with encoding %s", companded, requested)
+		}
+		return string(companded), nil
+	}
+
+	var linear AudioEncoding
+	switch bitDepth {
+	case 8:
+		if requested == AudioEncodingMulaw || requested == AudioEncodingAlaw {
+			return string(requested), nil
+		}
+		return "", fmt.Errorf("8-bit audio requires mulaw or alaw companding")
+	case 16:
+		linear = AudioEncodingPCM16
+	case 24:
+		linear = AudioEncodingPCM24
+	default:
+		return "", fmt.Errorf("unsupported bit depth: %d (supported: 8, 16, 24)", bitDepth)
+	}
+
+	switch requested {
+	case "", AudioEncodingPCM16, AudioEncodingPCM24:
+		return string(linear), nil
+	case AudioEncodingMulaw, AudioEncodingAlaw:
+		return "", fmt.Errorf("%s audio is 8-bit, got %d-bit", requested, bitDepth)
+	default:
+		// Compressed and container formats carry their own sample format
+		return string(requested), nil
+	}
+}
+
+// ResolveEncoding applies BitDepth and Companding to Encoding, using fallback when no encoding results
+func (c *TTSConfig) ResolveEncoding(fallback string) error {
+	return resolveEncoding(&c.Encoding, c.BitDepth, c.Companding, fallback)
+}
+
+// ResolveEncoding applies BitDepth and Companding to Encoding, using fallback when no encoding results
+func (c *STTConfig) ResolveEncoding(fallback string) error {
+	return resolveEncoding(&c.Encoding, c.BitDepth, c.Companding, fallback)
+}
+
+// resolveEncoding replaces encoding with its resolved format, or with fallback when that is empty
+func resolveEncoding(encoding *string, bitDepth int, companding, fallback string) error {
+	resolved, err := ResolveAudioFormat(*encoding, bitDepth, companding)
+	if err != nil {
+		return err
+	}
+	if resolved == "" {
+		resolved = fallback
+	}
+	*encoding = resolved
+	return nil
+}
+
+// ResolveProviderEncoding maps an encoding name or alias to the provider-native value
+func ResolveProviderEncoding(provider ProviderType, name string) (string, error) {
+	encoding, err := ParseAudioEncoding(name)
+	if err != nil {
+		return "", err
+	}
+	return encoding.ProviderValue(provider)
+}
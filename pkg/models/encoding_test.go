@@ -0,0 +1,89 @@
+package models
+
+import "testing"
+
+func TestResolveAudioFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		encoding   string
+		bitDepth   int
+		companding string
+		want       string
+		wantErr    bool
+	}{
+		{name: "nothing set", encoding: "opus", want: "opus"},
+		{name: "companding alone", companding: "mulaw", want: "mulaw"},
+		{name: "companding alias", bitDepth: 8, companding: "ulaw", want: "mulaw"},
+		{name: "companding matching encoding", encoding: "pcm_alaw", companding: "alaw", want: "alaw"},
+		{name: "24-bit linear", encoding: "linear16", bitDepth: 24, want: "linear24"},
+		{name: "bit depth alone", bitDepth: 16, want: "linear16"},
+		{name: "container keeps its format", encoding: "flac", bitDepth: 24, want: "flac"},
+		{name: "8-bit mulaw", encoding: "mulaw", bitDepth: 8, want: "mulaw"},
+		{name: "linear16 with companding", encoding: "linear16", companding: "mulaw", wantErr: true},
+		{name: "raw PCM with companding", encoding: "raw", companding: "alaw", wantErr: true},
+		{name: "companding other than G.711", companding: "opus", wantErr: true},
+		{name: "16-bit companding", bitDepth: 16, companding: "mulaw", wantErr: true},
+		{name: "8-bit without companding", bitDepth: 8, wantErr: true},
+		{name: "16-bit mulaw", encoding: "mulaw", bitDepth: 16, wantErr: true},
+		{name: "unsupported bit depth", bitDepth: 12, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveAudioFormat(tt.encoding, tt.bitDepth, tt.companding)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSTTConfigResolvesTelephonyAudio(t *testing.T) {
+	// 8 kHz mu-law as delivered by telephony gateways
+	tests := []struct {
+		provider ProviderType
+		want     string
+	}{
+		{provider: ProviderTypeDeepgram, want: "mulaw"},
+		{provider: ProviderTypeCartesia, want: "pcm_mulaw"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.provider), func(t *testing.T) {
+			config := STTConfig{SampleRate: 8000, BitDepth: 8, Companding: "mulaw"}
+			if err := config.ResolveEncoding(string(AudioEncodingPCM16)); err != nil {
+				t.Fatalf("ResolveEncoding: %v", err)
+			}
+			if config.Encoding != string(AudioEncodingMulaw) || config.SampleRate != 8000 {
+				t.Fatalf("resolved to %s at %d Hz", config.Encoding, config.SampleRate)
+			}
+
+			native, err := ResolveProviderEncoding(tt.provider, config.Encoding)
+			if err != nil || native != tt.want {
+				t.Errorf("got %q, %v; want %q", native, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ResolveProviderEncoding(ProviderTypeYandex, string(AudioEncodingMulaw)); err == nil {
+		t.Error("expected Yandex to have no native mu-law input")
+	}
+}
+
+func TestResolveEncodingFallback(t *testing.T) {
+	config := TTSConfig{}
+	if err := config.ResolveEncoding("mp3"); err != nil || config.Encoding != "mp3" {
+		t.Errorf("got %q, %v; want the fallback", config.Encoding, err)
+	}
+
+	config = TTSConfig{Encoding: "linear16", Companding: "mulaw"}
+	if err := config.ResolveEncoding("mp3"); err == nil {
+		t.Error("expected linear16 with mulaw companding to be rejected")
+	}
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxModelSuggestions caps the model IDs suggested by a ModelNotFoundError
+const maxModelSuggestions = 3
+
+// ErrorClass groups provider failures by how callers should react to them. The values can be
+// listed in FallbackConfig.Conditions.
+type ErrorClass string
+
+const (
+	ErrorClassUnknown        ErrorClass = "unknown"
+	ErrorClassTransient      ErrorClass = "transient"
+	ErrorClassRateLimited    ErrorClass = "rate_limited"
+	ErrorClassAuth           ErrorClass = "auth"
+	ErrorClassInvalidRequest ErrorClass = "invalid_request"
+	ErrorClassNotFound       ErrorClass = "not_found"
+	ErrorClassCanceled       ErrorClass = "canceled"
+)
+
+// Retryable reports whether an error of this class may succeed when the call is repeated
+func (c ErrorClass) Retryable() bool {
+	return c == ErrorClassTransient || c == ErrorClassRateLimited
+}
+
+// ClassifiedError tags a provider error with its class
+type ClassifiedError struct {
+	Class ErrorClass
+	Err   error
+}
+
+// NewClassifiedError tags err with class. It returns nil when err is nil.
+func NewClassifiedError(class ErrorClass, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ClassifiedError{Class: class, Err: err}
+}
+
+func (e *ClassifiedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError returns the class of the first ClassifiedError in err's chain. Context
+// cancellation and deadlines are classified as canceled and transient respectively.
+func ClassifyError(err error) ErrorClass {
+	var classified *ClassifiedError
+	switch {
+	case err == nil:
+		return ErrorClassUnknown
+	case errors.As(err, &classified):
+		return classified.Class
+	case errors.Is(err, ErrModelNotFound):
+		return ErrorClassNotFound
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTransient
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+// ErrModelNotFound matches any ModelNotFoundError with errors.Is
+var ErrModelNotFound = errors.New("model not found")
+
+// ModelNotFoundError reports a model the provider does not offer, with the closest known model IDs
+type ModelNotFoundError struct {
+	Provider    string
+	Model       string
+	Suggestions []string
+}
+
+// NewModelNotFoundError builds a ModelNotFoundError suggesting the known models closest to model
+func NewModelNotFoundError(provider, model string, known []string) *ModelNotFoundError {
+	return &ModelNotFoundError{Provider: provider, Model: model, Suggestions: suggestModels(model, known)}
+}
+
+func (e *ModelNotFoundError) Error() string {
+	msg := fmt.Sprintf("model %s not found for provider %s", e.Model, e.Provider)
+	if len(e.Suggestions) > 0 {
+		msg += "; did you mean " + strings.Join(e.Suggestions, ", ") + "?"
+	}
+	return msg
+}
+
+// Is reports whether target is ErrModelNotFound
+func (e *ModelNotFoundError) Is(target error) bool {
+	return target == ErrModelNotFound
+}
+
+// suggestModels returns up to maxModelSuggestions known IDs that contain model or are within a
+// small edit distance of it, closest first
+func suggestModels(model string, known []string) []string {
+	type candidate struct {
+		id       string
+		distance int
+	}
+
+	model = strings.ToLower(model)
+	limit := max(3, len(model)/3)
+
+	var candidates []candidate
+	for _, id := range known {
+		lower := strings.ToLower(id)
+		distance := editDistance(model, lower)
+		if distance <= limit || strings.Contains(lower, model) || strings.Contains(model, lower) {
+			candidates = append(candidates, candidate{id: id, distance: distance})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return a.distance - b.distance
+	})
+
+	suggestions := make([]string, 0, min(len(candidates), maxModelSuggestions))
+	for _, c := range candidates {
+		if len(suggestions) == maxModelSuggestions {
+			break
+		}
+		if !slices.Contains(suggestions, c.id) {
+			suggestions = append(suggestions, c.id)
+		}
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b, counted in bytes
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,27 @@
+package models
+
+import "strings"
+
+// NormalizeLanguageCode reduces a language tag such as "ru-RU" or "en_US" to its lowercase primary subtag
+func NormalizeLanguageCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if idx := strings.IndexAny(code, "-_"); idx >= 0 {
+		code = code[:idx]
+	}
+	return code
+}
+
+// NormalizeLanguages normalizes each language tag and removes duplicates, preserving order
+func NormalizeLanguages(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	normalized := make([]string, 0, len(codes))
+	for _, code := range codes {
+		code = NormalizeLanguageCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		normalized = append(normalized, code)
+	}
+	return normalized
+}
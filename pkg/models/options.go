@@ -0,0 +1,53 @@
+package models
+
+import "sort"
+
+// OptionScope names the Options map an option is read from
+type OptionScope string
+
+const (
+	OptionScopeProvider OptionScope = "provider" // ProviderConfig.Options
+	OptionScopeSTT      OptionScope = "stt"      // STTConfig.Options
+	OptionScopeTTS      OptionScope = "tts"      // TTSConfig.Options
+	OptionScopeChat     OptionScope = "chat"     // the options passed to chat completion calls
+)
+
+// OptionType is the expected type of an option value
+type OptionType string
+
+const (
+	OptionTypeBool       OptionType = "bool"
+	OptionTypeInt        OptionType = "int"
+	OptionTypeNumber     OptionType = "number" // int or float
+	OptionTypeString     OptionType = "string"
+	OptionTypeStringList OptionType = "string_list"
+	OptionTypeMap        OptionType = "map"
+	OptionTypeAny        OptionType = "any" // the option accepts several types, see its description
+)
+
+// OptionDescriptor describes an Options key a provider honors
+type OptionDescriptor struct {
+	Name        string      `json:"name"`
+	Type        OptionType  `json:"type"`
+	Scope       OptionScope `json:"scope"`
+	Description string      `json:"description"`
+}
+
+// UnknownOptions returns the sorted keys of options that no descriptor for scope describes
+func UnknownOptions(descriptors []OptionDescriptor, scope OptionScope, options map[string]any) []string {
+	known := make(map[string]bool, len(descriptors))
+	for _, descriptor := range descriptors {
+		if descriptor.Scope == scope {
+			known[descriptor.Name] = true
+		}
+	}
+
+	var unknown []string
+	for key := range options {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -66,6 +66,25 @@ type ModelPricing struct {
 	Currency   string  `json:"currency"`    // USD, EUR, etc.
 }
 
+// UnknownCurrency is the currency EstimateCost reports for models without pricing
+const UnknownCurrency = "unknown"
+
+// EstimateCost returns the cost of a request with the given token counts and its currency.
+// Models without pricing cost zero in UnknownCurrency so callers can detect them.
+func (m Model) EstimateCost(inputTokens, outputTokens int) (float64, string) {
+	if m.Pricing == nil {
+		return 0, UnknownCurrency
+	}
+
+	cost := float64(inputTokens)/1000*m.Pricing.InputCost + float64(outputTokens)/1000*m.Pricing.OutputCost
+
+	currency := m.Pricing.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+	return cost, currency
+}
+
 // ProviderCapabilities represents the capabilities of a provider
 type ProviderCapabilities struct {
 	Chat      *ChatCapability      `json:"chat,omitempty"`
@@ -74,6 +93,25 @@ type ProviderCapabilities struct {
 	TTS       *TTSCapability       `json:"tts,omitempty"`
 }
 
+// SupportsStreaming reports whether the given capability is available as a stream.
+// Embeddings never stream.
+func (c *ProviderCapabilities) SupportsStreaming(capability Capability) bool {
+	if c == nil {
+		return false
+	}
+
+	switch capability {
+	case CapabilityChat:
+		return c.Chat != nil && c.Chat.Streaming
+	case CapabilitySTT:
+		return c.STT != nil && c.STT.Streaming
+	case CapabilityTTS:
+		return c.TTS != nil && c.TTS.Streaming
+	default:
+		return false
+	}
+}
+
 // ChatCapability represents chat-specific capabilities
 type ChatCapability struct {
 	Streaming        bool     `json:"streaming"`
@@ -120,24 +158,24 @@ const (
 
 // ProviderConfig represents configuration for a provider
 type ProviderConfig struct {
-	Name        string         `json:"name"`
-	Type        ProviderType   `json:"type"`
-	APIKey      string         `json:"api_key,omitempty"`
-	BaseURL     string         `json:"base_url,omitempty"`
-	Model       string         `json:"model,omitempty"`
-	Options     map[string]any `json:"options,omitempty"`
-	Timeout     time.Duration  `json:"timeout,omitempty"`
-	RetryPolicy *RetryPolicy   `json:"retry_policy,omitempty"`
-	Enabled     bool           `json:"enabled"`
+	Name        string         `yaml:"name" json:"name"`
+	Type        ProviderType   `yaml:"type" json:"type"`
+	APIKey      string         `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	BaseURL     string         `yaml:"base_url,omitempty" json:"base_url,omitempty"`
+	Model       string         `yaml:"model,omitempty" json:"model,omitempty"`
+	Options     map[string]any `yaml:"options,omitempty" json:"options,omitempty"`
+	Timeout     time.Duration  `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	RetryPolicy *RetryPolicy   `yaml:"retry_policy,omitempty" json:"retry_policy,omitempty"`
+	Enabled     bool           `yaml:"enabled" json:"enabled"`
 }
 
 // RetryPolicy defines retry behavior for provider calls
 type RetryPolicy struct {
-	MaxAttempts     int           `json:"max_attempts"`
-	InitialDelay    time.Duration `json:"initial_delay"`
-	MaxDelay        time.Duration `json:"max_delay"`
-	BackoffFactor   float64       `json:"backoff_factor"`
-	RetryableErrors []string      `json:"retryable_errors,omitempty"`
+	MaxAttempts     int           `yaml:"max_attempts" json:"max_attempts"`
+	InitialDelay    time.Duration `yaml:"initial_delay" json:"initial_delay"`
+	MaxDelay        time.Duration `yaml:"max_delay" json:"max_delay"`
+	BackoffFactor   float64       `yaml:"backoff_factor" json:"backoff_factor"`
+	RetryableErrors []string      `yaml:"retryable_errors,omitempty" json:"retryable_errors,omitempty"`
 }
 
 // FallbackConfig defines fallback behavior between providers
@@ -191,6 +229,18 @@ func (pi *ProviderInfo) GetModels(capability Capability) []Model {
 	return pi.Models[string(capability)]
 }
 
+// FindModel returns the model with the given ID, searching every capability
+func (pi *ProviderInfo) FindModel(id string) (Model, bool) {
+	for _, models := range pi.Models {
+		for _, model := range models {
+			if model.ID == id {
+				return model, true
+			}
+		}
+	}
+	return Model{}, false
+}
+
 // HasCapability checks if the provider has a specific capability
 func (pi *ProviderInfo) HasCapability(capability Capability) bool {
 	for _, cap := range pi.Capabilities {
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"context"
+	"sync"
+
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// ResponseMetadata reports which provider and model actually served a request, and the tool
+// calls and token usage of a chat response. Attach it to a context with WithResponseMetadata before
+// calling a service; providers fill it in once the response is known.
+type ResponseMetadata struct {
+	mu        sync.RWMutex
+	provider  string
+	model     string
+	toolCalls []types.ToolCall
+	usage     *TokenUsage
+}
+
+type responseMetadataKey struct{}
+
+// WithResponseMetadata returns a context that collects response metadata
+func WithResponseMetadata(ctx context.Context) (context.Context, *ResponseMetadata) {
+	metadata := &ResponseMetadata{}
+	return context.WithValue(ctx, responseMetadataKey{}, metadata), metadata
+}
+
+// RecordResponseMetadata stores the effective provider and model on the context, if it collects them.
+// A different provider, such as a fallback after a failed attempt, replaces everything recorded so far.
+func RecordResponseMetadata(ctx context.Context, provider, model string) {
+	metadata, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	if !ok {
+		return
+	}
+
+	metadata.mu.Lock()
+	defer metadata.mu.Unlock()
+
+	if metadata.provider != provider {
+		metadata.model = ""
+		metadata.toolCalls = nil
+		metadata.usage = nil
+	}
+	metadata.provider = provider
+	if model != "" {
+		metadata.model = model
+	}
+}
+
+// RecordToolCalls stores the complete tool calls of a chat response on the context, if it collects them
+func RecordToolCalls(ctx context.Context, toolCalls []types.ToolCall) {
+	metadata, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	if !ok {
+		return
+	}
+
+	metadata.mu.Lock()
+	defer metadata.mu.Unlock()
+
+	metadata.toolCalls = append([]types.ToolCall(nil), toolCalls...)
+}
+
+// RecordUsage stores the token usage of a chat response on the context, if it collects it
+func RecordUsage(ctx context.Context, usage *TokenUsage) {
+	metadata, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	if !ok {
+		return
+	}
+
+	metadata.mu.Lock()
+	defer metadata.mu.Unlock()
+
+	metadata.usage = usage
+}
+
+// Provider returns the name of the provider that served the request
+func (m *ResponseMetadata) Provider() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.provider
+}
+
+// Model returns the resolved model ID that served the request
+func (m *ResponseMetadata) Model() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.model
+}
+
+// ToolCalls returns the tool calls the chat response requested, with their arguments assembled
+func (m *ResponseMetadata) ToolCalls() []types.ToolCall {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]types.ToolCall(nil), m.toolCalls...)
+}
+
+// Usage returns the token usage the provider reported, or nil when it reported none
+func (m *ResponseMetadata) Usage() *TokenUsage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.usage
+}
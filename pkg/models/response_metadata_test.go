@@ -0,0 +1,41 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/types"
+)
+
+func TestResponseMetadataAfterFallback(t *testing.T) {
+	ctx, metadata := WithResponseMetadata(context.Background())
+
+	// The primary starts a response, then fails and the fallback serves the request
+	RecordResponseMetadata(ctx, "openai", "gpt-4o-2024-08-06")
+	RecordToolCalls(ctx, []types.ToolCall{{ID: "call_1", Name: "lookup"}})
+	RecordUsage(ctx, &TokenUsage{PromptTokens: 10, TotalTokens: 10})
+	RecordResponseMetadata(ctx, "gemini", "")
+
+	if metadata.Provider() != "gemini" {
+		t.Errorf("expected the fallback provider, got %q", metadata.Provider())
+	}
+	if metadata.Model() != "" {
+		t.Errorf("expected no model from the failed primary, got %q", metadata.Model())
+	}
+	if calls := metadata.ToolCalls(); len(calls) != 0 {
+		t.Errorf("expected no tool calls from the failed primary, got %v", calls)
+	}
+	if usage := metadata.Usage(); usage != nil {
+		t.Errorf("expected no usage from the failed primary, got %+v", usage)
+	}
+
+	// The same provider refining its model keeps what it recorded before
+	RecordResponseMetadata(ctx, "gemini", "gemini-2.0-flash")
+	RecordResponseMetadata(ctx, "gemini", "")
+	if metadata.Model() != "gemini-2.0-flash" {
+		t.Errorf("expected the resolved model to be kept, got %q", metadata.Model())
+	}
+
+	// Contexts without metadata are ignored
+	RecordResponseMetadata(context.Background(), "openai", "gpt-4o")
+}
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // TTSConfig represents TTS configuration
@@ -12,6 +14,8 @@ type TTSConfig struct {
 	Model      string         `json:"model,omitempty"`
 	SampleRate int            `json:"sample_rate,omitempty"`
 	Encoding   string         `json:"encoding,omitempty"`
+	BitDepth   int            `json:"bit_depth,omitempty"`  // Bits per sample; 8 requires Companding
+	Companding string         `json:"companding,omitempty"` // "mulaw" or "alaw" for 8-bit telephony audio
 	Speed      float64        `json:"speed,omitempty"`
 	Volume     float64        `json:"volume,omitempty"`
 	Pitch      float64        `json:"pitch,omitempty"`
@@ -24,24 +28,28 @@ type STTConfig struct {
 	Model              string         `json:"model,omitempty"`
 	SampleRate         int            `json:"sample_rate,omitempty"`
 	Encoding           string         `json:"encoding,omitempty"`
+	BitDepth           int            `json:"bit_depth,omitempty"`  // Bits per sample; 8 requires Companding
+	Companding         string         `json:"companding,omitempty"` // "mulaw" or "alaw" for 8-bit telephony audio
 	Channels           int            `json:"channels,omitempty"`
 	InterimResults     bool           `json:"interim_results,omitempty"`
 	PunctuationEnabled bool           `json:"punctuation_enabled,omitempty"`
+	ContextPhrases     []string       `json:"context_phrases,omitempty"` // Terms to bias recognition toward
 	Options            map[string]any `json:"options,omitempty"`
 }
 
 // STTResult represents a speech-to-text result
 type STTResult struct {
-	Text       string         `json:"text"`
-	Confidence float64        `json:"confidence"`
-	IsFinal    bool           `json:"is_final"`
-	Language   string         `json:"language,omitempty"`
-	Duration   float64        `json:"duration,omitempty"`
-	Timestamp  time.Time      `json:"timestamp"`
-	StartTime  float64        `json:"start_time,omitempty"`
-	EndTime    float64        `json:"end_time,omitempty"`
-	Words      []WordInfo     `json:"words,omitempty"`
-	Metadata   map[string]any `json:"metadata,omitempty"`
+	Text             string         `json:"text"`
+	Confidence       float64        `json:"confidence"`
+	IsFinal          bool           `json:"is_final"`
+	Language         string         `json:"language,omitempty"`
+	DetectedLanguage string         `json:"detected_language,omitempty"` // Language the provider recognized in this utterance
+	Duration         float64        `json:"duration,omitempty"`
+	Timestamp        time.Time      `json:"timestamp"`
+	StartTime        float64        `json:"start_time,omitempty"`
+	EndTime          float64        `json:"end_time,omitempty"`
+	Words            []WordInfo     `json:"words,omitempty"`
+	Metadata         map[string]any `json:"metadata,omitempty"`
 }
 
 // WordInfo represents information about a single word in STT result
@@ -50,6 +58,74 @@ type WordInfo struct {
 	StartTime  float64 `json:"start_time"`
 	EndTime    float64 `json:"end_time"`
 	Confidence float64 `json:"confidence"`
+	Speaker    int     `json:"speaker"` // Speaker index when diarization is enabled, otherwise 0
+}
+
+// AbsoluteStart returns the wall-clock time the word started, given when the stream started
+func (w WordInfo) AbsoluteStart(streamStart time.Time) time.Time {
+	return offsetTime(streamStart, w.StartTime)
+}
+
+// AbsoluteEnd returns the wall-clock time the word ended, given when the stream started
+func (w WordInfo) AbsoluteEnd(streamStart time.Time) time.Time {
+	return offsetTime(streamStart, w.EndTime)
+}
+
+// AbsoluteStart returns the wall-clock time the result started, given when the stream started
+func (r *STTResult) AbsoluteStart(streamStart time.Time) time.Time {
+	return offsetTime(streamStart, r.StartTime)
+}
+
+// AbsoluteEnd returns the wall-clock time the result ended, given when the stream started
+func (r *STTResult) AbsoluteEnd(streamStart time.Time) time.Time {
+	return offsetTime(streamStart, r.EndTime)
+}
+
+// WordsBySpeaker groups the result's words by speaker, keeping each speaker's words in order
+func (r *STTResult) WordsBySpeaker() map[int][]WordInfo {
+	groups := make(map[int][]WordInfo)
+	for _, word := range r.Words {
+		groups[word.Speaker] = append(groups[word.Speaker], word)
+	}
+	return groups
+}
+
+// MergeWordTimelines stitches the words of several results into one monotonic timeline.
+// Each result's word times are shifted by its offset in seconds; results without an offset
+// continue where the previous one ended. A segment whose shifted words would start before the
+// timeline so far (e.g. timestamps reset by a reconnect) is moved to start at its end, and
+// overlapping words within a segment are clamped so start and end times never go backwards.
+func MergeWordTimelines(results []*STTResult, offsets []float64) []WordInfo {
+	var merged []WordInfo
+	var timelineEnd float64
+
+	for i, result := range results {
+		if result == nil || len(result.Words) == 0 {
+			continue
+		}
+
+		shift := timelineEnd
+		if i < len(offsets) {
+			shift = offsets[i]
+		}
+		if first := result.Words[0].StartTime + shift; first < timelineEnd {
+			shift += timelineEnd - first
+		}
+
+		for _, word := range result.Words {
+			word.StartTime = max(word.StartTime+shift, timelineEnd)
+			word.EndTime = max(word.EndTime+shift, word.StartTime)
+			timelineEnd = word.EndTime
+			merged = append(merged, word)
+		}
+	}
+
+	return merged
+}
+
+// offsetTime converts an offset in seconds from the stream start to a wall-clock time
+func offsetTime(streamStart time.Time, seconds float64) time.Time {
+	return streamStart.Add(time.Duration(seconds * float64(time.Second)))
 }
 
 // Voice represents a TTS voice
@@ -62,3 +138,49 @@ type Voice struct {
 	SampleRate  int      `json:"sample_rate,omitempty"`
 	Styles      []string `json:"styles,omitempty"`
 }
+
+const (
+	// ttsWordsPerMinute is the average speaking rate used for duration estimates at speed 1.0
+	ttsWordsPerMinute = 150.0
+
+	// defaultPCMBitDepth is the sample width assumed when none is given
+	defaultPCMBitDepth = 16
+)
+
+// EstimateTTSUsage returns the billable character count and an estimated audio
+// duration in seconds for synthesizing text with the given config.
+// The duration uses a words-per-minute heuristic adjusted by config.Speed.
+func EstimateTTSUsage(text string, config TTSConfig) (chars int, estSeconds float64) {
+	chars = utf8.RuneCountInString(text)
+
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return chars, 0
+	}
+
+	speed := config.Speed
+	if speed <= 0 {
+		speed = 1.0
+	}
+
+	estSeconds = float64(words) / ttsWordsPerMinute * 60.0 / speed
+	return chars, estSeconds
+}
+
+// PCMDuration returns the duration in seconds of PCM audio sampled at the given rate. A bitDepth
+// of 0 means 16 bits and a channels count of 0 means mono; companded audio has a bit depth of 8.
+func PCMDuration(audio []byte, sampleRate, bitDepth, channels int) float64 {
+	if bitDepth <= 0 {
+		bitDepth = defaultPCMBitDepth
+	}
+	if channels <= 0 {
+		channels = 1
+	}
+	if sampleRate <= 0 || len(audio) == 0 || bitDepth%8 != 0 {
+		return 0
+	}
+
+	frameBytes := bitDepth / 8 * channels
+	frames := len(audio) / frameBytes
+	return float64(frames) / float64(sampleRate)
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAbsoluteTimestamps(t *testing.T) {
+	streamStart := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	result := &STTResult{
+		StartTime: 1.25,
+		EndTime:   2.5,
+		Words: []WordInfo{
+			{Word: "hello", StartTime: 1.25, EndTime: 1.75},
+			{Word: "world", StartTime: 2.001, EndTime: 2.5},
+		},
+	}
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"result start", result.AbsoluteStart(streamStart), streamStart.Add(1250 * time.Millisecond)},
+		{"result end", result.AbsoluteEnd(streamStart), streamStart.Add(2500 * time.Millisecond)},
+		{"first word start", result.Words[0].AbsoluteStart(streamStart), streamStart.Add(1250 * time.Millisecond)},
+		{"first word end", result.Words[0].AbsoluteEnd(streamStart), streamStart.Add(1750 * time.Millisecond)},
+		{"millisecond offset", result.Words[1].AbsoluteStart(streamStart), streamStart.Add(2001 * time.Millisecond)},
+		{"zero offset is the stream start", WordInfo{}.AbsoluteStart(streamStart), streamStart},
+	}
+	for _, tt := range tests {
+		if diff := tt.got.Sub(tt.want); diff < -time.Microsecond || diff > time.Microsecond {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestPCMDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		bytes      int
+		sampleRate int
+		bitDepth   int
+		channels   int
+		want       float64
+	}{
+		{name: "16-bit mono default", bytes: 32000, sampleRate: 16000, want: 1},
+		{name: "16-bit stereo", bytes: 32000, sampleRate: 16000, bitDepth: 16, channels: 2, want: 0.5},
+		{name: "8-bit telephony", bytes: 8000, sampleRate: 8000, bitDepth: 8, channels: 1, want: 1},
+		{name: "24-bit mono", bytes: 144000, sampleRate: 48000, bitDepth: 24, want: 1},
+		{name: "partial frame ignored", bytes: 32001, sampleRate: 16000, want: 1},
+		{name: "no sample rate", bytes: 32000, want: 0},
+		{name: "empty", bytes: 0, sampleRate: 16000, want: 0},
+		{name: "unaligned bit depth", bytes: 32000, sampleRate: 16000, bitDepth: 12, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PCMDuration(make([]byte, tt.bytes), tt.sampleRate, tt.bitDepth, tt.channels); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateTTSUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		speed   float64
+		chars   int
+		seconds float64
+	}{
+		// 150 words per minute at normal speed
+		{name: "normal speed", text: strings.Repeat("word ", 150), chars: 750, seconds: 60},
+		{name: "double speed", text: strings.Repeat("word ", 150), speed: 2, chars: 750, seconds: 30},
+		{name: "runes not bytes", text: "привет мир", chars: 10, seconds: 0.8},
+		{name: "blank", text: "   ", chars: 3, seconds: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chars, seconds := EstimateTTSUsage(tt.text, TTSConfig{Speed: tt.speed})
+			if chars != tt.chars {
+				t.Errorf("chars: got %d, want %d", chars, tt.chars)
+			}
+			if math.Abs(seconds-tt.seconds) > 1e-9 {
+				t.Errorf("seconds: got %v, want %v", seconds, tt.seconds)
+			}
+		})
+	}
+}
+
+func TestMergeWordTimelines(t *testing.T) {
+	words := func(times ...float64) *STTResult {
+		result := &STTResult{}
+		for i := 0; i+1 < len(times); i += 2 {
+			result.Words = append(result.Words, WordInfo{Word: "w", StartTime: times[i], EndTime: times[i+1]})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name    string
+		results []*STTResult
+		offsets []float64
+		want    []float64 // start and end of each merged word
+	}{
+		{
+			name:    "offsets shift each result",
+			results: []*STTResult{words(0, 0.5, 0.5, 1), words(0, 0.4)},
+			offsets: []float64{0, 2},
+			want:    []float64{0, 0.5, 0.5, 1, 2, 2.4},
+		},
+		{
+			name:    "missing offset continues the timeline",
+			results: []*STTResult{words(0, 1), words(0.1, 0.4)},
+			offsets: []float64{0},
+			want:    []float64{0, 1, 1.1, 1.4},
+		},
+		{
+			name:    "reset timestamps move after the timeline",
+			results: []*STTResult{words(0, 1), words(0, 0.4, 0.5, 0.9)},
+			offsets: []float64{0, 0.5},
+			want:    []float64{0, 1, 1, 1.4, 1.5, 1.9},
+		},
+		{
+			name:    "overlapping words are clamped",
+			results: []*STTResult{words(0, 1, 0.8, 1.2, 1.1, 1)},
+			want:    []float64{0, 1, 1, 1.2, 1.2, 1.2},
+		},
+		{
+			name:    "results without words keep their offsets aligned",
+			results: []*STTResult{nil, {}, words(0, 0.4)},
+			offsets: []float64{5, 6, 2},
+			want:    []float64{2, 2.4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := MergeWordTimelines(tt.results, tt.offsets)
+			if len(merged)*2 != len(tt.want) {
+				t.Fatalf("expected %d words, got %v", len(tt.want)/2, merged)
+			}
+
+			var previousEnd float64
+			for i, word := range merged {
+				if math.Abs(word.StartTime-tt.want[2*i]) > 1e-9 || math.Abs(word.EndTime-tt.want[2*i+1]) > 1e-9 {
+					t.Errorf("word %d: got %v-%v, want %v-%v", i, word.StartTime, word.EndTime, tt.want[2*i], tt.want[2*i+1])
+				}
+				if word.StartTime < previousEnd || word.EndTime < word.StartTime {
+					t.Errorf("word %d goes backwards: %v-%v after %v", i, word.StartTime, word.EndTime, previousEnd)
+				}
+				previousEnd = word.EndTime
+			}
+		})
+	}
+
+	// The input results are left unchanged
+	result := words(0, 1)
+	MergeWordTimelines([]*STTResult{result}, []float64{3})
+	if result.Words[0].StartTime != 0 {
+		t.Errorf("the input was modified: %v", result.Words)
+	}
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// CapabilityTimeout returns the timeout configured for a capability via Options["<capability>_timeout"]
+// (e.g. "chat_timeout"), or 0 when none is set. Values may be a time.Duration, a duration string
+// such as "30s", or a number of seconds.
+func (c ProviderConfig) CapabilityTimeout(capability Capability) time.Duration {
+	switch v := c.Options[string(capability)+"_timeout"].(type) {
+	case time.Duration:
+		return v
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0
+		}
+		return d
+	case int:
+		return time.Duration(v) * time.Second
+	case float64:
+		return time.Duration(v * float64(time.Second))
+	default:
+		return 0
+	}
+}
+
+// CapabilityTimeoutOption describes the "<capability>_timeout" provider option read by CapabilityTimeout
+func CapabilityTimeoutOption(capability Capability) OptionDescriptor {
+	return OptionDescriptor{
+		Name:        string(capability) + "_timeout",
+		Type:        OptionTypeAny,
+		Scope:       OptionScopeProvider,
+		Description: "timeout of each " + string(capability) + " call, as a duration string such as \"30s\" or seconds",
+	}
+}
+
+// WithCapabilityTimeout bounds ctx by the capability's configured timeout when the caller has not set a deadline.
+// The returned cancel func must always be called.
+func WithCapabilityTimeout(ctx context.Context, config ProviderConfig, capability Capability) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, func() {}
+	}
+
+	timeout := config.CapabilityTimeout(capability)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
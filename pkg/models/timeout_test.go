@@ -0,0 +1,60 @@
+package models
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCapabilityTimeout(t *testing.T) {
+	config := ProviderConfig{Options: map[string]any{
+		"chat_timeout":      2 * time.Minute,
+		"embedding_timeout": "5s",
+		"stt_timeout":       30,
+		"tts_timeout":       1.5,
+	}}
+
+	tests := map[Capability]time.Duration{
+		CapabilityChat:      2 * time.Minute,
+		CapabilityEmbedding: 5 * time.Second,
+		CapabilitySTT:       30 * time.Second,
+		CapabilityTTS:       1500 * time.Millisecond,
+	}
+	for capability, want := range tests {
+		if got := config.CapabilityTimeout(capability); got != want {
+			t.Errorf("%s: got %v, want %v", capability, got, want)
+		}
+	}
+
+	if got := (ProviderConfig{Options: map[string]any{"chat_timeout": "soon"}}).CapabilityTimeout(CapabilityChat); got != 0 {
+		t.Errorf("expected an unparsable timeout to be ignored, got %v", got)
+	}
+}
+
+func TestWithCapabilityTimeout(t *testing.T) {
+	config := ProviderConfig{Options: map[string]any{"chat_timeout": "1h", "tts_timeout": "1m"}}
+
+	ctx, cancel := WithCapabilityTimeout(context.Background(), config, CapabilityTTS)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("expected the 1m TTS timeout, got deadline in %v", time.Until(deadline))
+	}
+
+	ctx, cancel = WithCapabilityTimeout(context.Background(), config, CapabilitySTT)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline for a capability without a timeout")
+	}
+
+	// A deadline set by the caller wins over the configured timeout
+	callerCtx, callerCancel := context.WithTimeout(context.Background(), time.Second)
+	defer callerCancel()
+	ctx, cancel = WithCapabilityTimeout(callerCtx, config, CapabilityChat)
+	defer cancel()
+	deadline, _ = ctx.Deadline()
+	callerDeadline, _ := callerCtx.Deadline()
+	if !deadline.Equal(callerDeadline) {
+		t.Errorf("expected the caller's deadline to be kept, got %v instead of %v", deadline, callerDeadline)
+	}
+}
@@ -0,0 +1,80 @@
+package models
+
+import "strings"
+
+// VoiceCriteria describes the constraints a voice must satisfy; zero-valued fields match any voice
+type VoiceCriteria struct {
+	Language      string // Matched on the primary subtag, so "en" matches "en-US"
+	Gender        string // Case-insensitive
+	MinSampleRate int    // Voices with an unknown sample rate never satisfy a sample rate bound
+	MaxSampleRate int
+	Style         string // Case-insensitive
+}
+
+// SelectVoice returns the first voice, in input order, that satisfies the criteria
+func SelectVoice(voices []Voice, criteria VoiceCriteria) (Voice, bool) {
+	for _, voice := range voices {
+		if criteria.Matches(voice) {
+			return voice, true
+		}
+	}
+	return Voice{}, false
+}
+
+// Matches reports whether a voice satisfies the criteria
+func (c VoiceCriteria) Matches(voice Voice) bool {
+	if c.Language != "" && NormalizeLanguageCode(voice.Language) != NormalizeLanguageCode(c.Language) {
+		return false
+	}
+	if c.Gender != "" && !strings.EqualFold(voice.Gender, c.Gender) {
+		return false
+	}
+	if (c.MinSampleRate > 0 || c.MaxSampleRate > 0) && voice.SampleRate == 0 {
+		return false
+	}
+	if c.MinSampleRate > 0 && voice.SampleRate < c.MinSampleRate {
+		return false
+	}
+	if c.MaxSampleRate > 0 && voice.SampleRate > c.MaxSampleRate {
+		return false
+	}
+	if c.Style != "" && !containsFold(voice.Styles, c.Style) {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether values contains target, ignoring case
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterVoicesByLanguage returns the voices for a language, in input order. A bare language such as
+// "ru" matches every regional variant ("ru-RU"); a regional tag such as "en-US" matches that region
+// and voices that name no region.
+func FilterVoicesByLanguage(voices []Voice, language string) []Voice {
+	want := normalizeLanguageTag(language)
+	wantPrimary := NormalizeLanguageCode(language)
+
+	var filtered []Voice
+	for _, voice := range voices {
+		if NormalizeLanguageCode(voice.Language) != wantPrimary {
+			continue
+		}
+		have := normalizeLanguageTag(voice.Language)
+		if want == wantPrimary || have == NormalizeLanguageCode(voice.Language) || have == want {
+			filtered = append(filtered, voice)
+		}
+	}
+	return filtered
+}
+
+// normalizeLanguageTag lowercases a language tag and uses "-" as its separator
+func normalizeLanguageTag(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "_", "-")
+}
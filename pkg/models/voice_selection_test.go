@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestSelectVoice(t *testing.T) {
+	voices := []Voice{
+		{ID: "anna", Language: "ru-RU", Gender: "female", SampleRate: 48000},
+		{ID: "john", Language: "en-US", Gender: "male", SampleRate: 24000, Styles: []string{"neutral"}},
+		{ID: "mark", Language: "en-GB", Gender: "Male", SampleRate: 16000, Styles: []string{"Friendly"}},
+		{ID: "unknown-rate", Language: "en", Gender: "male"},
+	}
+
+	tests := []struct {
+		name     string
+		criteria VoiceCriteria
+		want     string
+	}{
+		{name: "no criteria picks the first voice", want: "anna"},
+		{name: "language primary subtag", criteria: VoiceCriteria{Language: "en"}, want: "john"},
+		{name: "regional language matches on primary subtag", criteria: VoiceCriteria{Language: "en_GB"}, want: "john"},
+		{name: "gender is case-insensitive", criteria: VoiceCriteria{Language: "ru", Gender: "FEMALE"}, want: "anna"},
+		{name: "male English under 22kHz", criteria: VoiceCriteria{Language: "en", Gender: "male", MaxSampleRate: 22050}, want: "mark"},
+		{name: "minimum sample rate", criteria: VoiceCriteria{Gender: "male", MinSampleRate: 20000}, want: "john"},
+		{name: "style", criteria: VoiceCriteria{Style: "friendly"}, want: "mark"},
+		{name: "unknown sample rate never satisfies a bound", criteria: VoiceCriteria{Language: "en", MaxSampleRate: 8000}},
+		{name: "no language match", criteria: VoiceCriteria{Language: "de"}},
+		{name: "conflicting criteria", criteria: VoiceCriteria{Language: "ru", Gender: "male"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			voice, ok := SelectVoice(voices, tt.criteria)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("expected no match, got %q", voice.ID)
+				}
+				return
+			}
+			if !ok || voice.ID != tt.want {
+				t.Errorf("got %q (found %v), want %q", voice.ID, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := SelectVoice(nil, VoiceCriteria{}); ok {
+		t.Error("expected no match from an empty voice list")
+	}
+}
@@ -0,0 +1,149 @@
+// Package providers holds decorators shared by the services of every provider.
+package providers
+
+import (
+	"context"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/logger"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// withProviderContext tags ctx with the provider ID and capability so downstream logs carry them
+func withProviderContext(ctx context.Context, providerID string, capability types.Capability) context.Context {
+	ctx = logger.ContextWithProviderID(ctx, providerID)
+	return logger.ContextWithCapability(ctx, string(capability))
+}
+
+// contextChatService tags every chat call with the provider ID and capability
+type contextChatService struct {
+	interfaces.ChatService
+	providerID string
+}
+
+// NewContextChatService wraps a chat service so every call's context carries the provider ID and capability
+func NewContextChatService(providerID string, service interfaces.ChatService) interfaces.ChatService {
+	return &contextChatService{ChatService: service, providerID: providerID}
+}
+
+// ChatCompletion delegates with a tagged context
+func (s *contextChatService) ChatCompletion(ctx context.Context, messages []types.ChatMessage, options map[string]any) (string, error) {
+	return s.ChatService.ChatCompletion(withProviderContext(ctx, s.providerID, types.CapabilityChat), messages, options)
+}
+
+// StreamChatCompletion delegates with a tagged context once streaming is known to be supported
+func (s *contextChatService) StreamChatCompletion(ctx context.Context, messages []types.ChatMessage, options map[string]any) (<-chan string, <-chan error) {
+	if err := checkStreaming(s.providerID, s.ChatService, types.CapabilityChat); err != nil {
+		return failedStream[string](err)
+	}
+	return s.ChatService.StreamChatCompletion(withProviderContext(ctx, s.providerID, types.CapabilityChat), messages, options)
+}
+
+// GetModels delegates with a tagged context
+func (s *contextChatService) GetModels(ctx context.Context) ([]models.Model, error) {
+	return s.ChatService.GetModels(withProviderContext(ctx, s.providerID, types.CapabilityChat))
+}
+
+// StreamCompletion delegates with a tagged context once streaming is known to be supported
+func (s *contextChatService) StreamCompletion(ctx context.Context, req interfaces.ChatRequest, stream interfaces.ChatStream) error {
+	if err := checkStreaming(s.providerID, s.ChatService, types.CapabilityChat); err != nil {
+		return err
+	}
+	return s.ChatService.StreamCompletion(withProviderContext(ctx, s.providerID, types.CapabilityChat), req, stream)
+}
+
+// contextEmbeddingService tags every embedding call with the provider ID and capability
+type contextEmbeddingService struct {
+	interfaces.EmbeddingService
+	providerID string
+}
+
+// NewContextEmbeddingService wraps an embedding service so every call's context carries the provider ID and capability
+func NewContextEmbeddingService(providerID string, service interfaces.EmbeddingService) interfaces.EmbeddingService {
+	return &contextEmbeddingService{EmbeddingService: service, providerID: providerID}
+}
+
+// GenerateEmbedding delegates with a tagged context
+func (s *contextEmbeddingService) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	return s.EmbeddingService.GenerateEmbedding(withProviderContext(ctx, s.providerID, types.CapabilityEmbedding), text)
+}
+
+// GenerateEmbeddings delegates with a tagged context
+func (s *contextEmbeddingService) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return s.EmbeddingService.GenerateEmbeddings(withProviderContext(ctx, s.providerID, types.CapabilityEmbedding), texts)
+}
+
+// contextSTTService tags every STT call with the provider ID and capability
+type contextSTTService struct {
+	interfaces.STTService
+	providerID string
+}
+
+// NewContextSTTService wraps an STT service so every call's context carries the provider ID and capability
+func NewContextSTTService(providerID string, service interfaces.STTService) interfaces.STTService {
+	return &contextSTTService{STTService: service, providerID: providerID}
+}
+
+// Transcribe delegates with a tagged context
+func (s *contextSTTService) Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error) {
+	return s.STTService.Transcribe(withProviderContext(ctx, s.providerID, types.CapabilitySTT), audioData, options)
+}
+
+// StreamTranscribe delegates with a tagged context once streaming is known to be supported
+func (s *contextSTTService) StreamTranscribe(ctx context.Context, audioStream <-chan []byte, options map[string]any) (<-chan string, <-chan error) {
+	if err := checkStreaming(s.providerID, s.STTService, types.CapabilitySTT); err != nil {
+		return failedStream[string](err)
+	}
+	return s.STTService.StreamTranscribe(withProviderContext(ctx, s.providerID, types.CapabilitySTT), audioStream, options)
+}
+
+// NewSTTClient delegates with a tagged context once streaming is known to be supported
+func (s *contextSTTService) NewSTTClient(ctx context.Context, config models.STTConfig) (interfaces.STTClient, error) {
+	if err := checkStreaming(s.providerID, s.STTService, types.CapabilitySTT); err != nil {
+		return nil, err
+	}
+	return s.STTService.NewSTTClient(withProviderContext(ctx, s.providerID, types.CapabilitySTT), config)
+}
+
+// contextTTSService tags every TTS call with the provider ID and capability
+type contextTTSService struct {
+	interfaces.TTSService
+	providerID string
+}
+
+// NewContextTTSService wraps a TTS service so every call's context carries the provider ID and capability
+func NewContextTTSService(providerID string, service interfaces.TTSService) interfaces.TTSService {
+	return &contextTTSService{TTSService: service, providerID: providerID}
+}
+
+// Synthesize delegates with a tagged context
+func (s *contextTTSService) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
+	return s.TTSService.Synthesize(withProviderContext(ctx, s.providerID, types.CapabilityTTS), text, config)
+}
+
+// StreamSynthesize delegates with a tagged context once streaming is known to be supported
+func (s *contextTTSService) StreamSynthesize(ctx context.Context, textStream <-chan string, config models.TTSConfig) (<-chan []byte, <-chan error) {
+	if err := checkStreaming(s.providerID, s.TTSService, types.CapabilityTTS); err != nil {
+		return failedStream[[]byte](err)
+	}
+	return s.TTSService.StreamSynthesize(withProviderContext(ctx, s.providerID, types.CapabilityTTS), textStream, config)
+}
+
+// NewTTSClient delegates with a tagged context once streaming is known to be supported
+func (s *contextTTSService) NewTTSClient(ctx context.Context, config models.TTSConfig) (interfaces.TTSClient, error) {
+	if err := checkStreaming(s.providerID, s.TTSService, types.CapabilityTTS); err != nil {
+		return nil, err
+	}
+	return s.TTSService.NewTTSClient(withProviderContext(ctx, s.providerID, types.CapabilityTTS), config)
+}
+
+// GetVoices delegates with a tagged context
+func (s *contextTTSService) GetVoices(ctx context.Context) ([]models.Voice, error) {
+	return s.TTSService.GetVoices(withProviderContext(ctx, s.providerID, types.CapabilityTTS))
+}
+
+// GetVoicesByLanguage delegates with a tagged context
+func (s *contextTTSService) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
+	return s.TTSService.GetVoicesByLanguage(withProviderContext(ctx, s.providerID, types.CapabilityTTS), language)
+}
@@ -0,0 +1,199 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/logger"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// callContext is what a wrapped service saw in the context of one call
+type callContext struct {
+	method     string
+	providerID string
+	capability string
+	requestID  string
+}
+
+// recorder collects the context of every call made to the stub services
+type recorder struct {
+	calls []callContext
+}
+
+func (r *recorder) record(ctx context.Context, method string) {
+	r.calls = append(r.calls, callContext{
+		method:     method,
+		providerID: logger.GetProviderIDFromContext(ctx),
+		capability: logger.GetCapabilityFromContext(ctx),
+		requestID:  logger.GetRequestIDFromContext(ctx),
+	})
+}
+
+type stubChat struct {
+	interfaces.ChatService
+	*recorder
+}
+
+func (s stubChat) ChatCompletion(ctx context.Context, _ []types.ChatMessage, _ map[string]any) (string, error) {
+	s.record(ctx, "ChatCompletion")
+	return "", nil
+}
+
+func (s stubChat) StreamChatCompletion(ctx context.Context, _ []types.ChatMessage, _ map[string]any) (<-chan string, <-chan error) {
+	s.record(ctx, "StreamChatCompletion")
+	return nil, nil
+}
+
+func (s stubChat) GetModels(ctx context.Context) ([]models.Model, error) {
+	s.record(ctx, "GetModels")
+	return nil, nil
+}
+
+func (s stubChat) StreamCompletion(ctx context.Context, _ interfaces.ChatRequest, _ interfaces.ChatStream) error {
+	s.record(ctx, "StreamCompletion")
+	return nil
+}
+
+type stubEmbedding struct {
+	interfaces.EmbeddingService
+	*recorder
+}
+
+func (s stubEmbedding) GenerateEmbedding(ctx context.Context, _ string) ([]float32, error) {
+	s.record(ctx, "GenerateEmbedding")
+	return nil, nil
+}
+
+func (s stubEmbedding) GenerateEmbeddings(ctx context.Context, _ []string) ([][]float32, error) {
+	s.record(ctx, "GenerateEmbeddings")
+	return nil, nil
+}
+
+type stubSTT struct {
+	interfaces.STTService
+	*recorder
+}
+
+func (s stubSTT) Transcribe(ctx context.Context, _ []byte, _ map[string]any) (string, error) {
+	s.record(ctx, "Transcribe")
+	return "", nil
+}
+
+func (s stubSTT) StreamTranscribe(ctx context.Context, _ <-chan []byte, _ map[string]any) (<-chan string, <-chan error) {
+	s.record(ctx, "StreamTranscribe")
+	return nil, nil
+}
+
+func (s stubSTT) NewSTTClient(ctx context.Context, _ models.STTConfig) (interfaces.STTClient, error) {
+	s.record(ctx, "NewSTTClient")
+	return nil, nil
+}
+
+type stubTTS struct {
+	interfaces.TTSService
+	*recorder
+}
+
+func (s stubTTS) Synthesize(ctx context.Context, _ string, _ models.TTSConfig) ([]byte, error) {
+	s.record(ctx, "Synthesize")
+	return nil, nil
+}
+
+func (s stubTTS) StreamSynthesize(ctx context.Context, _ <-chan string, _ models.TTSConfig) (<-chan []byte, <-chan error) {
+	s.record(ctx, "StreamSynthesize")
+	return nil, nil
+}
+
+func (s stubTTS) NewTTSClient(ctx context.Context, _ models.TTSConfig) (interfaces.TTSClient, error) {
+	s.record(ctx, "NewTTSClient")
+	return nil, nil
+}
+
+func (s stubTTS) GetVoices(ctx context.Context) ([]models.Voice, error) {
+	s.record(ctx, "GetVoices")
+	return nil, nil
+}
+
+func (s stubTTS) GetVoicesByLanguage(ctx context.Context, _ string) ([]models.Voice, error) {
+	s.record(ctx, "GetVoicesByLanguage")
+	return nil, nil
+}
+
+func TestContextServicesTagCalls(t *testing.T) {
+	ctx := logger.ContextWithRequestID(context.Background(), "req-1")
+
+	tests := []struct {
+		capability types.Capability
+		calls      func(r *recorder)
+		methods    int
+	}{
+		{
+			capability: types.CapabilityChat,
+			calls: func(r *recorder) {
+				service := NewContextChatService("openai", stubChat{recorder: r})
+				service.ChatCompletion(ctx, nil, nil)
+				service.StreamChatCompletion(ctx, nil, nil)
+				service.GetModels(ctx)
+				service.StreamCompletion(ctx, interfaces.ChatRequest{}, nil)
+			},
+			methods: 4,
+		},
+		{
+			capability: types.CapabilityEmbedding,
+			calls: func(r *recorder) {
+				service := NewContextEmbeddingService("openai", stubEmbedding{recorder: r})
+				service.GenerateEmbedding(ctx, "a")
+				service.GenerateEmbeddings(ctx, []string{"a"})
+			},
+			methods: 2,
+		},
+		{
+			capability: types.CapabilitySTT,
+			calls: func(r *recorder) {
+				service := NewContextSTTService("openai", stubSTT{recorder: r})
+				service.Transcribe(ctx, nil, nil)
+				service.StreamTranscribe(ctx, nil, nil)
+				service.NewSTTClient(ctx, models.STTConfig{})
+			},
+			methods: 3,
+		},
+		{
+			capability: types.CapabilityTTS,
+			calls: func(r *recorder) {
+				service := NewContextTTSService("openai", stubTTS{recorder: r})
+				service.Synthesize(ctx, "a", models.TTSConfig{})
+				service.StreamSynthesize(ctx, nil, models.TTSConfig{})
+				service.NewTTSClient(ctx, models.TTSConfig{})
+				service.GetVoices(ctx)
+				service.GetVoicesByLanguage(ctx, "en")
+			},
+			methods: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.capability), func(t *testing.T) {
+			r := &recorder{}
+			tt.calls(r)
+
+			if len(r.calls) != tt.methods {
+				t.Fatalf("expected %d delegated calls, got %d", tt.methods, len(r.calls))
+			}
+			for _, call := range r.calls {
+				if call.providerID != "openai" || call.capability != string(tt.capability) {
+					t.Errorf("%s: context carried provider %q and capability %q", call.method, call.providerID, call.capability)
+				}
+				if call.requestID != "req-1" {
+					t.Errorf("%s: the caller's request ID was lost", call.method)
+				}
+			}
+		})
+	}
+
+	if logger.GetProviderIDFromContext(ctx) != "" {
+		t.Error("the caller's context must not be modified")
+	}
+}
@@ -3,6 +3,7 @@ package factory
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,6 +31,9 @@ type ProviderFactory interface {
 
 	// ClearCacheForProvider clears cache for a specific provider
 	ClearCacheForProvider(providerName string)
+
+	// Close stops background cache maintenance
+	Close() error
 }
 
 // Configuration defines the interface for configuration needed by the factory
@@ -43,138 +47,114 @@ type providerFactory struct {
 	config   Configuration
 
 	// Cache for provider instances to avoid redundant initialization
-	cache   map[string]any
-	cacheMu sync.RWMutex
+	cache    map[string]*cacheEntry
+	cacheMu  sync.RWMutex
+	cacheTTL time.Duration // zero means entries never expire
+
+	// Background sweeper lifecycle
+	stopSweeper chan struct{}
+	sweeperDone chan struct{}
+	closeOnce   sync.Once
 
 	// Initialization tracking to prevent concurrent initialization
 	initLocks   map[string]*sync.Mutex
 	initLocksMu sync.Mutex
 }
 
-// NewProviderFactory creates a new provider factory
+// cacheEntry holds a cached service and when it expires
+type cacheEntry struct {
+	service   any
+	expiresAt time.Time
+}
+
+// expired reports whether the entry is stale; entries without an expiry never are
+func (e *cacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// NewProviderFactory creates a new provider factory whose cache entries never expire
 func NewProviderFactory(registry registry.ProviderRegistry, cfg Configuration) ProviderFactory {
+	return newProviderFactory(registry, cfg, 0)
+}
+
+// NewProviderFactoryWithTTL creates a provider factory whose cached services expire after ttl.
+// An expired service is fetched from the registry again, so a provider re-registered there (for
+// example with rotated credentials) is picked up without calling ClearCache; the factory does not
+// re-initialize providers itself. A background sweeper evicts stale entries every ttl until Close
+// is called.
+func NewProviderFactoryWithTTL(registry registry.ProviderRegistry, cfg Configuration, ttl time.Duration) ProviderFactory {
+	f := newProviderFactory(registry, cfg, ttl)
+	if ttl > 0 {
+		f.stopSweeper = make(chan struct{})
+		f.sweeperDone = make(chan struct{})
+		go f.sweep(ttl)
+	}
+	return f
+}
+
+// newProviderFactory creates the concrete provider factory
+func newProviderFactory(registry registry.ProviderRegistry, cfg Configuration, ttl time.Duration) *providerFactory {
 	return &providerFactory{
 		registry:  registry,
 		config:    cfg,
-		cache:     make(map[string]any),
+		cache:     make(map[string]*cacheEntry),
+		cacheTTL:  ttl,
 		initLocks: make(map[string]*sync.Mutex),
 	}
 }
 
 // CreateChatService creates a chat service for the specified provider
 func (f *providerFactory) CreateChatService(ctx context.Context, providerName string) (interfaces.ChatService, error) {
-	cacheKey := fmt.Sprintf("chat:%s", providerName)
-
-	// Check cache first
-	if service := f.getCached(cacheKey); service != nil {
-		if chatService, ok := service.(interfaces.ChatService); ok {
-			return chatService, nil
-		}
-	}
-
-	// Get provider from registry
-	provider, err := f.registry.Get(providerName, types.CapabilityChat)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get chat provider %s: %w", providerName, err)
-	}
-
-	// Type assert to ChatService
-	chatService, ok := provider.(interfaces.ChatService)
-	if !ok {
-		return nil, fmt.Errorf("provider %s does not implement ChatService interface", providerName)
-	}
-
-	// Cache the service
-	f.setCached(cacheKey, chatService)
-
-	return chatService, nil
+	return createService(f, types.CapabilityChat, providerName, "chat", f.registry.GetChatService)
 }
 
 // CreateEmbeddingService creates an embedding service for the specified provider
 func (f *providerFactory) CreateEmbeddingService(ctx context.Context, providerName string) (interfaces.EmbeddingService, error) {
-	cacheKey := fmt.Sprintf("embedding:%s", providerName)
-
-	// Check cache first
-	if service := f.getCached(cacheKey); service != nil {
-		if embeddingService, ok := service.(interfaces.EmbeddingService); ok {
-			return embeddingService, nil
-		}
-	}
-
-	// Get provider from registry
-	provider, err := f.registry.Get(providerName, types.CapabilityEmbedding)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get embedding provider %s: %w", providerName, err)
-	}
-
-	// Type assert to EmbeddingService
-	embeddingService, ok := provider.(interfaces.EmbeddingService)
-	if !ok {
-		return nil, fmt.Errorf("provider %s does not implement EmbeddingService interface", providerName)
-	}
-
-	// Cache the service
-	f.setCached(cacheKey, embeddingService)
-
-	return embeddingService, nil
+	return createService(f, types.CapabilityEmbedding, providerName, "embedding", f.registry.GetEmbeddingService)
 }
 
 // CreateSTTService creates a speech-to-text service for the specified provider
 func (f *providerFactory) CreateSTTService(ctx context.Context, providerName string) (interfaces.STTService, error) {
-	cacheKey := fmt.Sprintf("stt:%s", providerName)
-
-	// Check cache first
-	if service := f.getCached(cacheKey); service != nil {
-		if sttService, ok := service.(interfaces.STTService); ok {
-			return sttService, nil
-		}
-	}
-
-	// Get provider from registry
-	provider, err := f.registry.Get(providerName, types.CapabilitySTT)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get STT provider %s: %w", providerName, err)
-	}
-
-	// Type assert to SpeechToTextService
-	sttService, ok := provider.(interfaces.STTService)
-	if !ok {
-		return nil, fmt.Errorf("provider %s does not implement SpeechToTextService interface", providerName)
-	}
-
-	// Cache the service
-	f.setCached(cacheKey, sttService)
-
-	return sttService, nil
+	return createService(f, types.CapabilitySTT, providerName, "STT", f.registry.GetSTTService)
 }
 
 // CreateTTSService creates a text-to-speech service for the specified provider
 func (f *providerFactory) CreateTTSService(ctx context.Context, providerName string) (interfaces.TTSService, error) {
-	cacheKey := fmt.Sprintf("tts:%s", providerName)
+	return createService(f, types.CapabilityTTS, providerName, "TTS", f.registry.GetTTSService)
+}
+
+// createService returns the cached service for a provider and capability, or fetches it from the
+// registry under the provider's init lock so that concurrent callers share a single instance.
+// Services come from the registry's Get*Service methods, so they carry the same metrics and
+// context decorators as services fetched from the registry directly.
+func createService[T any](f *providerFactory, capability types.Capability, providerName, kind string, get func(string) (T, error)) (T, error) {
+	var zero T
+	cacheKey := fmt.Sprintf("%s:%s", capability, providerName)
 
 	// Check cache first
-	if service := f.getCached(cacheKey); service != nil {
-		if ttsService, ok := service.(interfaces.TTSService); ok {
-			return ttsService, nil
-		}
+	if service, ok := f.getCached(cacheKey).(T); ok {
+		return service, nil
 	}
 
-	// Get provider from registry
-	provider, err := f.registry.Get(providerName, types.CapabilityTTS)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get TTS provider %s: %w", providerName, err)
+	lock := f.getInitLock(providerName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Another caller may have populated the cache while we waited for the lock
+	if service, ok := f.getCached(cacheKey).(T); ok {
+		return service, nil
 	}
 
-	// Type assert to TextToSpeechService
-	ttsService, ok := provider.(interfaces.TTSService)
-	if !ok {
-		return nil, fmt.Errorf("provider %s does not implement TextToSpeechService interface", providerName)
+	// Get the decorated service from the registry
+	service, err := get(providerName)
+	if err != nil {
+		return zero, fmt.Errorf("failed to get %s provider %s: %w", kind, providerName, err)
 	}
 
 	// Cache the service
-	f.setCached(cacheKey, ttsService)
+	f.setCached(cacheKey, service)
 
-	return ttsService, nil
+	return service, nil
 }
 
 // ClearCache clears the entire provider instance cache
@@ -182,7 +162,7 @@ func (f *providerFactory) ClearCache() {
 	f.cacheMu.Lock()
 	defer f.cacheMu.Unlock()
 
-	f.cache = make(map[string]any)
+	f.cache = make(map[string]*cacheEntry)
 }
 
 // ClearCacheForProvider clears cache entries for a specific provider
@@ -203,12 +183,38 @@ func (f *providerFactory) ClearCacheForProvider(providerName string) {
 	}
 }
 
-// getCached retrieves a cached service instance
+// Close stops the background sweeper, if any
+func (f *providerFactory) Close() error {
+	f.closeOnce.Do(func() {
+		if f.stopSweeper != nil {
+			close(f.stopSweeper)
+			<-f.sweeperDone
+		}
+	})
+	return nil
+}
+
+// getCached retrieves a cached service instance, evicting it if it has expired
 func (f *providerFactory) getCached(key string) any {
 	f.cacheMu.RLock()
-	defer f.cacheMu.RUnlock()
+	entry, exists := f.cache[key]
+	f.cacheMu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+
+	if entry.expired(time.Now()) {
+		f.cacheMu.Lock()
+		// Only evict if the entry was not replaced in the meantime
+		if f.cache[key] == entry {
+			delete(f.cache, key)
+		}
+		f.cacheMu.Unlock()
+		return nil
+	}
 
-	return f.cache[key]
+	return entry.service
 }
 
 // setCached stores a service instance in the cache
@@ -216,7 +222,34 @@ func (f *providerFactory) setCached(key string, service any) {
 	f.cacheMu.Lock()
 	defer f.cacheMu.Unlock()
 
-	f.cache[key] = service
+	entry := &cacheEntry{service: service}
+	if f.cacheTTL > 0 {
+		entry.expiresAt = time.Now().Add(f.cacheTTL)
+	}
+	f.cache[key] = entry
+}
+
+// sweep periodically evicts expired cache entries until Close is called
+func (f *providerFactory) sweep(interval time.Duration) {
+	defer close(f.sweeperDone)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.stopSweeper:
+			return
+		case now := <-ticker.C:
+			f.cacheMu.Lock()
+			for key, entry := range f.cache {
+				if entry.expired(now) {
+					delete(f.cache, key)
+				}
+			}
+			f.cacheMu.Unlock()
+		}
+	}
 }
 
 // getInitLock gets or creates a mutex for provider initialization
@@ -249,66 +282,58 @@ func NewProviderFactoryWithFallback(factory ProviderFactory, cfg Configuration)
 
 // CreateChatService creates a chat service with fallback support
 func (f *ProviderFactoryWithFallback) CreateChatService(ctx context.Context, providerName string) (interfaces.ChatService, error) {
-	service, err := f.factory.CreateChatService(ctx, providerName)
-	if err == nil {
-		return service, nil
-	}
-
-	// Try fallback provider if configured
-	fallback := f.config.GetFallbackProvider("chat")
-	if fallback != "" && fallback != providerName {
-		return f.factory.CreateChatService(ctx, fallback)
-	}
-
-	return nil, err
+	return createWithFallback(f, types.CapabilityChat, providerName, func(name string) (interfaces.ChatService, error) {
+		return f.factory.CreateChatService(ctx, name)
+	})
 }
 
 // CreateEmbeddingService creates an embedding service with fallback support
 func (f *ProviderFactoryWithFallback) CreateEmbeddingService(ctx context.Context, providerName string) (interfaces.EmbeddingService, error) {
-	service, err := f.factory.CreateEmbeddingService(ctx, providerName)
-	if err == nil {
-		return service, nil
-	}
-
-	// Try fallback provider if configured
-	fallback := f.config.GetFallbackProvider("embedding")
-	if fallback != "" && fallback != providerName {
-		return f.factory.CreateEmbeddingService(ctx, fallback)
-	}
-
-	return nil, err
+	return createWithFallback(f, types.CapabilityEmbedding, providerName, func(name string) (interfaces.EmbeddingService, error) {
+		return f.factory.CreateEmbeddingService(ctx, name)
+	})
 }
 
 // CreateSTTService creates an STT service with fallback support
 func (f *ProviderFactoryWithFallback) CreateSTTService(ctx context.Context, providerName string) (interfaces.STTService, error) {
-	service, err := f.factory.CreateSTTService(ctx, providerName)
-	if err == nil {
-		return service, nil
-	}
-
-	// Try fallback provider if configured
-	fallback := f.config.GetFallbackProvider("stt")
-	if fallback != "" && fallback != providerName {
-		return f.factory.CreateSTTService(ctx, fallback)
-	}
-
-	return nil, err
+	return createWithFallback(f, types.CapabilitySTT, providerName, func(name string) (interfaces.STTService, error) {
+		return f.factory.CreateSTTService(ctx, name)
+	})
 }
 
 // CreateTTSService creates a TTS service with fallback support
 func (f *ProviderFactoryWithFallback) CreateTTSService(ctx context.Context, providerName string) (interfaces.TTSService, error) {
-	service, err := f.factory.CreateTTSService(ctx, providerName)
+	return createWithFallback(f, types.CapabilityTTS, providerName, func(name string) (interfaces.TTSService, error) {
+		return f.factory.CreateTTSService(ctx, name)
+	})
+}
+
+// createWithFallback tries the requested provider and then the configured fallback for a capability.
+// When both fail it returns an AllProvidersFailedError listing every attempt.
+func createWithFallback[T any](f *ProviderFactoryWithFallback, capability types.Capability, providerName string, create func(string) (T, error)) (T, error) {
+	service, err := create(providerName)
 	if err == nil {
 		return service, nil
 	}
 
 	// Try fallback provider if configured
-	fallback := f.config.GetFallbackProvider("tts")
-	if fallback != "" && fallback != providerName {
-		return f.factory.CreateTTSService(ctx, fallback)
+	fallback := f.config.GetFallbackProvider(string(capability))
+	if fallback == "" || fallback == providerName {
+		return service, err
 	}
 
-	return nil, err
+	fallbackService, fallbackErr := create(fallback)
+	if fallbackErr == nil {
+		return fallbackService, nil
+	}
+
+	return fallbackService, &AllProvidersFailedError{
+		Capability: capability,
+		Attempts: []ProviderAttempt{
+			{ProviderName: providerName, Err: err},
+			{ProviderName: fallback, Err: fallbackErr},
+		},
+	}
 }
 
 // ClearCache clears the cache
@@ -321,6 +346,11 @@ func (f *ProviderFactoryWithFallback) ClearCacheForProvider(providerName string)
 	f.factory.ClearCacheForProvider(providerName)
 }
 
+// Close closes the wrapped factory
+func (f *ProviderFactoryWithFallback) Close() error {
+	return f.factory.Close()
+}
+
 // ProviderInitializationError represents an error during provider initialization
 type ProviderInitializationError struct {
 	ProviderName string
@@ -349,3 +379,33 @@ func NewProviderInitializationError(providerName string, capability types.Capabi
 		Timestamp:    time.Now(),
 	}
 }
+
+// ProviderAttempt records a single failed attempt to create a provider service
+type ProviderAttempt struct {
+	ProviderName string
+	Err          error
+}
+
+// AllProvidersFailedError is returned when every provider tried for a capability failed
+type AllProvidersFailedError struct {
+	Capability types.Capability
+	Attempts   []ProviderAttempt
+}
+
+// Error implements the error interface
+func (e *AllProvidersFailedError) Error() string {
+	parts := make([]string, len(e.Attempts))
+	for i, attempt := range e.Attempts {
+		parts[i] = fmt.Sprintf("%s: %v", attempt.ProviderName, attempt.Err)
+	}
+	return fmt.Sprintf("all providers failed for capability %s: %s", e.Capability, strings.Join(parts, "; "))
+}
+
+// Unwrap returns the errors of every attempt so errors.Is and errors.As can inspect them
+func (e *AllProvidersFailedError) Unwrap() []error {
+	errs := make([]error, len(e.Attempts))
+	for i, attempt := range e.Attempts {
+		errs[i] = attempt.Err
+	}
+	return errs
+}
@@ -0,0 +1,228 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/mock"
+	"github.com/creastat/common-go/pkg/providers/registry"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// staticConfig is a Configuration without fallback providers
+type staticConfig struct{}
+
+func (staticConfig) GetFallbackProvider(string) string { return "" }
+
+func TestCreatedServicesAreMetered(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	if _, err := mock.Register(reg, mock.Config{Name: "mock", Embedding: []float32{1}}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	f := NewProviderFactory(reg, staticConfig{})
+	ctx := context.Background()
+
+	chat, err := f.CreateChatService(ctx, "mock")
+	if err != nil {
+		t.Fatalf("CreateChatService: %v", err)
+	}
+	chat.ChatCompletion(ctx, []types.ChatMessage{{Role: "user", Content: "hi"}}, nil)
+
+	embedding, err := f.CreateEmbeddingService(ctx, "mock")
+	if err != nil {
+		t.Fatalf("CreateEmbeddingService: %v", err)
+	}
+	embedding.GenerateEmbeddings(ctx, []string{"a", "b"})
+
+	stt, err := f.CreateSTTService(ctx, "mock")
+	if err != nil {
+		t.Fatalf("CreateSTTService: %v", err)
+	}
+	stt.Transcribe(ctx, []byte{0, 0}, nil)
+
+	tts, err := f.CreateTTSService(ctx, "mock")
+	if err != nil {
+		t.Fatalf("CreateTTSService: %v", err)
+	}
+	tts.Synthesize(ctx, "hi", models.TTSConfig{})
+
+	for _, capability := range []types.Capability{types.CapabilityChat, types.CapabilityEmbedding, types.CapabilitySTT, types.CapabilityTTS} {
+		metrics, err := reg.GetMetrics("mock", capability)
+		if err != nil {
+			t.Fatalf("GetMetrics(%s): %v", capability, err)
+		}
+		if metrics.TotalRequests != 1 {
+			t.Errorf("%s: expected 1 recorded request, got %d", capability, metrics.TotalRequests)
+		}
+	}
+}
+
+// countingRegistry counts chat service fetches and holds each one briefly, widening the window in
+// which concurrent creations could race
+type countingRegistry struct {
+	registry.ProviderRegistry
+	fetches atomic.Int32
+}
+
+func (r *countingRegistry) GetChatService(name string) (interfaces.ChatService, error) {
+	r.fetches.Add(1)
+	time.Sleep(10 * time.Millisecond)
+	return r.ProviderRegistry.GetChatService(name)
+}
+
+func TestConcurrentCreationSharesOneInstance(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	if _, err := mock.Register(reg, mock.Config{Name: "mock"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	counting := &countingRegistry{ProviderRegistry: reg}
+	f := NewProviderFactory(counting, staticConfig{})
+
+	const callers = 16
+	services := make([]interfaces.ChatService, callers)
+	var wg sync.WaitGroup
+	for i := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			service, err := f.CreateChatService(context.Background(), "mock")
+			if err != nil {
+				t.Errorf("CreateChatService: %v", err)
+			}
+			services[i] = service
+		}()
+	}
+	wg.Wait()
+
+	if n := counting.fetches.Load(); n != 1 {
+		t.Errorf("expected a single registry fetch, got %d", n)
+	}
+	for i, service := range services {
+		if service != services[0] {
+			t.Fatalf("caller %d got a different instance", i)
+		}
+	}
+}
+
+func TestExpiredServicesAreFetchedAgain(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	if _, err := mock.Register(reg, mock.Config{Name: "mock", ChatResponse: "old key"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	const ttl = 50 * time.Millisecond
+	f := NewProviderFactoryWithTTL(reg, staticConfig{}, ttl)
+	defer f.Close()
+
+	reply := func() string {
+		t.Helper()
+		chat, err := f.CreateChatService(context.Background(), "mock")
+		if err != nil {
+			t.Fatalf("CreateChatService: %v", err)
+		}
+		response, err := chat.ChatCompletion(context.Background(), []types.ChatMessage{{Role: "user", Content: "hi"}}, nil)
+		if err != nil {
+			t.Fatalf("ChatCompletion: %v", err)
+		}
+		return response
+	}
+
+	if got := reply(); got != "old key" {
+		t.Fatalf("got %q from the first provider", got)
+	}
+
+	// Re-register the provider, as an application does after rotating its credentials
+	if err := reg.Unregister("mock"); err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if _, err := mock.Register(reg, mock.Config{Name: "mock", ChatResponse: "new key"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if got := reply(); got != "old key" {
+		t.Errorf("expected the cached service before the TTL, got %q", got)
+	}
+	time.Sleep(2 * ttl)
+	if got := reply(); got != "new key" {
+		t.Errorf("expected the re-registered provider after the TTL, got %q", got)
+	}
+}
+
+// fallbackConfig routes every capability to a single fallback provider
+type fallbackConfig string
+
+func (c fallbackConfig) GetFallbackProvider(string) string { return string(c) }
+
+func TestResponseMetadataNamesFallbackProvider(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	primary, err := mock.Register(reg, mock.Config{Name: "primary", HealthErr: errors.New("down")})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if _, err := mock.Register(reg, mock.Config{Name: "backup", ChatResponse: "hello"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	reg.HealthCheck(context.Background())
+
+	f := NewProviderFactoryWithFallback(NewProviderFactory(reg, fallbackConfig("backup")), fallbackConfig("backup"))
+	chat, err := f.CreateChatService(context.Background(), "primary")
+	if err != nil {
+		t.Fatalf("CreateChatService: %v", err)
+	}
+
+	ctx, metadata := models.WithResponseMetadata(context.Background())
+	if _, err := chat.ChatCompletion(ctx, []types.ChatMessage{{Role: "user", Content: "hi"}}, map[string]any{"model": "backup-large"}); err != nil {
+		t.Fatalf("ChatCompletion: %v", err)
+	}
+
+	if metadata.Provider() != "backup" || metadata.Model() != "backup-large" {
+		t.Errorf("expected backup/backup-large, got %s/%s", metadata.Provider(), metadata.Model())
+	}
+	if primary.Calls("ChatCompletion") != 0 {
+		t.Error("the unhealthy primary should not have served the request")
+	}
+}
+
+func TestFallbackReportsEveryFailedAttempt(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	for _, name := range []string{"primary", "backup"} {
+		if _, err := mock.Register(reg, mock.Config{Name: name, HealthErr: errors.New(name + " down")}); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	reg.HealthCheck(context.Background())
+
+	f := NewProviderFactoryWithFallback(NewProviderFactory(reg, fallbackConfig("backup")), fallbackConfig("backup"))
+	_, err := f.CreateChatService(context.Background(), "primary")
+
+	var failed *AllProvidersFailedError
+	if !errors.As(err, &failed) {
+		t.Fatalf("expected an AllProvidersFailedError, got %v", err)
+	}
+	if failed.Capability != types.CapabilityChat || len(failed.Attempts) != 2 {
+		t.Fatalf("expected two chat attempts, got %+v", failed)
+	}
+	for i, name := range []string{"primary", "backup"} {
+		attempt := failed.Attempts[i]
+		if attempt.ProviderName != name || attempt.Err == nil {
+			t.Errorf("attempt %d: expected a failure of %s, got %+v", i, name, attempt)
+		}
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error does not mention %s: %v", name, err)
+		}
+	}
+
+	// Without a distinct fallback the primary's error is returned as is
+	_, err = NewProviderFactoryWithFallback(NewProviderFactory(reg, staticConfig{}), staticConfig{}).CreateChatService(context.Background(), "primary")
+	if err == nil || errors.As(err, &failed) {
+		t.Errorf("expected the plain primary error, got %v", err)
+	}
+}
@@ -28,9 +28,18 @@ func (s *ChatService) StreamCompletion(ctx context.Context, req interfaces.ChatR
 	if !s.provider.IsInitialized() {
 		return fmt.Errorf("provider not initialized")
 	}
+	if !s.provider.DescribeCapabilities().SupportsStreaming(models.CapabilityChat) {
+		return fmt.Errorf("provider %s does not support streaming chat", s.provider.Name())
+	}
+
+	ctx, cancel := models.WithCapabilityTimeout(ctx, s.provider.config, models.CapabilityChat)
+	defer cancel()
 
 	// Convert to OpenAI request
-	openaiReq := s.convertToOpenAIRequest(req)
+	openaiReq, err := s.convertToOpenAIRequest(req)
+	if err != nil {
+		return err
+	}
 
 	// Create stream
 	openaiStream, err := s.provider.client.CreateChatCompletionStream(ctx, openaiReq)
@@ -39,6 +48,15 @@ func (s *ChatService) StreamCompletion(ctx context.Context, req interfaces.ChatR
 	}
 	defer openaiStream.Close()
 
+	// Tool-call arguments arrive in fragments across chunks
+	var toolCalls toolCallAccumulator
+
+	// Usage arrives in a trailing chunk without choices
+	var usage *models.TokenUsage
+
+	// A delta may end mid-rune; the remainder is held back until the next delta
+	var runes runeBuffer
+
 	// Stream responses
 	for {
 		// Check if context is cancelled (e.g., by break signal)
@@ -53,7 +71,9 @@ func (s *ChatService) StreamCompletion(ctx context.Context, req interfaces.ChatR
 		response, err := openaiStream.Recv()
 		if err == io.EOF {
 			// Send final chunk with Done flag
-			if err := stream.Send(interfaces.ChatChunk{Done: true}); err != nil {
+			models.RecordUsage(ctx, usage)
+			rest := runes.flush()
+			if err := stream.Send(interfaces.ChatChunk{Delta: rest, Content: rest, Done: true, ToolCalls: toolCalls.complete(), Usage: usage}); err != nil {
 				return fmt.Errorf("failed to send final chunk: %w", err)
 			}
 			break
@@ -62,8 +82,21 @@ func (s *ChatService) StreamCompletion(ctx context.Context, req interfaces.ChatR
 			return fmt.Errorf("stream error: %w", err)
 		}
 
+		if response.Model != "" {
+			models.RecordResponseMetadata(ctx, s.provider.name, response.Model)
+		}
+
+		if response.Usage != nil {
+			usage = convertUsage(*response.Usage)
+		}
+		if len(response.Choices) == 0 {
+			continue
+		}
+
 		// Convert and send chunk
-		chunk := s.convertFromOpenAIResponse(response)
+		chunk := s.convertFromOpenAIResponse(response, &toolCalls)
+		chunk.Delta = runes.push(chunk.Delta)
+		chunk.Content = chunk.Delta
 		if err := stream.Send(chunk); err != nil {
 			return fmt.Errorf("failed to send chunk: %w", err)
 		}
@@ -95,13 +128,11 @@ func (s *ChatService) GetModels(ctx context.Context) ([]models.Model, error) {
 }
 
 // convertToOpenAIRequest converts interface request to OpenAI request
-func (s *ChatService) convertToOpenAIRequest(req interfaces.ChatRequest) openai.ChatCompletionRequest {
-	messages := make([]openai.ChatCompletionMessage, len(req.Messages))
-	for i, msg := range req.Messages {
-		messages[i] = openai.ChatCompletionMessage{
-			Role:    msg.Role,
-			Content: msg.Content,
-		}
+func (s *ChatService) convertToOpenAIRequest(req interfaces.ChatRequest) (openai.ChatCompletionRequest, error) {
+	messages := convertMessages(req.Messages)
+
+	if err := s.provider.checkModel(req.Model, req.Options); err != nil {
+		return openai.ChatCompletionRequest{}, err
 	}
 
 	// For Yandex, prepend the folder_id to the model name
@@ -122,6 +153,23 @@ func (s *ChatService) convertToOpenAIRequest(req interfaces.ChatRequest) openai.
 		Stream:   true,
 	}
 
+	if s.provider.streamUsage() {
+		openaiReq.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+	}
+
+	if err := applyToolOptions(&openaiReq, req.Options); err != nil {
+		return openaiReq, err
+	}
+
+	// Reasoning models take max_completion_tokens and reject sampling params
+	if isReasoningModel(model) {
+		applyReasoningOptions(&openaiReq, req.Options)
+		if openaiReq.MaxCompletionTokens == 0 && req.MaxTokens != nil && *req.MaxTokens > 0 {
+			openaiReq.MaxCompletionTokens = *req.MaxTokens
+		}
+		return openaiReq, nil
+	}
+
 	if req.Temperature != nil && *req.Temperature > 0 {
 		openaiReq.Temperature = float32(*req.Temperature)
 	}
@@ -132,11 +180,11 @@ func (s *ChatService) convertToOpenAIRequest(req interfaces.ChatRequest) openai.
 		openaiReq.TopP = float32(*req.TopP)
 	}
 
-	return openaiReq
+	return openaiReq, nil
 }
 
-// convertFromOpenAIResponse converts OpenAI response to interface chunk
-func (s *ChatService) convertFromOpenAIResponse(resp openai.ChatCompletionStreamResponse) interfaces.ChatChunk {
+// convertFromOpenAIResponse converts OpenAI response to interface chunk, recording tool-call fragments
+func (s *ChatService) convertFromOpenAIResponse(resp openai.ChatCompletionStreamResponse, toolCalls *toolCallAccumulator) interfaces.ChatChunk {
 	chunk := interfaces.ChatChunk{}
 
 	if len(resp.Choices) > 0 {
@@ -144,6 +192,7 @@ func (s *ChatService) convertFromOpenAIResponse(resp openai.ChatCompletionStream
 		chunk.Delta = choice.Delta.Content
 		chunk.Content = choice.Delta.Content
 		chunk.FinishReason = string(choice.FinishReason)
+		chunk.ToolCalls = toolCalls.add(choice.Delta.ToolCalls)
 
 		if choice.FinishReason == "stop" || choice.FinishReason == "length" {
 			chunk.Done = true
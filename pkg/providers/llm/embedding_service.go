@@ -3,6 +3,9 @@ package llm
 import (
 	"context"
 	"fmt"
+	"slices"
+
+	"github.com/creastat/common-go/pkg/models"
 
 	"github.com/sashabaranov/go-openai"
 )
@@ -21,19 +24,29 @@ func NewEmbeddingService(provider *OpenAICompatibleProvider) *EmbeddingService {
 
 // GenerateEmbedding generates an embedding for the given text
 func (s *EmbeddingService) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := s.GenerateEmbeddings(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// GenerateEmbeddings generates one embedding per text in input order, sending the texts in
+// batches of at most the provider's batch size. The embedding timeout bounds each batch rather
+// than the whole call. Once ctx is done no further batch is sent, and the embeddings of the
+// batches already completed are returned with the context error.
+func (s *EmbeddingService) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	if len(texts) == 0 {
+		return nil, nil
+	}
 
-	// Get model from provider config
-	model := s.provider.config.Model
+	// Get model from provider config; Yandex falls back to its default model
+	model := s.provider.embeddingModel()
 
-	// For Yandex, allow empty model and use default
 	if s.provider.name == "yandex" {
-		if model == "" {
-			model = "text-search-query/latest"
-		}
-
 		if folderID, ok := s.provider.config.Options["folder_id"].(string); ok && folderID != "" {
 			// Model format: emb://<folder_id>/<model_name>
 			model = fmt.Sprintf("emb://%s/%s", folderID, model)
@@ -45,19 +58,19 @@ func (s *EmbeddingService) GenerateEmbedding(ctx context.Context, text string) (
 		}
 	}
 
-	req := openai.EmbeddingRequest{
-		Input: []string{text},
-		Model: openai.EmbeddingModel(model),
+	if err := s.provider.checkModel(s.provider.embeddingModel(), nil); err != nil {
+		return nil, err
 	}
 
-	// Check for dimensions in options
-	if dims, ok := s.provider.config.Options["dimensions"]; ok {
-		switch v := dims.(type) {
-		case int:
-			req.Dimensions = v
-		case float64:
-			req.Dimensions = int(v)
-		}
+	// Reduced dimensions are only requested from models that support them
+	dimensions, err := s.provider.resolveEmbeddingDimensions(s.provider.embeddingModel())
+	if err != nil {
+		return nil, err
+	}
+
+	req := openai.EmbeddingRequest{
+		Model:      openai.EmbeddingModel(model),
+		Dimensions: dimensions,
 	}
 
 	// Check for encoding_format in options
@@ -70,16 +83,46 @@ func (s *EmbeddingService) GenerateEmbedding(ctx context.Context, text string) (
 		req.EncodingFormat = openai.EmbeddingEncodingFormatFloat
 	}
 
-	resp, err := s.provider.client.CreateEmbeddings(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create embeddings: %w", err)
-	}
+	embeddings := make([][]float32, 0, len(texts))
+	for batch := range slices.Chunk(texts, s.provider.embeddingBatchSize()) {
+		if err := ctx.Err(); err != nil {
+			return embeddings, embeddingsStopped(len(embeddings), len(texts), err)
+		}
+		req.Input = batch
+
+		batchCtx, cancel := models.WithCapabilityTimeout(ctx, s.provider.config, models.CapabilityEmbedding)
+		resp, err := s.provider.client.CreateEmbeddings(batchCtx, req)
+		cancel()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return embeddings, embeddingsStopped(len(embeddings), len(texts), ctxErr)
+			}
+			return nil, fmt.Errorf("failed to create embeddings: %w", err)
+		}
+
+		// The API reports each embedding's input index, which need not follow response order
+		ordered := make([][]float32, len(batch))
+		for _, data := range resp.Data {
+			if data.Index < 0 || data.Index >= len(batch) {
+				return nil, fmt.Errorf("embedding index %d out of range for a batch of %d", data.Index, len(batch))
+			}
+			ordered[data.Index] = data.Embedding
+		}
+		for i, embedding := range ordered {
+			if embedding == nil {
+				return nil, fmt.Errorf("no embedding returned for input %d", len(embeddings)+i)
+			}
+		}
 
-	if len(resp.Data) == 0 {
-		return nil, fmt.Errorf("no embeddings returned")
+		embeddings = append(embeddings, ordered...)
 	}
 
-	return resp.Data[0].Embedding, nil
+	return embeddings, nil
+}
+
+// embeddingsStopped reports a batched embedding call cut short by its context after done of total texts
+func embeddingsStopped(done, total int, err error) error {
+	return fmt.Errorf("embedding stopped after %d of %d texts: %w", done, total, err)
 }
 
 // GetDimensions returns the embedding dimensions
@@ -0,0 +1,149 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+func TestGenerateEmbeddingsStopsBetweenBatches(t *testing.T) {
+	texts := []string{"a", "b", "c", "d", "e"}
+	options := map[string]any{"embedding_batch_size": 2}
+
+	tests := []struct {
+		name string
+		// cancelAt is the request during which the ingest is cancelled; 0 cancels before the call
+		cancelAt int32
+		want     int
+	}{
+		{name: "cancelled before the first batch", cancelAt: 0, want: 0},
+		{name: "cancelled during the second batch", cancelAt: 2, want: 2},
+		{name: "cancelled during the last batch", cancelAt: 3, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelAt == 0 {
+				cancel()
+			}
+
+			var requests atomic.Int32
+			provider := newTestProvider(t, OpenAIConfig, []string{"text-embedding-3-small"}, options, func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Input []string `json:"input"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+
+				n := requests.Add(1)
+				if n == tt.cancelAt {
+					// Hold the batch open until the client abandons it
+					cancel()
+					<-r.Context().Done()
+					return
+				}
+
+				data := make([]map[string]any, len(body.Input))
+				for i := range body.Input {
+					data[i] = map[string]any{"object": "embedding", "index": i, "embedding": []float32{float32(n), float32(i)}}
+				}
+				json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data})
+			})
+			provider.config.Model = "text-embedding-3-small"
+
+			embeddings, err := NewEmbeddingService(provider).GenerateEmbeddings(ctx, texts)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected a cancellation error, got %v", err)
+			}
+
+			if len(embeddings) != tt.want {
+				t.Fatalf("expected the %d embeddings of the completed batches, got %d", tt.want, len(embeddings))
+			}
+			for i, embedding := range embeddings {
+				if embedding[0] != float32(i/2+1) || embedding[1] != float32(i%2) {
+					t.Errorf("embedding %d is out of order: %v", i, embedding)
+				}
+			}
+			if n := requests.Load(); n != tt.cancelAt {
+				t.Errorf("expected no request after the cancellation, got %d requests", n)
+			}
+		})
+	}
+}
+
+func TestEmbeddingTimeoutBoundsEachBatch(t *testing.T) {
+	// Each batch fits the timeout, but the four of them together do not
+	texts := []string{"a", "b", "c", "d"}
+	options := map[string]any{"embedding_batch_size": 1, "embedding_timeout": "150ms"}
+
+	var requests atomic.Int32
+	provider := newTestProvider(t, OpenAIConfig, []string{"text-embedding-3-small"}, options, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&struct{}{})
+		requests.Add(1)
+		time.Sleep(60 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": []map[string]any{
+			{"object": "embedding", "index": 0, "embedding": []float32{1}},
+		}})
+	})
+	provider.config.Model = "text-embedding-3-small"
+
+	embeddings, err := NewEmbeddingService(provider).GenerateEmbeddings(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("GenerateEmbeddings: %v", err)
+	}
+	if len(embeddings) != len(texts) || requests.Load() != int32(len(texts)) {
+		t.Errorf("expected %d embeddings from %d requests, got %d from %d", len(texts), len(texts), len(embeddings), requests.Load())
+	}
+}
+
+func TestGetDimensionsReportsOnlyAcceptedDimensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ProviderConfig
+		model   string
+		options map[string]any
+		want    int
+	}{
+		{name: "model metadata", config: OpenAIConfig, model: "text-embedding-3-small", want: 1536},
+		{name: "accepted option", config: OpenAIConfig, model: "text-embedding-3-small", options: map[string]any{"dimensions": 512}, want: 512},
+		{name: "zero is unset", config: OpenAIConfig, model: "text-embedding-3-small", options: map[string]any{"dimensions": 0}, want: 1536},
+		{name: "model without reduction", config: OpenAIConfig, model: "text-embedding-ada-002", options: map[string]any{"dimensions": 512}, want: 1536},
+		{name: "larger than the model", config: OpenAIConfig, model: "text-embedding-3-small", options: map[string]any{"dimensions": 4096}, want: 1536},
+		{name: "yandex", config: YandexConfig, options: map[string]any{"dimensions": 128}, want: 256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewOpenAICompatibleProvider(tt.config)
+			provider.config = models.ProviderConfig{Model: tt.model, Options: tt.options}
+
+			if got := provider.GetDimensions(); got != tt.want {
+				t.Errorf("GetDimensions: got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeminiTreatsZeroDimensionsAsUnset(t *testing.T) {
+	provider := NewGeminiProvider()
+	provider.config = models.ProviderConfig{Options: map[string]any{"embedding_model": "gemini-embedding-001", "dimensions": 0}}
+
+	if config, err := provider.embedContentConfig(); err != nil || config != nil {
+		t.Errorf("expected no output dimensionality, got %v and %v", config, err)
+	}
+	if got := provider.GetDimensions(); got != 3072 {
+		t.Errorf("GetDimensions: got %d, want the model's 3072", got)
+	}
+
+	provider.config.Options["dimensions"] = -1
+	if _, err := provider.embedContentConfig(); err == nil {
+		t.Error("expected negative dimensions to be rejected")
+	}
+}
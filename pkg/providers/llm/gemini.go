@@ -3,15 +3,47 @@ package llm
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/sanitize"
 	"github.com/creastat/common-go/pkg/types"
 
 	"google.golang.org/genai"
 )
 
+const (
+	// defaultGeminiChatModel is used when neither the config nor the options specify a model
+	defaultGeminiChatModel = "gemini-1.5-flash"
+
+	// defaultGeminiEmbeddingModel is used when the embedding_model option is not set
+	defaultGeminiEmbeddingModel = "text-embedding-004"
+
+	// defaultGeminiEmbeddingDimensions is reported for embedding models with unknown output length
+	defaultGeminiEmbeddingDimensions = 768
+
+	// geminiEmbeddingBatchSize is the most texts the API embeds in one request
+	geminiEmbeddingBatchSize = 100
+)
+
+// geminiEmbeddingDimensions maps known embedding models to their output vector length
+var geminiEmbeddingDimensions = map[string]int{
+	"text-embedding-004":              768,
+	"text-multilingual-embedding-002": 768,
+	"embedding-001":                   768,
+	"gemini-embedding-001":            3072,
+}
+
+// geminiReducibleEmbeddingModels lists the embedding models that accept a reduced output dimensionality
+var geminiReducibleEmbeddingModels = map[string]bool{
+	"text-embedding-004":              true,
+	"text-multilingual-embedding-002": true,
+	"gemini-embedding-001":            true,
+}
+
 // GeminiProvider implements the Provider interface for Google Gemini
 type GeminiProvider struct {
 	name         string
@@ -179,18 +211,129 @@ func (p *GeminiProvider) ChatCompletion(ctx context.Context, messages []types.Ch
 	if !p.initialized {
 		return "", fmt.Errorf("provider not initialized")
 	}
-	return "", fmt.Errorf("Gemini chat completion not yet implemented")
+
+	ctx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilityChat)
+	defer cancel()
+
+	model, contents, config := p.buildGenerateRequest(messages, options)
+
+	resp, err := p.client.Models.GenerateContent(ctx, model, contents, config)
+	if err != nil {
+		return "", fmt.Errorf("chat completion failed: %w", err)
+	}
+
+	if len(resp.Candidates) == 0 {
+		return "", fmt.Errorf("no response from model")
+	}
+
+	candidate := resp.Candidates[0]
+	if candidate.FinishReason != "" && candidate.FinishReason != genai.FinishReasonStop {
+		return "", fmt.Errorf("generation stopped with finish reason %s", candidate.FinishReason)
+	}
+
+	models.RecordResponseMetadata(ctx, p.name, effectiveGeminiModel(resp, model))
+
+	return candidateText(candidate), nil
+}
+
+// buildGenerateRequest converts chat messages and options into a Gemini request
+func (p *GeminiProvider) buildGenerateRequest(messages []types.ChatMessage, options map[string]any) (string, []*genai.Content, *genai.GenerateContentConfig) {
+	model := p.config.Model
+	if modelOpt, ok := options["model"].(string); ok && modelOpt != "" {
+		model = modelOpt
+	}
+	if model == "" {
+		model = defaultGeminiChatModel
+	}
+
+	config := &genai.GenerateContentConfig{}
+	contents := make([]*genai.Content, 0, len(messages))
+	var systemParts []*genai.Part
+
+	for _, msg := range messages {
+		switch msg.Role {
+		case "system":
+			systemParts = append(systemParts, genai.NewPartFromText(sanitize.Text(msg.Content)))
+		case "assistant", genai.RoleModel:
+			contents = append(contents, genai.NewContentFromText(sanitize.Text(msg.Content), genai.RoleModel))
+		default:
+			contents = append(contents, genai.NewContentFromText(sanitize.Text(msg.Content), genai.RoleUser))
+		}
+	}
+
+	if len(systemParts) > 0 {
+		config.SystemInstruction = &genai.Content{Parts: systemParts}
+	}
+
+	// Apply options
+	if temp, ok := options["temperature"].(float64); ok {
+		config.Temperature = genai.Ptr(float32(temp))
+	}
+	if maxTokens, ok := options["max_tokens"].(int); ok {
+		config.MaxOutputTokens = int32(maxTokens)
+	}
+	if topP, ok := options["top_p"].(float64); ok {
+		config.TopP = genai.Ptr(float32(topP))
+	}
+
+	return model, contents, config
+}
+
+// effectiveGeminiModel returns the model version that served a response, falling back to the requested model
+func effectiveGeminiModel(resp *genai.GenerateContentResponse, requested string) string {
+	if resp.ModelVersion != "" {
+		return resp.ModelVersion
+	}
+	return requested
+}
+
+// candidateText concatenates the text parts of a candidate
+func candidateText(candidate *genai.Candidate) string {
+	if candidate.Content == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, part := range candidate.Content.Parts {
+		if part.Text != "" && !part.Thought {
+			sb.WriteString(part.Text)
+		}
+	}
+
+	return sb.String()
 }
 
 // StreamChatCompletion implements ChatService interface
 func (p *GeminiProvider) StreamChatCompletion(ctx context.Context, messages []types.ChatMessage, options map[string]any) (<-chan string, <-chan error) {
-	contentChan := make(chan string)
+	contentChan := make(chan string, 10)
 	errChan := make(chan error, 1)
 
 	go func() {
 		defer close(contentChan)
 		defer close(errChan)
-		errChan <- fmt.Errorf("Gemini streaming chat completion not yet implemented")
+
+		if !p.initialized {
+			errChan <- fmt.Errorf("provider not initialized")
+			return
+		}
+
+		ctx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilityChat)
+		defer cancel()
+
+		model, contents, config := p.buildGenerateRequest(messages, options)
+		usage, err := p.generateStream(ctx, model, contents, config, func(content string) error {
+			select {
+			case contentChan <- content:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errChan <- err
+			return
+		}
+		models.RecordUsage(ctx, usage)
 	}()
 
 	return contentChan, errChan
@@ -198,8 +341,124 @@ func (p *GeminiProvider) StreamChatCompletion(ctx context.Context, messages []ty
 
 // StreamCompletion implements ChatService interface
 func (p *GeminiProvider) StreamCompletion(ctx context.Context, req interfaces.ChatRequest, stream interfaces.ChatStream) error {
-	// Gemini implementation would go here
-	return fmt.Errorf("Gemini streaming not yet implemented")
+	if !p.initialized {
+		return fmt.Errorf("provider not initialized")
+	}
+
+	ctx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilityChat)
+	defer cancel()
+
+	model, contents, config := p.buildGenerateRequest(req.Messages, chatRequestOptions(req))
+	usage, err := p.generateStream(ctx, model, contents, config, func(content string) error {
+		if err := stream.Send(interfaces.ChatChunk{Delta: content, Content: content}); err != nil {
+			return fmt.Errorf("failed to send chunk: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	models.RecordUsage(ctx, usage)
+
+	if err := stream.Send(interfaces.ChatChunk{Done: true, Usage: usage}); err != nil {
+		return fmt.Errorf("failed to send final chunk: %w", err)
+	}
+	return nil
+}
+
+// chatRequestOptions folds the model and sampling fields of a chat request into its options
+func chatRequestOptions(req interfaces.ChatRequest) map[string]any {
+	options := make(map[string]any, len(req.Options)+4)
+	for key, value := range req.Options {
+		options[key] = value
+	}
+
+	if req.Model != "" {
+		options["model"] = req.Model
+	}
+	if req.Temperature != nil {
+		options["temperature"] = *req.Temperature
+	}
+	if req.MaxTokens != nil && *req.MaxTokens > 0 {
+		options["max_tokens"] = *req.MaxTokens
+	}
+	if req.TopP != nil {
+		options["top_p"] = *req.TopP
+	}
+
+	return options
+}
+
+// generateStream streams a Gemini response, passing each piece of text to send, and returns the
+// token usage of the response
+func (p *GeminiProvider) generateStream(
+	ctx context.Context,
+	model string,
+	contents []*genai.Content,
+	config *genai.GenerateContentConfig,
+	send func(content string) error,
+) (*models.TokenUsage, error) {
+	// A delta may end mid-rune; the remainder is held back until the next delta
+	var runes runeBuffer
+
+	// Each response repeats the usage so far; the last one holds the totals
+	var usage *models.TokenUsage
+
+	received := false
+	for resp, err := range p.client.Models.GenerateContentStream(ctx, model, contents, config) {
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("stream error: %w", err)
+		}
+
+		if resp.UsageMetadata != nil {
+			usage = convertGeminiUsage(resp.UsageMetadata)
+		}
+		if len(resp.Candidates) == 0 {
+			continue
+		}
+		if !received {
+			models.RecordResponseMetadata(ctx, p.name, effectiveGeminiModel(resp, model))
+		}
+		received = true
+
+		// Apply the same rules as ChatCompletion so both paths yield identical text
+		candidate := resp.Candidates[0]
+		if content := runes.push(candidateText(candidate)); content != "" {
+			if err := send(content); err != nil {
+				return nil, err
+			}
+		}
+
+		if candidate.FinishReason != "" && candidate.FinishReason != genai.FinishReasonStop {
+			return nil, fmt.Errorf("generation stopped with finish reason %s", candidate.FinishReason)
+		}
+	}
+
+	if !received {
+		return nil, fmt.Errorf("no response from model")
+	}
+	if rest := runes.flush(); rest != "" {
+		if err := send(rest); err != nil {
+			return nil, err
+		}
+	}
+
+	return usage, nil
+}
+
+// convertGeminiUsage converts Gemini usage metadata, returning nil when it reports no tokens
+func convertGeminiUsage(usage *genai.GenerateContentResponseUsageMetadata) *models.TokenUsage {
+	if usage.TotalTokenCount == 0 && usage.PromptTokenCount == 0 && usage.CandidatesTokenCount == 0 {
+		return nil
+	}
+	return &models.TokenUsage{
+		PromptTokens:     int(usage.PromptTokenCount),
+		CompletionTokens: int(usage.CandidatesTokenCount),
+		TotalTokens:      int(usage.TotalTokenCount),
+	}
 }
 
 // GetModels implements ChatService interface
@@ -220,10 +479,125 @@ func (p *GeminiProvider) GetModels(ctx context.Context) ([]models.Model, error)
 
 // GenerateEmbedding implements EmbeddingService interface
 func (p *GeminiProvider) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
-	return nil, fmt.Errorf("Gemini embeddings not yet implemented")
+	embeddings, err := p.GenerateEmbeddings(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// GenerateEmbeddings implements EmbeddingService interface, embedding up to
+// geminiEmbeddingBatchSize texts per request. The embedding timeout bounds each request rather
+// than the whole call. Once ctx is done no further batch is sent, and the embeddings of the
+// batches already completed are returned with the context error.
+func (p *GeminiProvider) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if !p.initialized {
+		return nil, fmt.Errorf("provider not initialized")
+	}
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	contents := make([]*genai.Content, len(texts))
+	for i, text := range texts {
+		if strings.TrimSpace(text) == "" {
+			if len(texts) == 1 {
+				return nil, fmt.Errorf("embedding input text is empty")
+			}
+			return nil, fmt.Errorf("embedding input text %d is empty", i)
+		}
+		contents[i] = genai.NewContentFromText(text, genai.RoleUser)
+	}
+
+	config, err := p.embedContentConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+	for batch := range slices.Chunk(contents, geminiEmbeddingBatchSize) {
+		if err := ctx.Err(); err != nil {
+			return embeddings, embeddingsStopped(len(embeddings), len(texts), err)
+		}
+
+		batchCtx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilityEmbedding)
+		res, err := p.client.Models.EmbedContent(batchCtx, p.embeddingModel(), batch, config)
+		cancel()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return embeddings, embeddingsStopped(len(embeddings), len(texts), ctxErr)
+			}
+			return nil, fmt.Errorf("failed to create embeddings: %w", err)
+		}
+
+		if len(res.Embeddings) != len(batch) {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", len(batch), len(res.Embeddings))
+		}
+
+		for _, embedding := range res.Embeddings {
+			embeddings = append(embeddings, embedding.Values)
+		}
+	}
+
+	return embeddings, nil
 }
 
 // GetDimensions implements EmbeddingService interface
 func (p *GeminiProvider) GetDimensions() int {
-	return 768
+	if config, err := p.embedContentConfig(); err == nil && config != nil {
+		return int(*config.OutputDimensionality)
+	}
+	if dims, ok := geminiEmbeddingDimensions[strings.TrimPrefix(p.embeddingModel(), "models/")]; ok {
+		return dims
+	}
+	return defaultGeminiEmbeddingDimensions
+}
+
+// embedContentConfig requests the "dimensions" option as the output dimensionality. It is an error
+// for models that cannot reduce their output, so full-length vectors are never returned silently.
+func (p *GeminiProvider) embedContentConfig() (*genai.EmbedContentConfig, error) {
+	dims := requestedDimensions(p.config.Options)
+	if dims == 0 {
+		return nil, nil
+	}
+	if dims < 0 {
+		return nil, fmt.Errorf("invalid embedding dimensions %d", dims)
+	}
+
+	model := strings.TrimPrefix(p.embeddingModel(), "models/")
+	if !geminiReducibleEmbeddingModels[model] {
+		return nil, fmt.Errorf("embedding model %s does not support the dimensions option", model)
+	}
+	if native, ok := geminiEmbeddingDimensions[model]; ok && dims > native {
+		return nil, fmt.Errorf("embedding dimensions %d exceed the %d produced by model %s", dims, native, model)
+	}
+
+	outputDimensionality := int32(dims)
+	return &genai.EmbedContentConfig{OutputDimensionality: &outputDimensionality}, nil
+}
+
+// DescribeCapabilities reports the provider's chat and embedding capabilities
+func (p *GeminiProvider) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{
+		Chat:      &models.ChatCapability{Streaming: true},
+		Embedding: &models.EmbeddingCapability{Dimensions: p.GetDimensions(), SupportedModels: []string{p.embeddingModel()}},
+	}
+}
+
+// SupportedOptions describes the Options keys the provider honors
+func (p *GeminiProvider) SupportedOptions() []models.OptionDescriptor {
+	return append([]models.OptionDescriptor{
+		{Name: "embedding_model", Type: models.OptionTypeString, Scope: models.OptionScopeProvider, Description: "model used for embeddings"},
+		{Name: "dimensions", Type: models.OptionTypeInt, Scope: models.OptionScopeProvider, Description: "reduced embedding dimensions to request from models that support it"},
+		models.CapabilityTimeoutOption(models.CapabilityChat),
+		models.CapabilityTimeoutOption(models.CapabilityEmbedding),
+	}, chatOptions...)
+}
+
+// embeddingModel returns the configured embedding model id
+func (p *GeminiProvider) embeddingModel() string {
+	if model, ok := p.config.Options["embedding_model"].(string); ok && model != "" {
+		return model
+	}
+	return defaultGeminiEmbeddingModel
 }
@@ -0,0 +1,139 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+
+	"google.golang.org/genai"
+)
+
+// newTestGeminiProvider returns an initialized Gemini provider whose requests are served by handler
+func newTestGeminiProvider(t *testing.T, handler http.HandlerFunc) *GeminiProvider {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:      "test-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPOptions: genai.HTTPOptions{BaseURL: server.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	provider := NewGeminiProvider()
+	provider.client = client
+	provider.initialized = true
+	return provider
+}
+
+// streamGeminiResponses serves each response as a server-sent event
+func streamGeminiResponses(responses ...map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, response := range responses {
+			data, _ := json.Marshal(response)
+			fmt.Fprintf(w, "data: %s\n\n", data)
+		}
+	}
+}
+
+// geminiTextResponse is a streamed response carrying text in a single candidate
+func geminiTextResponse(text, finishReason string, usage map[string]any) map[string]any {
+	candidate := map[string]any{"content": map[string]any{"role": "model", "parts": []map[string]any{{"text": text}}}}
+	if finishReason != "" {
+		candidate["finishReason"] = finishReason
+	}
+	response := map[string]any{"candidates": []map[string]any{candidate}, "modelVersion": "gemini-1.5-pro-002"}
+	if usage != nil {
+		response["usageMetadata"] = usage
+	}
+	return response
+}
+
+func TestGeminiStreamCompletion(t *testing.T) {
+	var path string
+	var body map[string]any
+	serve := streamGeminiResponses(
+		geminiTextResponse("Hello", "", map[string]any{"promptTokenCount": 4}),
+		geminiTextResponse(", café", "", nil),
+		geminiTextResponse("!", "STOP", map[string]any{"promptTokenCount": 4, "candidatesTokenCount": 5, "totalTokenCount": 9}),
+	)
+	provider := newTestGeminiProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		serve(w, r)
+	})
+
+	temperature := 0.3
+	ctx, metadata := models.WithResponseMetadata(context.Background())
+	recorder := &chunkRecorder{}
+	err := provider.StreamCompletion(ctx, interfaces.ChatRequest{
+		Model:       "gemini-1.5-pro",
+		Messages:    []types.ChatMessage{{Role: "system", Content: "Be brief"}, {Role: "user", Content: "hi"}},
+		Temperature: &temperature,
+	}, recorder)
+	if err != nil {
+		t.Fatalf("StreamCompletion: %v", err)
+	}
+
+	if !strings.Contains(path, "models/gemini-1.5-pro:streamGenerateContent") {
+		t.Errorf("expected the requested model to be streamed, got path %s", path)
+	}
+	if config, _ := body["generationConfig"].(map[string]any); config["temperature"] != 0.3 {
+		t.Errorf("expected the request temperature, got %v", body["generationConfig"])
+	}
+	if body["systemInstruction"] == nil {
+		t.Errorf("expected the system message as the system instruction, got %v", body)
+	}
+
+	var content string
+	for _, chunk := range recorder.chunks[:len(recorder.chunks)-1] {
+		if chunk.Done {
+			t.Errorf("unexpected Done before the final chunk: %+v", chunk)
+		}
+		content += chunk.Delta
+	}
+	if content != "Hello, café!" {
+		t.Errorf("got content %q", content)
+	}
+
+	wantUsage := &models.TokenUsage{PromptTokens: 4, CompletionTokens: 5, TotalTokens: 9}
+	final := recorder.chunks[len(recorder.chunks)-1]
+	if !final.Done || !reflect.DeepEqual(final.Usage, wantUsage) {
+		t.Errorf("expected a final Done chunk with usage %+v, got %+v", wantUsage, final)
+	}
+	if metadata.Model() != "gemini-1.5-pro-002" || !reflect.DeepEqual(metadata.Usage(), wantUsage) {
+		t.Errorf("unexpected response metadata: model %q, usage %+v", metadata.Model(), metadata.Usage())
+	}
+}
+
+func TestGeminiStreamCompletionStopsOnBlockedResponse(t *testing.T) {
+	provider := newTestGeminiProvider(t, streamGeminiResponses(
+		geminiTextResponse("Partial", "", nil),
+		geminiTextResponse("", "SAFETY", nil),
+	))
+
+	recorder := &chunkRecorder{}
+	err := provider.StreamCompletion(context.Background(), interfaces.ChatRequest{Messages: []types.ChatMessage{{Role: "user", Content: "hi"}}}, recorder)
+	if err == nil || !strings.Contains(err.Error(), "SAFETY") {
+		t.Fatalf("expected the finish reason to be reported, got %v", err)
+	}
+	for _, chunk := range recorder.chunks {
+		if chunk.Done {
+			t.Errorf("a failed stream must not be marked done: %+v", chunk)
+		}
+	}
+}
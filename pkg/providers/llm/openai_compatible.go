@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/creastat/common-go/pkg/interfaces"
@@ -25,6 +26,15 @@ func (t *yandexTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return t.base.RoundTrip(req)
 }
 
+const (
+	// defaultEmbeddingDimensions is the size of OpenAI's default embedding models
+	defaultEmbeddingDimensions = 1536
+	// defaultYandexEmbeddingModel is used for Yandex when no model is configured
+	defaultYandexEmbeddingModel = "text-search-query/latest"
+	// defaultEmbeddingBatchSize is the most inputs OpenAI accepts in one embeddings request
+	defaultEmbeddingBatchSize = 2048
+)
+
 // OpenAICompatibleProvider is a universal provider for OpenAI-compatible APIs
 type OpenAICompatibleProvider struct {
 	name         string
@@ -34,6 +44,10 @@ type OpenAICompatibleProvider struct {
 	capabilities []types.Capability
 	initialized  bool
 	modelInfo    []models.Model
+	// dynamicModels skips the model pre-flight check because modelInfo is not exhaustive
+	dynamicModels bool
+	// catalog holds the model IDs listed by the API during initialization
+	catalog []string
 }
 
 // ProviderConfig holds provider-specific configuration
@@ -43,6 +57,9 @@ type ProviderConfig struct {
 	BaseURL      string
 	Models       []models.Model
 	DefaultModel string
+	// DynamicModels marks Models as a sample of a larger, changing catalog, so requested
+	// model IDs are passed to the API without a pre-flight check
+	DynamicModels bool
 }
 
 // Predefined provider configurations
@@ -74,16 +91,31 @@ var (
 				Name:        "Text Embedding 3 Small",
 				Description: "Small embedding model",
 				Capability:  models.CapabilityEmbedding,
+				Metadata:    map[string]any{"dimensions": 1536, "supports_dimensions": true},
+			},
+			{
+				ID:          "text-embedding-3-large",
+				Name:        "Text Embedding 3 Large",
+				Description: "Large embedding model",
+				Capability:  models.CapabilityEmbedding,
+				Metadata:    map[string]any{"dimensions": 3072, "supports_dimensions": true},
+			},
+			{
+				ID:          "text-embedding-ada-002",
+				Name:        "Text Embedding Ada 002",
+				Description: "Legacy embedding model",
+				Capability:  models.CapabilityEmbedding,
 				Metadata:    map[string]any{"dimensions": 1536},
 			},
 		},
 	}
 
 	OpenRouterConfig = ProviderConfig{
-		Name:         "openrouter",
-		Type:         models.ProviderTypeOpenRouter,
-		BaseURL:      "https://openrouter.ai/api/v1",
-		DefaultModel: "openai/gpt-4o-mini",
+		Name:          "openrouter",
+		Type:          models.ProviderTypeOpenRouter,
+		BaseURL:       "https://openrouter.ai/api/v1",
+		DefaultModel:  "openai/gpt-4o-mini",
+		DynamicModels: true,
 		Models: []models.Model{
 			{
 				ID:          "openai/gpt-4o-mini",
@@ -185,6 +217,26 @@ var (
 	}
 )
 
+// PredefinedConfigs maps provider names to their predefined configurations
+var PredefinedConfigs = map[string]ProviderConfig{
+	OpenAIConfig.Name:     OpenAIConfig,
+	OpenRouterConfig.Name: OpenRouterConfig,
+	YandexConfig.Name:     YandexConfig,
+	MinimaxLLMConfig.Name: MinimaxLLMConfig,
+}
+
+// GetPredefinedConfig returns a copy of the predefined configuration for a provider name
+func GetPredefinedConfig(name string) (ProviderConfig, bool) {
+	config, ok := PredefinedConfigs[name]
+	if !ok {
+		return ProviderConfig{}, false
+	}
+
+	// Copy the models so callers cannot mutate the shared configuration
+	config.Models = append([]models.Model(nil), config.Models...)
+	return config, true
+}
+
 // NewOpenAICompatibleProvider creates a new OpenAI-compatible provider
 func NewOpenAICompatibleProvider(providerConfig ProviderConfig) *OpenAICompatibleProvider {
 	return &OpenAICompatibleProvider{
@@ -194,8 +246,9 @@ func NewOpenAICompatibleProvider(providerConfig ProviderConfig) *OpenAICompatibl
 			types.CapabilityChat,
 			types.CapabilityEmbedding,
 		},
-		modelInfo:   providerConfig.Models,
-		initialized: false,
+		modelInfo:     providerConfig.Models,
+		dynamicModels: providerConfig.DynamicModels,
+		initialized:   false,
 	}
 }
 
@@ -204,9 +257,12 @@ func (p *OpenAICompatibleProvider) Name() string {
 	return p.name
 }
 
-// Type returns the provider type
+// Type returns the configured provider type, defaulting to OpenAI for custom configurations
 func (p *OpenAICompatibleProvider) Type() models.ProviderType {
-	return models.ProviderTypeOpenAI
+	if p.providerType == "" {
+		return models.ProviderTypeOpenAI
+	}
+	return p.providerType
 }
 
 // Capabilities returns the list of capabilities
@@ -260,10 +316,22 @@ func (p *OpenAICompatibleProvider) Initialize(ctx context.Context, config models
 
 // validateAPIKey validates the API key
 func (p *OpenAICompatibleProvider) validateAPIKey(ctx context.Context) error {
-	validateCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	err := withRetry(ctx, p.config.RetryPolicy, func(ctx context.Context) error {
+		validateCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		list, err := p.client.ListModels(validateCtx)
+		if err != nil {
+			return err
+		}
 
-	_, err := p.client.ListModels(validateCtx)
+		// Keep the listed models for the pre-flight model check
+		p.catalog = make([]string, len(list.Models))
+		for i, model := range list.Models {
+			p.catalog[i] = model.ID
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("API key validation failed: %w", err)
 	}
@@ -311,7 +379,7 @@ func (p *OpenAICompatibleProvider) IsInitialized() bool {
 
 // GetProviderInfo returns metadata about the provider
 func (p *OpenAICompatibleProvider) GetProviderInfo() *models.ProviderInfo {
-	info := models.NewProviderInfo(p.name, p.providerType, []models.Capability{
+	info := models.NewProviderInfo(p.name, p.Type(), []models.Capability{
 		models.CapabilityChat,
 		models.CapabilityEmbedding,
 	})
@@ -335,18 +403,88 @@ func (p *OpenAICompatibleProvider) GetProviderInfo() *models.ProviderInfo {
 
 // ChatCompletion implements ChatService interface
 func (p *OpenAICompatibleProvider) ChatCompletion(ctx context.Context, messages []types.ChatMessage, options map[string]any) (string, error) {
+	content, _, err := p.ChatCompletionWithUsage(ctx, messages, options)
+	return content, err
+}
+
+// ChatCompletionWithUsage runs a chat completion and also returns the token usage reported by the API
+func (p *OpenAICompatibleProvider) ChatCompletionWithUsage(ctx context.Context, messages []types.ChatMessage, options map[string]any) (string, *models.TokenUsage, error) {
 	if !p.initialized {
-		return "", fmt.Errorf("provider not initialized")
+		return "", nil, fmt.Errorf("provider not initialized")
 	}
 
-	// Convert messages
-	openaiMessages := make([]openai.ChatCompletionMessage, len(messages))
-	for i, msg := range messages {
-		openaiMessages[i] = openai.ChatCompletionMessage{
-			Role:    msg.Role,
-			Content: msg.Content,
-		}
+	ctx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilityChat)
+	defer cancel()
+
+	req, err := p.chatRequest(messages, options)
+	if err != nil {
+		return "", nil, err
+	}
+	model := req.Model
+
+	var resp openai.ChatCompletionResponse
+	err = withRetry(ctx, p.config.RetryPolicy, func(ctx context.Context) error {
+		var err error
+		resp, err = p.client.CreateChatCompletion(ctx, req)
+		return err
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("chat completion failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", nil, fmt.Errorf("no response from model")
+	}
+
+	// Report the resolved model, falling back to the requested one
+	effectiveModel := resp.Model
+	if effectiveModel == "" {
+		effectiveModel = model
+	}
+	models.RecordResponseMetadata(ctx, p.name, effectiveModel)
+	models.RecordToolCalls(ctx, convertToolCalls(resp.Choices[0].Message.ToolCalls))
+	usage := convertUsage(resp.Usage)
+	models.RecordUsage(ctx, usage)
+
+	return resp.Choices[0].Message.Content, usage, nil
+}
+
+// streamUsage reports whether streamed completions ask for a trailing usage chunk. Only OpenAI is
+// known to accept stream_options; other endpoints may reject the request, so the "stream_usage"
+// option has to turn it on for them.
+func (p *OpenAICompatibleProvider) streamUsage() bool {
+	if include, ok := p.config.Options["stream_usage"].(bool); ok {
+		return include
+	}
+	return p.providerType == models.ProviderTypeOpenAI
+}
+
+// convertUsage converts OpenAI token usage, returning nil when the API reported none
+func convertUsage(usage openai.Usage) *models.TokenUsage {
+	if usage.TotalTokens == 0 && usage.PromptTokens == 0 && usage.CompletionTokens == 0 {
+		return nil
+	}
+	return &models.TokenUsage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
 	}
+}
+
+// BuildChatRequest returns the request ChatCompletion would send for the messages and options
+// without calling the API
+func (p *OpenAICompatibleProvider) BuildChatRequest(messages []types.ChatMessage, options map[string]any) (openai.ChatCompletionRequest, error) {
+	if !p.initialized {
+		return openai.ChatCompletionRequest{}, fmt.Errorf("provider not initialized")
+	}
+
+	return p.chatRequest(messages, options)
+}
+
+// chatRequest builds a chat completion request from messages and options, including any tools
+func (p *OpenAICompatibleProvider) chatRequest(messages []types.ChatMessage, options map[string]any) (openai.ChatCompletionRequest, error) {
+	// Convert messages
+	openaiMessages := convertMessages(messages)
 
 	// Get model from options or use default
 	model := p.config.Model
@@ -354,6 +492,10 @@ func (p *OpenAICompatibleProvider) ChatCompletion(ctx context.Context, messages
 		model = modelOpt
 	}
 
+	if err := p.checkModel(model, options); err != nil {
+		return openai.ChatCompletionRequest{}, err
+	}
+
 	// For Yandex, prepend the folder_id to the model name
 	if p.name == "yandex" {
 		if folderID, ok := p.config.Options["folder_id"].(string); ok && folderID != "" {
@@ -366,7 +508,16 @@ func (p *OpenAICompatibleProvider) ChatCompletion(ctx context.Context, messages
 		Messages: openaiMessages,
 	}
 
+	if err := applyToolOptions(&req, options); err != nil {
+		return req, err
+	}
+
 	// Apply options
+	if isReasoningModel(model) {
+		applyReasoningOptions(&req, options)
+		return req, nil
+	}
+
 	if temp, ok := options["temperature"].(float64); ok {
 		req.Temperature = float32(temp)
 	}
@@ -377,16 +528,7 @@ func (p *OpenAICompatibleProvider) ChatCompletion(ctx context.Context, messages
 		req.TopP = float32(topP)
 	}
 
-	resp, err := p.client.CreateChatCompletion(ctx, req)
-	if err != nil {
-		return "", fmt.Errorf("chat completion failed: %w", err)
-	}
-
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no response from model")
-	}
-
-	return resp.Choices[0].Message.Content, nil
+	return req, nil
 }
 
 // StreamChatCompletion implements ChatService interface
@@ -402,45 +544,24 @@ func (p *OpenAICompatibleProvider) StreamChatCompletion(ctx context.Context, mes
 			errChan <- fmt.Errorf("provider not initialized")
 			return
 		}
-
-		// Convert messages
-		openaiMessages := make([]openai.ChatCompletionMessage, len(messages))
-		for i, msg := range messages {
-			openaiMessages[i] = openai.ChatCompletionMessage{
-				Role:    msg.Role,
-				Content: msg.Content,
-			}
-		}
-
-		// Get model from options or use default
-		model := p.config.Model
-		if modelOpt, ok := options["model"].(string); ok && modelOpt != "" {
-			model = modelOpt
-		}
-
-		// For Yandex, prepend the folder_id to the model name
-		if p.name == "yandex" {
-			if folderID, ok := p.config.Options["folder_id"].(string); ok && folderID != "" {
-				model = fmt.Sprintf("gpt://%s/%s", folderID, model)
-			}
+		if !p.DescribeCapabilities().SupportsStreaming(models.CapabilityChat) {
+			errChan <- fmt.Errorf("provider %s does not support streaming chat", p.name)
+			return
 		}
 
-		req := openai.ChatCompletionRequest{
-			Model:    model,
-			Messages: openaiMessages,
-			Stream:   true,
-		}
+		ctx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilityChat)
+		defer cancel()
 
-		// Apply options
-		if temp, ok := options["temperature"].(float64); ok {
-			req.Temperature = float32(temp)
-		}
-		if maxTokens, ok := options["max_tokens"].(int); ok {
-			req.MaxTokens = maxTokens
+		req, err := p.chatRequest(messages, options)
+		if err != nil {
+			errChan <- err
+			return
 		}
-		if topP, ok := options["top_p"].(float64); ok {
-			req.TopP = float32(topP)
+		req.Stream = true
+		if p.streamUsage() {
+			req.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
 		}
+		model := req.Model
 
 		stream, err := p.client.CreateChatCompletionStream(ctx, req)
 		if err != nil {
@@ -449,18 +570,42 @@ func (p *OpenAICompatibleProvider) StreamChatCompletion(ctx context.Context, mes
 		}
 		defer stream.Close()
 
+		models.RecordResponseMetadata(ctx, p.name, model)
+
+		// A delta may end mid-rune; the remainder is held back until the next delta
+		var runes runeBuffer
+
+		// Tool-call arguments arrive in fragments across chunks
+		var toolCalls toolCallAccumulator
+
+		// Usage arrives in a trailing chunk without choices
+		var usage *models.TokenUsage
+
 		for {
 			response, err := stream.Recv()
 			if err != nil {
 				if err.Error() == "EOF" {
+					models.RecordToolCalls(ctx, toolCalls.complete())
+					models.RecordUsage(ctx, usage)
+					if rest := runes.flush(); rest != "" {
+						contentChan <- rest
+					}
 					return
 				}
 				errChan <- fmt.Errorf("stream error: %w", err)
 				return
 			}
 
+			if response.Model != "" {
+				models.RecordResponseMetadata(ctx, p.name, response.Model)
+			}
+
+			if response.Usage != nil {
+				usage = convertUsage(*response.Usage)
+			}
 			if len(response.Choices) > 0 {
-				content := response.Choices[0].Delta.Content
+				toolCalls.add(response.Choices[0].Delta.ToolCalls)
+				content := runes.push(response.Choices[0].Delta.Content)
 				if content != "" {
 					contentChan <- content
 				}
@@ -489,7 +634,190 @@ func (p *OpenAICompatibleProvider) GenerateEmbedding(ctx context.Context, text s
 	return embeddingService.GenerateEmbedding(ctx, text)
 }
 
-// GetDimensions implements EmbeddingService interface
+// GenerateEmbeddings implements EmbeddingService interface
+func (p *OpenAICompatibleProvider) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddingService := NewEmbeddingService(p)
+	return embeddingService.GenerateEmbeddings(ctx, texts)
+}
+
+// GetDimensions implements EmbeddingService interface. A "dimensions" option the embedding model
+// accepts wins, then the model's metadata; a rejected option is not reported.
 func (p *OpenAICompatibleProvider) GetDimensions() int {
-	return 1536 // Default OpenAI embedding dimensions
+	if dims, err := p.resolveEmbeddingDimensions(p.embeddingModel()); err == nil && dims > 0 {
+		return dims
+	}
+	return p.GetDimensionsForModel(p.embeddingModel())
+}
+
+// GetDimensionsForModel returns the embedding dimensions recorded in a model's metadata,
+// or the OpenAI default when the model is unknown
+func (p *OpenAICompatibleProvider) GetDimensionsForModel(modelID string) int {
+	if dims := p.nativeDimensions(modelID); dims > 0 {
+		return dims
+	}
+	return defaultEmbeddingDimensions
+}
+
+// nativeDimensions returns the embedding dimensions recorded in a model's metadata, or 0 when unknown
+func (p *OpenAICompatibleProvider) nativeDimensions(modelID string) int {
+	for _, model := range p.modelInfo {
+		if model.ID != modelID {
+			continue
+		}
+		switch dims := model.Metadata["dimensions"].(type) {
+		case int:
+			return dims
+		case float64:
+			return int(dims)
+		}
+	}
+	return 0
+}
+
+// resolveEmbeddingDimensions validates the "dimensions" option against the embedding model and
+// returns the size to request, or 0 for the model's full size. Models without "supports_dimensions"
+// in their metadata are rejected rather than silently returning full-length vectors; models missing
+// from the configuration are passed through for the API to judge.
+func (p *OpenAICompatibleProvider) resolveEmbeddingDimensions(modelID string) (int, error) {
+	dims := requestedDimensions(p.config.Options)
+	if dims == 0 {
+		return 0, nil
+	}
+	if dims < 0 {
+		return 0, fmt.Errorf("invalid embedding dimensions %d", dims)
+	}
+	if p.name == "yandex" {
+		return 0, fmt.Errorf("%s embeddings do not support the dimensions option", p.name)
+	}
+
+	for _, model := range p.modelInfo {
+		if model.ID != modelID {
+			continue
+		}
+		if supported, _ := model.Metadata["supports_dimensions"].(bool); !supported {
+			return 0, fmt.Errorf("embedding model %s does not support the dimensions option", modelID)
+		}
+		if native := p.nativeDimensions(modelID); native > 0 && dims > native {
+			return 0, fmt.Errorf("embedding dimensions %d exceed the %d produced by model %s", dims, native, modelID)
+		}
+	}
+
+	return dims, nil
+}
+
+// embeddingModel returns the configured embedding model, defaulting to the query model for Yandex
+func (p *OpenAICompatibleProvider) embeddingModel() string {
+	if p.config.Model == "" && p.name == "yandex" {
+		return defaultYandexEmbeddingModel
+	}
+	return p.config.Model
+}
+
+// embeddingBatchSize returns the most texts sent in one embeddings request. Yandex embeds a single
+// text per request; the "embedding_batch_size" option overrides the default.
+func (p *OpenAICompatibleProvider) embeddingBatchSize() int {
+	switch v := p.config.Options["embedding_batch_size"].(type) {
+	case int:
+		if v > 0 {
+			return v
+		}
+	case float64:
+		if v >= 1 {
+			return int(v)
+		}
+	}
+	if p.name == "yandex" {
+		return 1
+	}
+	return defaultEmbeddingBatchSize
+}
+
+// checkModel returns a ModelNotFoundError when model is neither configured nor listed by the API.
+// It only applies once the API has listed its models, since the configured models are a sample,
+// and is skipped for dynamic catalogs and when "allow_unknown_models" is set in the provider or
+// call options.
+func (p *OpenAICompatibleProvider) checkModel(model string, options map[string]any) error {
+	if model == "" || p.dynamicModels || len(p.catalog) == 0 {
+		return nil
+	}
+	if allow, _ := p.config.Options["allow_unknown_models"].(bool); allow {
+		return nil
+	}
+	if allow, _ := options["allow_unknown_models"].(bool); allow {
+		return nil
+	}
+
+	known := make([]string, 0, len(p.modelInfo)+len(p.catalog))
+	for _, info := range p.modelInfo {
+		known = append(known, info.ID)
+	}
+	known = append(known, p.catalog...)
+
+	if slices.Contains(known, model) {
+		return nil
+	}
+	return models.NewModelNotFoundError(p.name, model, known)
+}
+
+// requestedDimensions returns the "dimensions" option, or 0 when unset. Both providers treat an
+// explicit 0 as unset.
+func requestedDimensions(options map[string]any) int {
+	switch v := options["dimensions"].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// DescribeCapabilities reports the provider's chat and embedding capabilities.
+// Streaming can be turned off with the "streaming" option for backends that only answer in full.
+func (p *OpenAICompatibleProvider) DescribeCapabilities() *models.ProviderCapabilities {
+	streaming := true
+	if enabled, ok := p.config.Options["streaming"].(bool); ok {
+		streaming = enabled
+	}
+
+	chat := &models.ChatCapability{Streaming: streaming, FunctionCalling: true}
+	embedding := &models.EmbeddingCapability{Dimensions: p.GetDimensions()}
+	for _, model := range p.modelInfo {
+		switch model.Capability {
+		case models.CapabilityChat:
+			chat.SupportedModels = append(chat.SupportedModels, model.ID)
+			chat.MaxContextTokens = max(chat.MaxContextTokens, model.ContextSize)
+		case models.CapabilityEmbedding:
+			embedding.SupportedModels = append(embedding.SupportedModels, model.ID)
+		}
+	}
+
+	return &models.ProviderCapabilities{Chat: chat, Embedding: embedding}
+}
+
+// chatOptions describes the chat completion options shared by the LLM providers
+var chatOptions = []models.OptionDescriptor{
+	{Name: "model", Type: models.OptionTypeString, Scope: models.OptionScopeChat, Description: "model used instead of the provider default"},
+	{Name: "temperature", Type: models.OptionTypeNumber, Scope: models.OptionScopeChat, Description: "sampling temperature"},
+	{Name: "top_p", Type: models.OptionTypeNumber, Scope: models.OptionScopeChat, Description: "nucleus sampling probability mass"},
+	{Name: "max_tokens", Type: models.OptionTypeInt, Scope: models.OptionScopeChat, Description: "completion token limit"},
+}
+
+// SupportedOptions describes the Options keys the provider honors
+func (p *OpenAICompatibleProvider) SupportedOptions() []models.OptionDescriptor {
+	return append([]models.OptionDescriptor{
+		{Name: "folder_id", Type: models.OptionTypeString, Scope: models.OptionScopeProvider, Description: "Yandex Cloud folder used to build model URIs"},
+		{Name: "streaming", Type: models.OptionTypeBool, Scope: models.OptionScopeProvider, Description: "false when the endpoint cannot stream chat completions"},
+		{Name: "stream_usage", Type: models.OptionTypeBool, Scope: models.OptionScopeProvider, Description: "request token usage on streamed completions; on by default for OpenAI only"},
+		{Name: "dimensions", Type: models.OptionTypeInt, Scope: models.OptionScopeProvider, Description: "reduced embedding dimensions to request from models that support it"},
+		{Name: "encoding_format", Type: models.OptionTypeString, Scope: models.OptionScopeProvider, Description: "embedding encoding format"},
+		{Name: "allow_unknown_models", Type: models.OptionTypeBool, Scope: models.OptionScopeProvider, Description: "send model IDs missing from the known model list instead of failing with ErrModelNotFound"},
+		{Name: "allow_unknown_models", Type: models.OptionTypeBool, Scope: models.OptionScopeChat, Description: "send this call's model even when it is missing from the known model list"},
+		{Name: "embedding_batch_size", Type: models.OptionTypeInt, Scope: models.OptionScopeProvider, Description: "most texts sent in one embeddings request"},
+		models.CapabilityTimeoutOption(models.CapabilityChat),
+		models.CapabilityTimeoutOption(models.CapabilityEmbedding),
+		{Name: "max_completion_tokens", Type: models.OptionTypeInt, Scope: models.OptionScopeChat, Description: "completion token limit for reasoning models"},
+		{Name: "reasoning_effort", Type: models.OptionTypeString, Scope: models.OptionScopeChat, Description: "reasoning effort for reasoning models: low, medium or high"},
+		{Name: "tools", Type: models.OptionTypeAny, Scope: models.OptionScopeChat, Description: "tools the model may call, as []openai.Tool or the OpenAI JSON schema"},
+		{Name: "tool_choice", Type: models.OptionTypeAny, Scope: models.OptionScopeChat, Description: "auto, none, required, a function name or a ToolChoice"},
+	}, chatOptions...)
 }
@@ -0,0 +1,593 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// newTestProvider starts a stub OpenAI-compatible API that lists catalog from /models and serves
+// every other path with handler, and returns a provider for config initialized against it
+func newTestProvider(t *testing.T, config ProviderConfig, catalog []string, options map[string]any, handler http.HandlerFunc) *OpenAICompatibleProvider {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models" {
+			handler(w, r)
+			return
+		}
+
+		data := make([]map[string]any, len(catalog))
+		for i, id := range catalog {
+			data[i] = map[string]any{"id": id, "object": "model"}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data})
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewOpenAICompatibleProvider(config)
+	err := provider.Initialize(context.Background(), models.ProviderConfig{
+		APIKey:  "test-key",
+		BaseURL: server.URL,
+		Options: options,
+	})
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	return provider
+}
+
+// recordChatRequests serves chat completions with reply and stores each decoded request body in bodies
+func recordChatRequests(bodies *[]map[string]any, reply string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		*bodies = append(*bodies, body)
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":      "chatcmpl-test",
+			"object":  "chat.completion",
+			"model":   body["model"],
+			"choices": []map[string]any{{"index": 0, "message": map[string]any{"role": "assistant", "content": reply}, "finish_reason": "stop"}},
+		})
+	}
+}
+
+func TestChatCompletionShapesReasoningRequests(t *testing.T) {
+	var bodies []map[string]any
+	provider := newTestProvider(t, OpenAIConfig, []string{"o3-mini", "gpt-4o-mini"}, nil, recordChatRequests(&bodies, "ok"))
+
+	messages := []types.ChatMessage{{Role: "user", Content: "hi"}}
+	options := map[string]any{"temperature": 0.5, "top_p": 0.9, "max_tokens": 100, "reasoning_effort": "low"}
+
+	for _, model := range []string{"o3-mini", "gpt-4o-mini"} {
+		options["model"] = model
+		if _, err := provider.ChatCompletion(context.Background(), messages, options); err != nil {
+			t.Fatalf("ChatCompletion(%s): %v", model, err)
+		}
+	}
+
+	reasoning, standard := bodies[0], bodies[1]
+	for _, field := range []string{"temperature", "top_p", "max_tokens"} {
+		if _, ok := reasoning[field]; ok {
+			t.Errorf("reasoning request should not send %s: %v", field, reasoning)
+		}
+	}
+	if reasoning["max_completion_tokens"] != float64(100) || reasoning["reasoning_effort"] != "low" {
+		t.Errorf("reasoning request is missing its reasoning params: %v", reasoning)
+	}
+
+	if standard["max_tokens"] != float64(100) || standard["temperature"] != 0.5 {
+		t.Errorf("standard request lost its sampling params: %v", standard)
+	}
+	if _, ok := standard["max_completion_tokens"]; ok {
+		t.Errorf("standard request should not send max_completion_tokens: %v", standard)
+	}
+
+	// Streaming requests are shaped the same way
+	var streamed []map[string]any
+	streaming := newTestProvider(t, OpenAIConfig, []string{"o3-mini", "gpt-4o-mini"}, nil, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		streamed = append(streamed, body)
+		streamChunks()(w, r)
+	})
+	temperature, topP, maxTokens := 0.5, 0.9, 100
+	for _, model := range []string{"o3-mini", "gpt-4o-mini"} {
+		req := interfaces.ChatRequest{
+			Model:       model,
+			Messages:    messages,
+			Temperature: &temperature,
+			TopP:        &topP,
+			MaxTokens:   &maxTokens,
+			Options:     map[string]any{"reasoning_effort": "low"},
+		}
+		if err := streaming.StreamCompletion(context.Background(), req, &chunkRecorder{}); err != nil {
+			t.Fatalf("StreamCompletion(%s): %v", model, err)
+		}
+	}
+
+	reasoning, standard = streamed[0], streamed[1]
+	for _, field := range []string{"temperature", "top_p", "max_tokens"} {
+		if _, ok := reasoning[field]; ok {
+			t.Errorf("streaming reasoning request should not send %s: %v", field, reasoning)
+		}
+	}
+	if reasoning["max_completion_tokens"] != float64(100) || reasoning["reasoning_effort"] != "low" {
+		t.Errorf("streaming reasoning request is missing its reasoning params: %v", reasoning)
+	}
+	if standard["max_tokens"] != float64(100) || standard["temperature"] != 0.5 {
+		t.Errorf("streaming standard request lost its sampling params: %v", standard)
+	}
+	if _, ok := standard["reasoning_effort"]; ok {
+		t.Errorf("streaming standard request should not send reasoning_effort: %v", standard)
+	}
+}
+
+// chunkRecorder is a ChatStream that keeps every chunk it is sent
+type chunkRecorder struct {
+	chunks []interfaces.ChatChunk
+}
+
+func (r *chunkRecorder) Send(chunk interfaces.ChatChunk) error {
+	r.chunks = append(r.chunks, chunk)
+	return nil
+}
+
+func (r *chunkRecorder) Close() error { return nil }
+
+// blockUntil holds each request open until the client gives up on it or release is closed
+func blockUntil(release <-chan struct{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}
+}
+
+func TestCallsUseCapabilityTimeout(t *testing.T) {
+	options := map[string]any{"chat_timeout": "50ms", "embedding_timeout": "50ms", "stt_timeout": "1h", "tts_timeout": "1h"}
+	release := make(chan struct{})
+	provider := newTestProvider(t, OpenAIConfig, []string{"gpt-4o-mini", "text-embedding-3-small"}, options, blockUntil(release))
+	t.Cleanup(func() { close(release) })
+	provider.config.Model = "text-embedding-3-small"
+
+	calls := map[string]func() error{
+		"chat": func() error {
+			_, err := provider.ChatCompletion(context.Background(), []types.ChatMessage{{Role: "user", Content: "hi"}}, map[string]any{"model": "gpt-4o-mini"})
+			return err
+		},
+		"embedding": func() error {
+			_, err := NewEmbeddingService(provider).GenerateEmbeddings(context.Background(), []string{"hi"})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		errCh := make(chan error, 1)
+		go func() { errCh <- call() }()
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("%s: expected the configured timeout to expire, got %v", name, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: call ignored its configured timeout", name)
+		}
+	}
+}
+
+func TestBuildChatRequest(t *testing.T) {
+	unused := func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("BuildChatRequest must not call the API, got %s %s", r.Method, r.URL.Path)
+	}
+	openaiProvider := newTestProvider(t, OpenAIConfig, []string{"gpt-4o-mini", "o3-mini"}, nil, unused)
+	yandexProvider := newTestProvider(t, YandexConfig, nil, map[string]any{"folder_id": "b1g"}, unused)
+
+	messages := []types.ChatMessage{{Role: "system", Content: "be brief"}, {Role: "user", Content: "hi"}}
+
+	tests := []struct {
+		name     string
+		provider *OpenAICompatibleProvider
+		options  map[string]any
+		check    func(t *testing.T, req openai.ChatCompletionRequest)
+		wantErr  bool
+	}{
+		{
+			name:     "sampling options",
+			provider: openaiProvider,
+			options:  map[string]any{"model": "gpt-4o-mini", "temperature": 0.2, "top_p": 0.8, "max_tokens": 64},
+			check: func(t *testing.T, req openai.ChatCompletionRequest) {
+				if req.Model != "gpt-4o-mini" || req.Temperature != 0.2 || req.TopP != 0.8 || req.MaxTokens != 64 {
+					t.Errorf("sampling options not mapped: %+v", req)
+				}
+				if len(req.Messages) != 2 || req.Messages[0].Role != "system" || req.Messages[1].Content != "hi" {
+					t.Errorf("messages not mapped: %+v", req.Messages)
+				}
+			},
+		},
+		{
+			name:     "reasoning model",
+			provider: openaiProvider,
+			options:  map[string]any{"model": "o3-mini", "temperature": 0.2, "max_tokens": 64, "reasoning_effort": "high"},
+			check: func(t *testing.T, req openai.ChatCompletionRequest) {
+				if req.Temperature != 0 || req.MaxTokens != 0 {
+					t.Errorf("reasoning request kept sampling options: %+v", req)
+				}
+				if req.MaxCompletionTokens != 64 || req.ReasoningEffort != "high" {
+					t.Errorf("reasoning fields not set: %+v", req)
+				}
+			},
+		},
+		{
+			name:     "max_completion_tokens wins over max_tokens",
+			provider: openaiProvider,
+			options:  map[string]any{"model": "o3-mini", "max_tokens": 64, "max_completion_tokens": 256},
+			check: func(t *testing.T, req openai.ChatCompletionRequest) {
+				if req.MaxCompletionTokens != 256 {
+					t.Errorf("expected max_completion_tokens 256, got %d", req.MaxCompletionTokens)
+				}
+			},
+		},
+		{
+			name:     "tools",
+			provider: openaiProvider,
+			options: map[string]any{
+				"model":       "gpt-4o-mini",
+				"tools":       []map[string]any{{"function": map[string]any{"name": "lookup"}}},
+				"tool_choice": "lookup",
+			},
+			check: func(t *testing.T, req openai.ChatCompletionRequest) {
+				if len(req.Tools) != 1 || req.Tools[0].Type != openai.ToolTypeFunction || req.Tools[0].Function.Name != "lookup" {
+					t.Errorf("tools not mapped: %+v", req.Tools)
+				}
+				if choice, ok := req.ToolChoice.(openai.ToolChoice); !ok || choice.Function.Name != "lookup" {
+					t.Errorf("tool choice not mapped: %+v", req.ToolChoice)
+				}
+			},
+		},
+		{
+			name:     "Yandex model URI",
+			provider: yandexProvider,
+			options:  map[string]any{"model": "yandexgpt-lite/latest"},
+			check: func(t *testing.T, req openai.ChatCompletionRequest) {
+				if req.Model != "gpt://b1g/yandexgpt-lite/latest" {
+					t.Errorf("expected the folder-qualified model URI, got %q", req.Model)
+				}
+			},
+		},
+		{
+			name:     "unknown model",
+			provider: openaiProvider,
+			options:  map[string]any{"model": "gpt-unknown"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid tools",
+			provider: openaiProvider,
+			options:  map[string]any{"model": "gpt-4o-mini", "tools": []map[string]any{{"type": "function"}}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.provider.BuildChatRequest(messages, tt.options)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", req)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildChatRequest: %v", err)
+			}
+			tt.check(t, req)
+		})
+	}
+
+	if _, err := NewOpenAICompatibleProvider(OpenAIConfig).BuildChatRequest(messages, nil); err == nil {
+		t.Error("expected an error from an uninitialized provider")
+	}
+}
+
+// streamChunks serves a chat completion stream sending each chunk as a server-sent event
+func streamChunks(chunks ...map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range chunks {
+			data, _ := json.Marshal(chunk)
+			fmt.Fprintf(w, "data: %s\n\n", data)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}
+}
+
+// deltaChunk is a stream chunk carrying delta as its only choice
+func deltaChunk(delta map[string]any) map[string]any {
+	return map[string]any{
+		"id":      "chatcmpl-test",
+		"object":  "chat.completion.chunk",
+		"model":   "gpt-4o-mini",
+		"choices": []map[string]any{{"index": 0, "delta": delta}},
+	}
+}
+
+func TestChatCompletionMapsToolCalls(t *testing.T) {
+	var body map[string]any
+	provider := newTestProvider(t, OpenAIConfig, []string{"gpt-4o-mini"}, nil, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":    "chatcmpl-test",
+			"model": "gpt-4o-mini",
+			"choices": []map[string]any{{
+				"index": 0,
+				"message": map[string]any{
+					"role": "assistant",
+					"tool_calls": []map[string]any{{
+						"id":       "call_2",
+						"type":     "function",
+						"function": map[string]any{"name": "lookup", "arguments": `{"city":"Oslo"}`},
+					}},
+				},
+				"finish_reason": "tool_calls",
+			}},
+		})
+	})
+
+	messages := []types.ChatMessage{
+		{Role: "user", Content: "weather in Paris and Oslo?"},
+		{Role: "assistant", ToolCalls: []types.ToolCall{{ID: "call_1", Name: "lookup", Arguments: `{"city":"Paris"}`}}},
+		{Role: "tool", Content: "sunny", ToolCallID: "call_1"},
+	}
+
+	ctx, metadata := models.WithResponseMetadata(context.Background())
+	if _, err := provider.ChatCompletion(ctx, messages, map[string]any{"model": "gpt-4o-mini"}); err != nil {
+		t.Fatalf("ChatCompletion: %v", err)
+	}
+
+	sent, _ := body["messages"].([]any)
+	if len(sent) != 3 {
+		t.Fatalf("expected 3 messages, got %v", body["messages"])
+	}
+	assistant, _ := sent[1].(map[string]any)
+	calls, _ := assistant["tool_calls"].([]any)
+	if len(calls) != 1 {
+		t.Fatalf("assistant tool calls not sent: %v", assistant)
+	}
+	call, _ := calls[0].(map[string]any)
+	function, _ := call["function"].(map[string]any)
+	if call["id"] != "call_1" || call["type"] != "function" || function["name"] != "lookup" || function["arguments"] != `{"city":"Paris"}` {
+		t.Errorf("unexpected tool call sent: %v", call)
+	}
+	if tool, _ := sent[2].(map[string]any); tool["tool_call_id"] != "call_1" {
+		t.Errorf("tool result not linked to its call: %v", tool)
+	}
+
+	want := []types.ToolCall{{Index: 0, ID: "call_2", Type: "function", Name: "lookup", Arguments: `{"city":"Oslo"}`}}
+	if got := metadata.ToolCalls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got tool calls %+v, want %+v", got, want)
+	}
+}
+
+func TestStreamChatCompletionAssemblesToolCalls(t *testing.T) {
+	provider := newTestProvider(t, OpenAIConfig, []string{"gpt-4o-mini"}, nil, streamChunks(
+		deltaChunk(map[string]any{"role": "assistant", "content": "Checking"}),
+		deltaChunk(map[string]any{"tool_calls": []map[string]any{{"index": 0, "id": "call_1", "type": "function", "function": map[string]any{"name": "lookup", "arguments": `{"ci`}}}}),
+		deltaChunk(map[string]any{"tool_calls": []map[string]any{{"index": 0, "function": map[string]any{"arguments": `ty":"Oslo"}`}}}}),
+		deltaChunk(map[string]any{"tool_calls": []map[string]any{{"index": 1, "id": "call_2", "type": "function", "function": map[string]any{"name": "time", "arguments": `{}`}}}}),
+	))
+
+	ctx, metadata := models.WithResponseMetadata(context.Background())
+	contentCh, errCh := provider.StreamChatCompletion(ctx, []types.ChatMessage{{Role: "user", Content: "hi"}}, map[string]any{"model": "gpt-4o-mini"})
+
+	var content string
+	for chunk := range contentCh {
+		content += chunk
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("StreamChatCompletion: %v", err)
+	}
+
+	if content != "Checking" {
+		t.Errorf("got content %q", content)
+	}
+	want := []types.ToolCall{
+		{Index: 0, ID: "call_1", Type: "function", Name: "lookup", Arguments: `{"city":"Oslo"}`},
+		{Index: 1, ID: "call_2", Type: "function", Name: "time", Arguments: `{}`},
+	}
+	if got := metadata.ToolCalls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got tool calls %+v, want %+v", got, want)
+	}
+}
+
+func TestStreamChatCompletionRequiresStreaming(t *testing.T) {
+	messages := []types.ChatMessage{{Role: "user", Content: "hi"}}
+	options := map[string]any{"model": "gpt-4o-mini"}
+
+	tests := []struct {
+		name      string
+		options   map[string]any
+		streaming bool
+	}{
+		{name: "streaming by default", streaming: true},
+		{name: "streaming turned off", options: map[string]any{"streaming": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider(t, OpenAIConfig, []string{"gpt-4o-mini"}, tt.options, streamChunks(deltaChunk(map[string]any{"content": "hello"})))
+
+			if got := provider.DescribeCapabilities().SupportsStreaming(models.CapabilityChat); got != tt.streaming {
+				t.Errorf("SupportsStreaming: got %v, want %v", got, tt.streaming)
+			}
+
+			contentCh, errCh := provider.StreamChatCompletion(context.Background(), messages, options)
+			var content string
+			for chunk := range contentCh {
+				content += chunk
+			}
+			err := <-errCh
+
+			if tt.streaming {
+				if err != nil || content != "hello" {
+					t.Errorf("expected the streamed reply, got %q and %v", content, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "does not support streaming chat") {
+				t.Errorf("expected a clear streaming error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTypeMatchesPredefinedConfig(t *testing.T) {
+	tests := []struct {
+		config ProviderConfig
+		want   models.ProviderType
+	}{
+		{config: OpenAIConfig, want: models.ProviderTypeOpenAI},
+		{config: OpenRouterConfig, want: models.ProviderTypeOpenRouter},
+		{config: YandexConfig, want: models.ProviderTypeYandex},
+		{config: MinimaxLLMConfig, want: models.ProviderTypeMinimax},
+		{config: ProviderConfig{Name: "custom"}, want: models.ProviderTypeOpenAI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.config.Name, func(t *testing.T) {
+			if got := NewOpenAICompatibleProvider(tt.config).Type(); got != tt.want {
+				t.Errorf("Type: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatCompletionChecksModel(t *testing.T) {
+	messages := []types.ChatMessage{{Role: "user", Content: "hi"}}
+
+	tests := []struct {
+		name     string
+		config   ProviderConfig
+		options  map[string]any
+		call     map[string]any
+		notFound bool
+	}{
+		{name: "listed model", config: OpenAIConfig, call: map[string]any{"model": "gpt-4o-mini"}},
+		{name: "bad model ID", config: OpenAIConfig, call: map[string]any{"model": "gpt-4o-mnii"}, notFound: true},
+		{name: "provider opt-out", config: OpenAIConfig, options: map[string]any{"allow_unknown_models": true}, call: map[string]any{"model": "gpt-4o-mnii"}},
+		{name: "call opt-out", config: OpenAIConfig, call: map[string]any{"model": "gpt-4o-mnii", "allow_unknown_models": true}},
+		// Yandex lists no models, so its configured models are only a sample
+		{name: "unlisted catalog", config: YandexConfig, options: map[string]any{"folder_id": "folder"}, call: map[string]any{"model": "yandexgpt-5-lite/latest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []map[string]any
+			provider := newTestProvider(t, tt.config, []string{"gpt-4o-mini"}, tt.options, recordChatRequests(&bodies, "hello"))
+
+			_, err := provider.ChatCompletion(context.Background(), messages, tt.call)
+			if !tt.notFound {
+				if err != nil {
+					t.Fatalf("ChatCompletion: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, models.ErrModelNotFound) {
+				t.Fatalf("expected ErrModelNotFound, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "did you mean gpt-4o-mini") {
+				t.Errorf("expected the listed model to be suggested, got %v", err)
+			}
+			if len(bodies) != 0 {
+				t.Error("the request was sent despite the unknown model")
+			}
+		})
+	}
+}
+
+func TestStreamingRequestsUsage(t *testing.T) {
+	messages := []types.ChatMessage{{Role: "user", Content: "hi"}}
+	usageChunk := map[string]any{
+		"id":      "chatcmpl-test",
+		"object":  "chat.completion.chunk",
+		"choices": []map[string]any{},
+		"usage":   map[string]any{"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15},
+	}
+	wantUsage := &models.TokenUsage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}
+
+	tests := []struct {
+		name    string
+		config  ProviderConfig
+		model   string
+		options map[string]any
+		usage   bool
+	}{
+		{name: "openai by default", config: OpenAIConfig, model: "gpt-4o-mini", usage: true},
+		{name: "openai turned off", config: OpenAIConfig, model: "gpt-4o-mini", options: map[string]any{"stream_usage": false}},
+		{name: "minimax by default", config: MinimaxLLMConfig, model: "abab6.5s-chat"},
+		{name: "openrouter by default", config: OpenRouterConfig, model: "openai/gpt-4o-mini"},
+		{name: "openrouter turned on", config: OpenRouterConfig, model: "openai/gpt-4o-mini", options: map[string]any{"stream_usage": true}, usage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []map[string]any
+			serve := streamChunks(deltaChunk(map[string]any{"content": "hello"}), usageChunk)
+			provider := newTestProvider(t, tt.config, []string{tt.model}, tt.options, func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				json.NewDecoder(r.Body).Decode(&body)
+				bodies = append(bodies, body)
+				serve(w, r)
+			})
+
+			ctx, metadata := models.WithResponseMetadata(context.Background())
+			contentCh, errCh := provider.StreamChatCompletion(ctx, messages, map[string]any{"model": tt.model})
+			for range contentCh {
+			}
+			if err := <-errCh; err != nil {
+				t.Fatalf("StreamChatCompletion: %v", err)
+			}
+
+			recorder := &chunkRecorder{}
+			if err := provider.StreamCompletion(context.Background(), interfaces.ChatRequest{Model: tt.model, Messages: messages}, recorder); err != nil {
+				t.Fatalf("StreamCompletion: %v", err)
+			}
+
+			if len(bodies) != 2 {
+				t.Fatalf("expected two streamed requests, got %d", len(bodies))
+			}
+			for _, body := range bodies {
+				streamOptions, ok := body["stream_options"].(map[string]any)
+				if ok != tt.usage || (ok && streamOptions["include_usage"] != true) {
+					t.Errorf("expected include_usage %v, got stream_options %v", tt.usage, body["stream_options"])
+				}
+			}
+
+			// The test server reports usage either way; it is surfaced whenever the API sends it
+			if got := metadata.Usage(); !reflect.DeepEqual(got, wantUsage) {
+				t.Errorf("StreamChatCompletion usage: got %+v, want %+v", got, wantUsage)
+			}
+			final := recorder.chunks[len(recorder.chunks)-1]
+			if !final.Done || !reflect.DeepEqual(final.Usage, wantUsage) {
+				t.Errorf("StreamCompletion final chunk: got %+v, want Done with usage %+v", final, wantUsage)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package llm
+
+import (
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// reasoningModelPrefixes lists the model families that take reasoning parameters
+var reasoningModelPrefixes = []string{"o1", "o3", "o4"}
+
+// isReasoningModel reports whether a model is an o-series reasoning model.
+// Vendor prefixes such as "openai/" (OpenRouter) are ignored.
+func isReasoningModel(model string) bool {
+	if idx := strings.LastIndex(model, "/"); idx >= 0 {
+		model = model[idx+1:]
+	}
+
+	for _, prefix := range reasoningModelPrefixes {
+		if model == prefix || strings.HasPrefix(model, prefix+"-") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyReasoningOptions sets max_completion_tokens and reasoning_effort from chat options.
+// Reasoning models reject sampling params and max_tokens, so those are never set for them.
+func applyReasoningOptions(req *openai.ChatCompletionRequest, options map[string]any) {
+	if maxTokens, ok := options["max_completion_tokens"].(int); ok {
+		req.MaxCompletionTokens = maxTokens
+	} else if maxTokens, ok := options["max_tokens"].(int); ok {
+		req.MaxCompletionTokens = maxTokens
+	}
+	if effort, ok := options["reasoning_effort"].(string); ok {
+		req.ReasoningEffort = effort
+	}
+}
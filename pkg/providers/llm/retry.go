@@ -0,0 +1,54 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// withRetry calls fn until it succeeds, returns a non-retryable error, or the policy's
+// attempts are exhausted. A nil policy or MaxAttempts <= 1 calls fn once.
+func withRetry(ctx context.Context, policy *models.RetryPolicy, fn func(ctx context.Context) error) error {
+	return providers.Retry(ctx, policy, func(err error) bool {
+		return isRetryableError(err, policy)
+	}, fn)
+}
+
+// isRetryableError reports whether err is a transient failure worth retrying
+func isRetryableError(err error, policy *models.RetryPolicy) bool {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) && isRetryableStatus(apiErr.HTTPStatusCode) {
+		return true
+	}
+
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) && isRetryableStatus(reqErr.HTTPStatusCode) {
+		return true
+	}
+
+	// Transport timeouts surface as deadline exceeded on a per-attempt context
+	if models.ClassifyError(err).Retryable() {
+		return true
+	}
+
+	if policy != nil {
+		for _, pattern := range policy.RetryableErrors {
+			if pattern != "" && strings.Contains(err.Error(), pattern) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isRetryableStatus reports whether an HTTP status code indicates a transient failure
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
@@ -0,0 +1,204 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/sanitize"
+	"github.com/creastat/common-go/pkg/types"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// toolsFromOptions reads options["tools"], given either as []openai.Tool or in the
+// OpenAI JSON tool schema (e.g. []map[string]any decoded from configuration)
+func toolsFromOptions(options map[string]any) ([]openai.Tool, error) {
+	value, ok := options["tools"]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	if tools, ok := value.([]openai.Tool); ok {
+		return tools, nil
+	}
+
+	var tools []openai.Tool
+	if err := convertJSON(value, &tools); err != nil {
+		return nil, fmt.Errorf("invalid tools option: %w", err)
+	}
+
+	for i, tool := range tools {
+		if tool.Type == "" {
+			tools[i].Type = openai.ToolTypeFunction
+		}
+		if tool.Function == nil || tool.Function.Name == "" {
+			return nil, fmt.Errorf("invalid tools option: tool %d has no function name", i)
+		}
+	}
+
+	return tools, nil
+}
+
+// toolChoiceFromOptions reads options["tool_choice"]: "auto", "none", "required",
+// the name of a function to force, or a ToolChoice object
+func toolChoiceFromOptions(options map[string]any) (any, error) {
+	switch v := options["tool_choice"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		switch v {
+		case "":
+			return nil, nil
+		case "auto", "none", "required":
+			return v, nil
+		}
+		return openai.ToolChoice{
+			Type:     openai.ToolTypeFunction,
+			Function: openai.ToolFunction{Name: v},
+		}, nil
+	case openai.ToolChoice:
+		return v, nil
+	default:
+		var choice openai.ToolChoice
+		if err := convertJSON(v, &choice); err != nil {
+			return nil, fmt.Errorf("invalid tool_choice option: %w", err)
+		}
+		if choice.Type == "" {
+			choice.Type = openai.ToolTypeFunction
+		}
+		return choice, nil
+	}
+}
+
+// applyToolOptions sets the tools and tool choice on a chat request
+func applyToolOptions(req *openai.ChatCompletionRequest, options map[string]any) error {
+	tools, err := toolsFromOptions(options)
+	if err != nil {
+		return err
+	}
+	choice, err := toolChoiceFromOptions(options)
+	if err != nil {
+		return err
+	}
+
+	req.Tools = tools
+	req.ToolChoice = choice
+	return nil
+}
+
+// convertMessages converts chat messages to OpenAI messages, including the tool calls an
+// assistant message requested and the call a tool result answers
+func convertMessages(messages []types.ChatMessage) []openai.ChatCompletionMessage {
+	converted := make([]openai.ChatCompletionMessage, len(messages))
+	for i, msg := range messages {
+		converted[i] = openai.ChatCompletionMessage{
+			Role:       msg.Role,
+			Content:    sanitize.Text(msg.Content),
+			ToolCallID: msg.ToolCallID,
+		}
+		for _, call := range msg.ToolCalls {
+			callType := openai.ToolType(call.Type)
+			if callType == "" {
+				callType = openai.ToolTypeFunction
+			}
+			converted[i].ToolCalls = append(converted[i].ToolCalls, openai.ToolCall{
+				ID:   call.ID,
+				Type: callType,
+				Function: openai.FunctionCall{
+					Name:      call.Name,
+					Arguments: call.Arguments,
+				},
+			})
+		}
+	}
+	return converted
+}
+
+// convertToolCalls converts the complete tool calls of a chat response
+func convertToolCalls(calls []openai.ToolCall) []interfaces.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	converted := make([]interfaces.ToolCall, len(calls))
+	for i, call := range calls {
+		index := i
+		if call.Index != nil {
+			index = *call.Index
+		}
+		converted[i] = interfaces.ToolCall{
+			Index:     index,
+			ID:        call.ID,
+			Type:      string(call.Type),
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		}
+	}
+	return converted
+}
+
+// convertJSON converts a loosely typed value into target via its JSON encoding
+func convertJSON(value any, target any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// toolCallAccumulator assembles streamed tool-call fragments into complete calls.
+// The first fragment of a call carries its ID and name; later fragments only append arguments.
+type toolCallAccumulator struct {
+	calls []interfaces.ToolCall
+}
+
+// add records the fragments of a stream chunk and returns them converted
+func (a *toolCallAccumulator) add(deltas []openai.ToolCall) []interfaces.ToolCall {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	fragments := make([]interfaces.ToolCall, len(deltas))
+	for i, delta := range deltas {
+		index := i
+		if delta.Index != nil {
+			index = *delta.Index
+		}
+
+		fragment := interfaces.ToolCall{
+			Index:     index,
+			ID:        delta.ID,
+			Type:      string(delta.Type),
+			Name:      delta.Function.Name,
+			Arguments: delta.Function.Arguments,
+		}
+		fragments[i] = fragment
+
+		for len(a.calls) <= index {
+			a.calls = append(a.calls, interfaces.ToolCall{Index: len(a.calls)})
+		}
+
+		call := &a.calls[index]
+		if fragment.ID != "" {
+			call.ID = fragment.ID
+		}
+		if fragment.Type != "" {
+			call.Type = fragment.Type
+		}
+		if fragment.Name != "" {
+			call.Name = fragment.Name
+		}
+		call.Arguments += fragment.Arguments
+	}
+
+	return fragments
+}
+
+// complete returns the assembled tool calls
+func (a *toolCallAccumulator) complete() []interfaces.ToolCall {
+	if len(a.calls) == 0 {
+		return nil
+	}
+	return append([]interfaces.ToolCall(nil), a.calls...)
+}
@@ -0,0 +1,37 @@
+package llm
+
+import "unicode/utf8"
+
+// runeBuffer holds back an incomplete UTF-8 sequence at the end of a streamed delta until the next
+// delta completes it, so each emitted chunk can be displayed or spoken on its own
+type runeBuffer struct {
+	pending string
+}
+
+// push returns the complete runes of the held-back bytes followed by delta, keeping any incomplete trailing sequence
+func (b *runeBuffer) push(delta string) string {
+	text := b.pending + delta
+	cut := len(text) - incompleteSuffix(text)
+	b.pending = text[cut:]
+	return text[:cut]
+}
+
+// flush returns the bytes still held back once the stream has ended
+func (b *runeBuffer) flush() string {
+	rest := b.pending
+	b.pending = ""
+	return rest
+}
+
+// incompleteSuffix returns the length of the truncated multi-byte sequence ending text, or 0 when it ends on a rune boundary
+func incompleteSuffix(text string) int {
+	for i := 1; i < utf8.UTFMax && i <= len(text); i++ {
+		if utf8.RuneStart(text[len(text)-i]) {
+			if utf8.FullRuneInString(text[len(text)-i:]) {
+				return 0
+			}
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package llm
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRuneBufferReassemblesSplitRunes(t *testing.T) {
+	// Two-, three- and four-byte runes next to ASCII
+	const text = "при€ 😀 ok"
+
+	for cut := 0; cut <= len(text); cut++ {
+		var runes runeBuffer
+		chunks := []string{runes.push(text[:cut]), runes.push(text[cut:]), runes.flush()}
+
+		var got string
+		for _, chunk := range chunks {
+			if !utf8.ValidString(chunk) {
+				t.Errorf("split at byte %d: emitted a broken rune in %q", cut, chunk)
+			}
+			got += chunk
+		}
+		if got != text {
+			t.Errorf("split at byte %d: reassembled %q, want %q", cut, got, text)
+		}
+	}
+}
+
+func TestRuneBufferHoldsBackAcrossDeltas(t *testing.T) {
+	// "😀" arrives one byte per delta
+	var runes runeBuffer
+	emoji := "😀"
+	for i := 0; i < len(emoji)-1; i++ {
+		if got := runes.push(emoji[i : i+1]); got != "" {
+			t.Fatalf("byte %d: emitted %q before the rune was complete", i, got)
+		}
+	}
+	if got := runes.push(emoji[len(emoji)-1:] + "!"); got != emoji+"!" {
+		t.Errorf("got %q once the rune completed", got)
+	}
+	if got := runes.flush(); got != "" {
+		t.Errorf("flush returned %q after a complete rune", got)
+	}
+}
+
+func TestRuneBufferFlushesTruncatedStream(t *testing.T) {
+	// A stream ending mid-rune still hands back every byte it received
+	var runes runeBuffer
+	if got := runes.push("ok\xd0"); got != "ok" {
+		t.Errorf("push returned %q", got)
+	}
+	if got := runes.flush(); got != "\xd0" {
+		t.Errorf("flush returned %q", got)
+	}
+}
@@ -0,0 +1,158 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// sttClient delivers a fixed list of results and then reports end of stream
+type sttClient struct {
+	mu          sync.Mutex
+	results     []*models.STTResult
+	audio       [][]byte
+	closed      bool
+	streamStart time.Time
+}
+
+// newSTTClient creates a mock STT client delivering results in order
+func newSTTClient(results []*models.STTResult) *sttClient {
+	return &sttClient{
+		results:     append([]*models.STTResult(nil), results...),
+		streamStart: time.Now(),
+	}
+}
+
+// Send records the audio chunk
+func (c *sttClient) Send(ctx context.Context, audioData []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return fmt.Errorf("STT client is closed")
+	}
+	c.audio = append(c.audio, append([]byte(nil), audioData...))
+	return nil
+}
+
+// Receive returns the next result, or io.EOF once all results were delivered
+func (c *sttClient) Receive(ctx context.Context) (*models.STTResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.results) == 0 {
+		return nil, io.EOF
+	}
+	result := c.results[0]
+	c.results = c.results[1:]
+	return result, nil
+}
+
+// StreamTo invokes handler for each result until end of stream
+func (c *sttClient) StreamTo(ctx context.Context, handler func(*models.STTResult) error) error {
+	for {
+		result, err := c.Receive(ctx)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := handler(result); err != nil {
+			return err
+		}
+	}
+}
+
+// StreamStartTime returns when the client was created
+func (c *sttClient) StreamStartTime() time.Time {
+	return c.streamStart
+}
+
+// Close closes the client
+func (c *sttClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// ttsClient emits the same audio for every text sent
+type ttsClient struct {
+	audio  []byte
+	voices []models.Voice
+
+	mu      sync.Mutex
+	closed  bool
+	audioCh chan []byte
+}
+
+// newTTSClient creates a mock TTS client
+func newTTSClient(audio []byte, voices []models.Voice) *ttsClient {
+	return &ttsClient{
+		audio:   audio,
+		voices:  voices,
+		audioCh: make(chan []byte, 100),
+	}
+}
+
+// Send queues the configured audio for the text
+func (c *ttsClient) Send(ctx context.Context, text string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return fmt.Errorf("TTS client is closed")
+	}
+
+	select {
+	case c.audioCh <- append([]byte(nil), c.audio...):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive returns the next audio chunk, or io.EOF once the client is closed and drained
+func (c *ttsClient) Receive(ctx context.Context) ([]byte, error) {
+	select {
+	case chunk, ok := <-c.audioCh:
+		if !ok {
+			return nil, io.EOF
+		}
+		return chunk, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// GetVoices returns the configured voices
+func (c *ttsClient) GetVoices(ctx context.Context) ([]models.Voice, error) {
+	return c.voices, nil
+}
+
+// Close closes the client; queued audio can still be received
+func (c *ttsClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.audioCh)
+	}
+	return nil
+}
+
+var (
+	_ interfaces.STTClient = (*sttClient)(nil)
+	_ interfaces.TTSClient = (*ttsClient)(nil)
+)
@@ -0,0 +1,491 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// Config holds the canned responses and failure modes of a MockProvider
+type Config struct {
+	// Name is the provider name; defaults to "mock"
+	Name string
+
+	// Capabilities lists the capabilities the provider reports; defaults to all of them
+	Capabilities []types.Capability
+
+	// ChatResponse is returned by ChatCompletion and streamed word by word by the streaming methods
+	ChatResponse string
+
+	// Embedding is returned by GenerateEmbedding, and by GenerateEmbeddings once per text
+	Embedding []float32
+
+	// Transcript is returned by Transcribe and StreamTranscribe
+	Transcript string
+
+	// STTResults are delivered in order by clients from NewSTTClient;
+	// when empty a single final result holding Transcript is delivered
+	STTResults []*models.STTResult
+
+	// Audio is returned by Synthesize and emitted once per text sent to a TTS client
+	Audio []byte
+
+	// Voices is returned by GetVoices
+	Voices []models.Voice
+
+	// Languages is returned by SupportedLanguages
+	Languages []string
+
+	// Models is returned by GetModels and listed in the provider info
+	Models []models.Model
+
+	// NoStreaming makes DescribeCapabilities report that no capability streams
+	NoStreaming bool
+
+	// Latency delays every call; a call whose context ends first returns the context error
+	Latency time.Duration
+
+	// InitErr is returned by Initialize
+	InitErr error
+
+	// HealthErr is returned by HealthCheck
+	HealthErr error
+
+	// CloseErr is returned by Close
+	CloseErr error
+
+	// Errors maps a capability to the error returned by every call for it
+	Errors map[types.Capability]error
+}
+
+// MockProvider is an in-memory provider implementing every capability with programmable
+// responses, latency and errors. It is safe for concurrent use.
+type MockProvider struct {
+	mu     sync.RWMutex
+	config Config
+	calls  map[string]int
+}
+
+var (
+	_ interfaces.AIProvider     = (*MockProvider)(nil)
+	_ interfaces.SpeechProvider = (*MockProvider)(nil)
+)
+
+// NewMockProvider creates a new mock provider
+func NewMockProvider(config Config) *MockProvider {
+	if config.Name == "" {
+		config.Name = "mock"
+	}
+	if len(config.Capabilities) == 0 {
+		config.Capabilities = []types.Capability{
+			types.CapabilityChat,
+			types.CapabilityEmbedding,
+			types.CapabilitySTT,
+			types.CapabilityTTS,
+		}
+	}
+
+	errs := make(map[types.Capability]error, len(config.Errors))
+	for capability, err := range config.Errors {
+		errs[capability] = err
+	}
+	config.Errors = errs
+
+	return &MockProvider{
+		config: config,
+		calls:  make(map[string]int),
+	}
+}
+
+// SetError makes every call for the capability fail with err; a nil err clears it
+func (p *MockProvider) SetError(capability types.Capability, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		delete(p.config.Errors, capability)
+		return
+	}
+	p.config.Errors[capability] = err
+}
+
+// SetHealthError sets the error returned by HealthCheck
+func (p *MockProvider) SetHealthError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config.HealthErr = err
+}
+
+// SetLatency sets the delay applied to every call
+func (p *MockProvider) SetLatency(latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config.Latency = latency
+}
+
+// Calls returns how many times the named method has been called
+func (p *MockProvider) Calls(method string) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.calls[method]
+}
+
+// Name returns the provider name
+func (p *MockProvider) Name() string {
+	return p.config.Name
+}
+
+// Type returns the provider type
+func (p *MockProvider) Type() models.ProviderType {
+	return models.ProviderTypeAI
+}
+
+// Capabilities returns the list of capabilities this provider supports
+func (p *MockProvider) Capabilities() []types.Capability {
+	return p.config.Capabilities
+}
+
+// Initialize returns the configured InitErr
+func (p *MockProvider) Initialize(ctx context.Context, config models.ProviderConfig) error {
+	cfg, err := p.begin(ctx, "Initialize", "")
+	if err != nil {
+		return err
+	}
+	return cfg.InitErr
+}
+
+// HealthCheck returns the configured HealthErr
+func (p *MockProvider) HealthCheck(ctx context.Context) error {
+	cfg, err := p.begin(ctx, "HealthCheck", "")
+	if err != nil {
+		return err
+	}
+	return cfg.HealthErr
+}
+
+// Close returns the configured CloseErr
+func (p *MockProvider) Close() error {
+	p.record("Close")
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.config.CloseErr
+}
+
+// GetProviderInfo returns metadata about the mock provider
+func (p *MockProvider) GetProviderInfo() *models.ProviderInfo {
+	capabilities := make([]models.Capability, len(p.config.Capabilities))
+	for i, capability := range p.config.Capabilities {
+		capabilities[i] = models.Capability(capability)
+	}
+
+	info := models.NewProviderInfo(p.config.Name, models.ProviderTypeAI, capabilities)
+	info.Description = "In-memory mock provider for tests"
+	info.Available = true
+	for _, model := range p.config.Models {
+		info.AddModel(model.Capability, model)
+	}
+	return info
+}
+
+// DescribeCapabilities reports the configured capabilities, streaming unless NoStreaming is set
+func (p *MockProvider) DescribeCapabilities() *models.ProviderCapabilities {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	streaming := !p.config.NoStreaming
+	capabilities := &models.ProviderCapabilities{}
+	for _, capability := range p.config.Capabilities {
+		switch capability {
+		case types.CapabilityChat:
+			capabilities.Chat = &models.ChatCapability{Streaming: streaming}
+		case types.CapabilityEmbedding:
+			capabilities.Embedding = &models.EmbeddingCapability{Dimensions: len(p.config.Embedding)}
+		case types.CapabilitySTT:
+			capabilities.STT = &models.STTCapability{Streaming: streaming, Languages: p.config.Languages}
+		case types.CapabilityTTS:
+			capabilities.TTS = &models.TTSCapability{Streaming: streaming, Languages: p.config.Languages}
+		}
+	}
+	return capabilities
+}
+
+// ChatCompletion returns the configured ChatResponse, recording the provider in the response metadata
+func (p *MockProvider) ChatCompletion(ctx context.Context, messages []types.ChatMessage, options map[string]any) (string, error) {
+	cfg, err := p.begin(ctx, "ChatCompletion", types.CapabilityChat)
+	if err != nil {
+		return "", err
+	}
+	model, _ := options["model"].(string)
+	models.RecordResponseMetadata(ctx, cfg.Name, model)
+	return cfg.ChatResponse, nil
+}
+
+// StreamChatCompletion streams the configured ChatResponse word by word
+func (p *MockProvider) StreamChatCompletion(ctx context.Context, messages []types.ChatMessage, options map[string]any) (<-chan string, <-chan error) {
+	contentCh := make(chan string)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(contentCh)
+		defer close(errCh)
+
+		cfg, err := p.begin(ctx, "StreamChatCompletion", types.CapabilityChat)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		model, _ := options["model"].(string)
+		models.RecordResponseMetadata(ctx, cfg.Name, model)
+
+		for _, chunk := range chatChunks(cfg.ChatResponse) {
+			select {
+			case contentCh <- chunk:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return contentCh, errCh
+}
+
+// GetModels returns the configured Models
+func (p *MockProvider) GetModels(ctx context.Context) ([]models.Model, error) {
+	cfg, err := p.begin(ctx, "GetModels", "")
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Models, nil
+}
+
+// StreamCompletion streams the configured ChatResponse word by word to stream
+func (p *MockProvider) StreamCompletion(ctx context.Context, req interfaces.ChatRequest, stream interfaces.ChatStream) error {
+	cfg, err := p.begin(ctx, "StreamCompletion", types.CapabilityChat)
+	if err != nil {
+		return err
+	}
+	models.RecordResponseMetadata(ctx, cfg.Name, req.Model)
+
+	var content strings.Builder
+	for _, chunk := range chatChunks(cfg.ChatResponse) {
+		content.WriteString(chunk)
+		if err := stream.Send(interfaces.ChatChunk{Delta: chunk, Content: content.String()}); err != nil {
+			return fmt.Errorf("failed to send chunk: %w", err)
+		}
+	}
+
+	return stream.Send(interfaces.ChatChunk{Content: content.String(), Done: true, FinishReason: "stop"})
+}
+
+// GenerateEmbedding returns a copy of the configured Embedding
+func (p *MockProvider) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	cfg, err := p.begin(ctx, "GenerateEmbedding", types.CapabilityEmbedding)
+	if err != nil {
+		return nil, err
+	}
+	return append([]float32(nil), cfg.Embedding...), nil
+}
+
+// GenerateEmbeddings returns a copy of the configured Embedding for every text
+func (p *MockProvider) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	cfg, err := p.begin(ctx, "GenerateEmbeddings", types.CapabilityEmbedding)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i := range embeddings {
+		embeddings[i] = append([]float32(nil), cfg.Embedding...)
+	}
+	return embeddings, nil
+}
+
+// Transcribe returns the configured Transcript
+func (p *MockProvider) Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error) {
+	cfg, err := p.begin(ctx, "Transcribe", types.CapabilitySTT)
+	if err != nil {
+		return "", err
+	}
+	return cfg.Transcript, nil
+}
+
+// StreamTranscribe drains the audio stream and then emits the configured Transcript
+func (p *MockProvider) StreamTranscribe(ctx context.Context, audioStream <-chan []byte, options map[string]any) (<-chan string, <-chan error) {
+	textCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(textCh)
+		defer close(errCh)
+
+		cfg, err := p.begin(ctx, "StreamTranscribe", types.CapabilitySTT)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		for {
+			select {
+			case _, ok := <-audioStream:
+				if !ok {
+					textCh <- cfg.Transcript
+					return
+				}
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return textCh, errCh
+}
+
+// NewSTTClient creates a client that delivers the configured STT results
+func (p *MockProvider) NewSTTClient(ctx context.Context, config models.STTConfig) (interfaces.STTClient, error) {
+	cfg, err := p.begin(ctx, "NewSTTClient", types.CapabilitySTT)
+	if err != nil {
+		return nil, err
+	}
+
+	results := cfg.STTResults
+	if len(results) == 0 {
+		results = []*models.STTResult{{
+			Text:       cfg.Transcript,
+			Confidence: 1,
+			IsFinal:    true,
+			Language:   config.Language,
+			Timestamp:  time.Now(),
+		}}
+	}
+
+	return newSTTClient(results), nil
+}
+
+// SupportedLanguages returns the configured Languages
+func (p *MockProvider) SupportedLanguages() []string {
+	return p.config.Languages
+}
+
+// Synthesize returns a copy of the configured Audio
+func (p *MockProvider) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
+	cfg, err := p.begin(ctx, "Synthesize", types.CapabilityTTS)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), cfg.Audio...), nil
+}
+
+// StreamSynthesize emits the configured Audio once per text received
+func (p *MockProvider) StreamSynthesize(ctx context.Context, textStream <-chan string, config models.TTSConfig) (<-chan []byte, <-chan error) {
+	audioCh := make(chan []byte)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(audioCh)
+		defer close(errCh)
+
+		cfg, err := p.begin(ctx, "StreamSynthesize", types.CapabilityTTS)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		for {
+			select {
+			case _, ok := <-textStream:
+				if !ok {
+					return
+				}
+				select {
+				case audioCh <- append([]byte(nil), cfg.Audio...):
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return audioCh, errCh
+}
+
+// NewTTSClient creates a client that emits the configured Audio for each Send
+func (p *MockProvider) NewTTSClient(ctx context.Context, config models.TTSConfig) (interfaces.TTSClient, error) {
+	cfg, err := p.begin(ctx, "NewTTSClient", types.CapabilityTTS)
+	if err != nil {
+		return nil, err
+	}
+	return newTTSClient(cfg.Audio, cfg.Voices), nil
+}
+
+// GetVoices returns the configured Voices
+func (p *MockProvider) GetVoices(ctx context.Context) ([]models.Voice, error) {
+	cfg, err := p.begin(ctx, "GetVoices", types.CapabilityTTS)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Voices, nil
+}
+
+// GetVoicesByLanguage returns the configured Voices for a language
+func (p *MockProvider) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
+	cfg, err := p.begin(ctx, "GetVoicesByLanguage", types.CapabilityTTS)
+	if err != nil {
+		return nil, err
+	}
+	return models.FilterVoicesByLanguage(cfg.Voices, language), nil
+}
+
+// begin records a call, applies the configured latency and returns the configured error
+// for the capability, if any, along with a snapshot of the configuration
+func (p *MockProvider) begin(ctx context.Context, method string, capability types.Capability) (Config, error) {
+	p.mu.Lock()
+	p.calls[method]++
+	cfg := p.config
+	err := p.config.Errors[capability]
+	p.mu.Unlock()
+
+	if cfg.Latency > 0 {
+		timer := time.NewTimer(cfg.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return cfg, ctx.Err()
+		}
+	}
+
+	return cfg, err
+}
+
+// record counts a call that has no latency or error behavior
+func (p *MockProvider) record(method string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls[method]++
+}
+
+// chatChunks splits a response into word chunks that concatenate back to it
+func chatChunks(response string) []string {
+	var chunks []string
+	for _, word := range strings.SplitAfter(response, " ") {
+		if word != "" {
+			chunks = append(chunks, word)
+		}
+	}
+	return chunks
+}
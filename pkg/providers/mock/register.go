@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/registry"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// Register creates a mock provider and registers it for the given capabilities,
+// or for all of the provider's capabilities when none are given
+func Register(r registry.ProviderRegistry, config Config, capabilities ...types.Capability) (*MockProvider, error) {
+	provider := NewMockProvider(config)
+	if len(capabilities) == 0 {
+		capabilities = provider.Capabilities()
+	}
+
+	if err := r.RegisterWithCapabilities(provider, capabilities); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// Plugin exposes a MockProvider through the plugin discovery mechanism
+type Plugin struct {
+	provider *MockProvider
+}
+
+// NewPlugin creates a plugin whose Initialize returns provider
+func NewPlugin(provider *MockProvider) *Plugin {
+	return &Plugin{provider: provider}
+}
+
+// Name returns the plugin name
+func (p *Plugin) Name() string {
+	return p.provider.Name()
+}
+
+// Version returns the plugin version
+func (p *Plugin) Version() string {
+	return "1.0.0"
+}
+
+// Capabilities returns the capabilities this plugin provides
+func (p *Plugin) Capabilities() []types.Capability {
+	return p.provider.Capabilities()
+}
+
+// Initialize initializes the mock provider and returns it
+func (p *Plugin) Initialize(ctx context.Context, config models.ProviderConfig) (interfaces.Provider, error) {
+	if err := p.provider.Initialize(ctx, config); err != nil {
+		return nil, err
+	}
+	return p.provider, nil
+}
+
+// Metadata returns additional metadata about the plugin
+func (p *Plugin) Metadata() map[string]any {
+	return map[string]any{
+		"description": "In-memory mock provider for tests",
+	}
+}
+
+var _ registry.ProviderPlugin = (*Plugin)(nil)
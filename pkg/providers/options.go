@@ -0,0 +1,27 @@
+package providers
+
+import (
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// WarnUnknownOptions logs a warning when options holds keys the provider does not describe for scope,
+// since such options are silently ignored, and returns those keys. Providers that do not describe
+// their options are not checked.
+func WarnUnknownOptions(provider any, scope models.OptionScope, options map[string]any, logger types.Logger) []string {
+	describer, ok := provider.(interfaces.OptionDescriber)
+	if !ok {
+		return nil
+	}
+
+	unknown := models.UnknownOptions(describer.SupportedOptions(), scope, options)
+	if len(unknown) > 0 && logger != nil {
+		name := ""
+		if named, ok := provider.(interfaces.Provider); ok {
+			name = named.Name()
+		}
+		logger.Warn("Ignoring unsupported provider options", "provider", name, "scope", scope, "options", unknown)
+	}
+	return unknown
+}
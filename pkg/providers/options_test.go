@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// describedProvider describes a single STT option
+type describedProvider struct{}
+
+func (describedProvider) SupportedOptions() []models.OptionDescriptor {
+	return []models.OptionDescriptor{
+		{Name: "max_message_bytes", Type: models.OptionTypeInt, Scope: models.OptionScopeSTT},
+	}
+}
+
+// warnLogger records every warning it is given
+type warnLogger struct {
+	warnings []string
+}
+
+func (l *warnLogger) Debug(string, ...any) {}
+func (l *warnLogger) Info(string, ...any)  {}
+func (l *warnLogger) Error(string, ...any) {}
+func (l *warnLogger) Warn(msg string, args ...any) {
+	l.warnings = append(l.warnings, fmt.Sprint(msg, args))
+}
+
+func TestWarnUnknownOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider any
+		scope    models.OptionScope
+		options  map[string]any
+		unknown  []string
+	}{
+		{
+			name:     "known keys",
+			provider: describedProvider{},
+			scope:    models.OptionScopeSTT,
+			options:  map[string]any{"max_message_bytes": 1024},
+		},
+		{
+			name:     "unknown keys",
+			provider: describedProvider{},
+			scope:    models.OptionScopeSTT,
+			options:  map[string]any{"max_message_bytes": 1024, "sample_rte": 16000, "endpointing": 300},
+			unknown:  []string{"endpointing", "sample_rte"},
+		},
+		{
+			name:     "key described for another scope",
+			provider: describedProvider{},
+			scope:    models.OptionScopeTTS,
+			options:  map[string]any{"max_message_bytes": 1024},
+			unknown:  []string{"max_message_bytes"},
+		},
+		{
+			name:     "provider without descriptors",
+			provider: struct{}{},
+			scope:    models.OptionScopeSTT,
+			options:  map[string]any{"anything": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &warnLogger{}
+			unknown := WarnUnknownOptions(tt.provider, tt.scope, tt.options, logger)
+
+			if !reflect.DeepEqual(unknown, tt.unknown) {
+				t.Errorf("expected unknown options %v, got %v", tt.unknown, unknown)
+			}
+			wantWarnings := 0
+			if len(tt.unknown) > 0 {
+				wantWarnings = 1
+			}
+			if len(logger.warnings) != wantWarnings {
+				t.Errorf("expected %d warnings, got %v", wantWarnings, logger.warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// configReloadDebounce coalesces bursts of file events into a single reload
+const configReloadDebounce = 200 * time.Millisecond
+
+// NewDiscoveryConfigFromDir creates a discovery config from a directory of provider config files
+func NewDiscoveryConfigFromDir(dir string) (*DiscoveryConfig, error) {
+	configs, err := LoadProviderConfigsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscoveryConfig{
+		AutoDiscover:    true,
+		ProviderConfigs: configs,
+	}, nil
+}
+
+// LoadProviderConfigsFromDir reads every .yaml, .yml and .json file in dir and returns
+// the provider configs keyed by provider name. The file name (without extension) is used
+// when a file does not set a name.
+func LoadProviderConfigsFromDir(dir string) (map[string]models.ProviderConfig, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %w", err)
+	}
+
+	configs := make(map[string]models.ProviderConfig)
+	sources := make(map[string]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isProviderConfigFile(entry.Name()) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		config, err := loadProviderConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if config.Name == "" {
+			config.Name = strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		}
+
+		if existing, exists := sources[config.Name]; exists {
+			return nil, fmt.Errorf("provider %s is configured in both %s and %s", config.Name, existing, entry.Name())
+		}
+
+		configs[config.Name] = config
+		sources[config.Name] = entry.Name()
+	}
+
+	return configs, nil
+}
+
+// WatchProviderConfigDir reloads the provider configs in dir whenever a config file changes
+// and passes the result to onChange. It blocks until the context is cancelled.
+func WatchProviderConfigDir(ctx context.Context, dir string, onChange func(map[string]models.ProviderConfig, error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !isProviderConfigFile(event.Name) {
+				continue
+			}
+			reload = time.After(configReloadDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			onChange(nil, fmt.Errorf("config watcher error: %w", err))
+
+		case <-reload:
+			reload = nil
+			onChange(LoadProviderConfigsFromDir(dir))
+		}
+	}
+}
+
+// loadProviderConfigFile parses a single YAML or JSON provider config file
+func loadProviderConfigFile(path string) (models.ProviderConfig, error) {
+	var config models.ProviderConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read provider config %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return config, fmt.Errorf("failed to parse YAML provider config %s: %w", path, err)
+		}
+	case ".json":
+		if err := unmarshalJSONProviderConfig(data, &config); err != nil {
+			return config, fmt.Errorf("failed to parse JSON provider config %s: %w", path, err)
+		}
+	}
+
+	return config, nil
+}
+
+// unmarshalJSONProviderConfig parses a JSON provider config, accepting the timeout either as
+// a duration string such as "30s" (as YAML does) or as nanoseconds
+func unmarshalJSONProviderConfig(data []byte, config *models.ProviderConfig) error {
+	var raw struct {
+		models.ProviderConfig
+		Timeout json.RawMessage `json:"timeout,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*config = raw.ProviderConfig
+
+	if len(raw.Timeout) == 0 || string(raw.Timeout) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw.Timeout, &text); err == nil {
+		timeout, err := time.ParseDuration(text)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", text, err)
+		}
+		config.Timeout = timeout
+		return nil
+	}
+
+	if err := json.Unmarshal(raw.Timeout, &config.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %w", raw.Timeout, err)
+	}
+	return nil
+}
+
+// isProviderConfigFile reports whether a file name has a supported config extension
+func isProviderConfigFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
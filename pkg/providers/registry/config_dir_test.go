@@ -0,0 +1,153 @@
+package registry_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/registry"
+)
+
+// writeConfig writes a provider config file into dir
+func writeConfig(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestLoadProviderConfigsFromDir(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "openai.yaml", "type: openai\napi_key: sk-yaml\ntimeout: 30s\nenabled: true\n")
+	writeConfig(t, dir, "deepgram.json", `{"type": "deepgram", "timeout": "1m30s", "enabled": true}`)
+	writeConfig(t, dir, "legacy.json", `{"name": "gemini", "type": "gemini", "timeout": 5000000000}`)
+	writeConfig(t, dir, "notes.txt", "not a config")
+	if err := os.Mkdir(filepath.Join(dir, "nested.yaml"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	configs, err := registry.LoadProviderConfigsFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadProviderConfigsFromDir: %v", err)
+	}
+
+	if len(configs) != 3 {
+		t.Fatalf("expected 3 configs, got %v", configs)
+	}
+	tests := []struct {
+		name     string
+		typ      models.ProviderType
+		timeout  time.Duration
+		disabled bool
+	}{
+		{name: "openai", typ: models.ProviderTypeOpenAI, timeout: 30 * time.Second},
+		{name: "deepgram", typ: models.ProviderTypeDeepgram, timeout: 90 * time.Second},
+		{name: "gemini", typ: models.ProviderTypeGemini, timeout: 5 * time.Second, disabled: true},
+	}
+	for _, tt := range tests {
+		config, ok := configs[tt.name]
+		if !ok {
+			t.Errorf("missing config %s", tt.name)
+			continue
+		}
+		if config.Name != tt.name || config.Type != tt.typ || config.Timeout != tt.timeout || config.Enabled == tt.disabled {
+			t.Errorf("unexpected config %+v", config)
+		}
+	}
+	if configs["openai"].APIKey != "sk-yaml" {
+		t.Errorf("expected the YAML api key, got %q", configs["openai"].APIKey)
+	}
+}
+
+func TestLoadProviderConfigsFromDirErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "duplicate name",
+			files: map[string]string{"a.yaml": "name: openai\n", "openai.json": `{"type": "openai"}`},
+			want:  "configured in both",
+		},
+		{
+			name:  "invalid timeout",
+			files: map[string]string{"openai.json": `{"timeout": "soon"}`},
+			want:  "invalid timeout",
+		},
+		{
+			name:  "malformed YAML",
+			files: map[string]string{"openai.yml": "options: [\n"},
+			want:  "failed to parse YAML",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeConfig(t, dir, name, content)
+			}
+			_, err := registry.LoadProviderConfigsFromDir(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := registry.LoadProviderConfigsFromDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestWatchProviderConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "openai.yaml", "type: openai\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan map[string]models.ProviderConfig, 4)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- registry.WatchProviderConfigDir(ctx, dir, func(configs map[string]models.ProviderConfig, err error) {
+			if err != nil {
+				t.Errorf("reload: %v", err)
+				return
+			}
+			changes <- configs
+		})
+	}()
+
+	// The watcher starts asynchronously, so the file is rewritten until a reload reports it.
+	// The interval is well above the debounce, which every write restarts.
+	deadline := time.After(10 * time.Second)
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+	for reloaded := false; !reloaded; {
+		select {
+		case configs := <-changes:
+			if _, ok := configs["deepgram"]; ok && len(configs) == 2 {
+				reloaded = true
+			}
+		case <-tick.C:
+			writeConfig(t, dir, "deepgram.json", `{"type": "deepgram", "timeout": "10s"}`)
+		case <-deadline:
+			t.Fatal("timed out waiting for the reload")
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-watchErr:
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the watcher did not stop on cancel")
+	}
+}
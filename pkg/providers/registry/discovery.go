@@ -3,6 +3,7 @@ package registry
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/creastat/common-go/pkg/interfaces"
@@ -46,6 +47,9 @@ type PluginRegistry interface {
 	DiscoverAndRegister(ctx context.Context, configs map[string]models.ProviderConfig, providerRegistry ProviderRegistry) error
 }
 
+// maxConcurrentPluginInits bounds how many plugins DiscoverAndRegister initializes at once
+const maxConcurrentPluginInits = 8
+
 // pluginRegistry is the concrete implementation of PluginRegistry
 type pluginRegistry struct {
 	mu      sync.RWMutex
@@ -139,35 +143,37 @@ func (pr *pluginRegistry) DiscoverAndRegister(
 	}
 	pr.mu.RUnlock()
 
-	var errors []error
-	var mu sync.Mutex
-
-	// Process each plugin
-	for name, plugin := range pluginsCopy {
-		// Check if we have configuration for this plugin
-		config, hasConfig := configs[name]
-		if !hasConfig {
-			// Skip plugins without configuration
-			continue
+	// Only plugins with configuration are initialized; sort them so errors are reported in a stable order
+	names := make([]string, 0, len(pluginsCopy))
+	for name := range pluginsCopy {
+		if _, hasConfig := configs[name]; hasConfig {
+			names = append(names, name)
 		}
+	}
+	sort.Strings(names)
 
-		// Initialize the plugin
-		provider, err := plugin.Initialize(ctx, config)
-		if err != nil {
-			mu.Lock()
-			errors = append(errors, fmt.Errorf("failed to initialize plugin %s: %w", name, err))
-			mu.Unlock()
-			continue
-		}
+	// Initialize plugins concurrently, bounded so that startup does not open too many connections at once
+	results := make([]error, len(names))
+	sem := make(chan struct{}, maxConcurrentPluginInits)
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 
-		// Register the provider
-		if err := providerRegistry.Register(provider); err != nil {
-			mu.Lock()
-			errors = append(errors, fmt.Errorf("failed to register provider %s: %w", name, err))
-			mu.Unlock()
-			// Close the provider since registration failed
-			_ = provider.Close()
-			continue
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = initializeAndRegister(ctx, name, pluginsCopy[name], configs[name], providerRegistry)
+		}()
+	}
+	wg.Wait()
+
+	var errors []error
+	for _, err := range results {
+		if err != nil {
+			errors = append(errors, err)
 		}
 	}
 
@@ -179,6 +185,28 @@ func (pr *pluginRegistry) DiscoverAndRegister(
 	return nil
 }
 
+// initializeAndRegister initializes a single plugin and registers the resulting provider
+func initializeAndRegister(
+	ctx context.Context,
+	name string,
+	plugin ProviderPlugin,
+	config models.ProviderConfig,
+	providerRegistry ProviderRegistry,
+) error {
+	provider, err := plugin.Initialize(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize plugin %s: %w", name, err)
+	}
+
+	if err := providerRegistry.Register(provider); err != nil {
+		// Close the provider since registration failed
+		_ = provider.Close()
+		return fmt.Errorf("failed to register provider %s: %w", name, err)
+	}
+
+	return nil
+}
+
 // ProviderDiscovery provides utilities for discovering and loading providers
 type ProviderDiscovery struct {
 	pluginRegistry   PluginRegistry
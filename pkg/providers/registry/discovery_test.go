@@ -0,0 +1,144 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/mock"
+	"github.com/creastat/common-go/pkg/providers/registry"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// initTracker records how many plugins are initializing at once
+type initTracker struct {
+	mu     sync.Mutex
+	active int
+	peak   int
+}
+
+func (t *initTracker) enter() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active++
+	t.peak = max(t.peak, t.active)
+}
+
+func (t *initTracker) leave() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active--
+}
+
+// slowPlugin is a mock plugin whose Initialize takes a while and optionally fails
+type slowPlugin struct {
+	*mock.Plugin
+	tracker *initTracker
+	delay   time.Duration
+	err     error
+}
+
+func (p *slowPlugin) Initialize(ctx context.Context, config models.ProviderConfig) (interfaces.Provider, error) {
+	p.tracker.enter()
+	defer p.tracker.leave()
+
+	time.Sleep(p.delay)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.Plugin.Initialize(ctx, config)
+}
+
+func TestDiscoverAndRegisterInitializesInParallel(t *testing.T) {
+	const (
+		pluginCount = 20
+		delay       = 50 * time.Millisecond
+	)
+
+	plugins := registry.NewPluginRegistry()
+	tracker := &initTracker{}
+	configs := make(map[string]models.ProviderConfig)
+	var failing []string
+
+	// Register in reverse so that map and registration order differ from the sorted order
+	for i := pluginCount - 1; i >= 0; i-- {
+		name := fmt.Sprintf("provider-%02d", i)
+		plugin := &slowPlugin{
+			Plugin:  mock.NewPlugin(mock.NewMockProvider(mock.Config{Name: name})),
+			tracker: tracker,
+			delay:   delay,
+		}
+		if i%5 == 0 {
+			plugin.err = errors.New("unreachable")
+			failing = append([]string{name}, failing...)
+		}
+		if err := plugins.RegisterPlugin(plugin); err != nil {
+			t.Fatalf("RegisterPlugin(%s): %v", name, err)
+		}
+		configs[name] = models.ProviderConfig{Name: name}
+	}
+
+	// A plugin without configuration is never initialized
+	unconfigured := &slowPlugin{
+		Plugin:  mock.NewPlugin(mock.NewMockProvider(mock.Config{Name: "unconfigured"})),
+		tracker: tracker,
+		err:     errors.New("initialized without configuration"),
+	}
+	if err := plugins.RegisterPlugin(unconfigured); err != nil {
+		t.Fatalf("RegisterPlugin: %v", err)
+	}
+
+	reg := registry.NewProviderRegistry()
+	start := time.Now()
+	err := plugins.DiscoverAndRegister(context.Background(), configs, reg)
+	elapsed := time.Since(start)
+
+	if tracker.peak < 2 || tracker.peak > 8 {
+		t.Errorf("expected between 2 and 8 concurrent initializations, got %d", tracker.peak)
+	}
+	if serial := pluginCount * delay; elapsed >= serial/2 {
+		t.Errorf("initialization took %v, expected well under the serial %v", elapsed, serial)
+	}
+
+	// Failures are reported in sorted plugin order, whichever finishes first
+	if err == nil {
+		t.Fatal("expected the failing plugins to be reported")
+	}
+	message := err.Error()
+	if !strings.Contains(message, fmt.Sprintf("%d error(s)", len(failing))) {
+		t.Errorf("expected %d errors, got %v", len(failing), err)
+	}
+	position := -1
+	for _, name := range failing {
+		index := strings.Index(message, "plugin "+name+":")
+		if index < 0 {
+			t.Errorf("%s is not reported: %v", name, err)
+			continue
+		}
+		if index < position {
+			t.Errorf("%s is reported out of order: %v", name, err)
+		}
+		position = index
+	}
+	if strings.Contains(message, "unconfigured") {
+		t.Errorf("the unconfigured plugin was initialized: %v", err)
+	}
+
+	registered := reg.List(types.CapabilityChat)
+	if len(registered) != pluginCount-len(failing) {
+		t.Errorf("expected %d registered providers, got %d", pluginCount-len(failing), len(registered))
+	}
+	for i := range pluginCount {
+		name := fmt.Sprintf("provider-%02d", i)
+		_, getErr := reg.Get(name, types.CapabilityChat)
+		if wantRegistered := i%5 != 0; (getErr == nil) != wantRegistered {
+			t.Errorf("%s: expected registered %v, got error %v", name, wantRegistered, getErr)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package registry
+
+import (
+	"context"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// CapabilityHealth checks each capability of a provider and returns the outcome per capability,
+// where a nil error means healthy. Providers that cannot check capabilities separately report the
+// result of HealthCheck for every capability.
+func CapabilityHealth(ctx context.Context, provider interfaces.BaseProvider) map[types.Capability]error {
+	results := make(map[types.Capability]error)
+
+	checker, ok := provider.(interfaces.CapabilityHealthChecker)
+	if !ok {
+		err := provider.HealthCheck(ctx)
+		for _, capability := range provider.Capabilities() {
+			results[capability] = err
+		}
+		return results
+	}
+
+	for _, capability := range provider.Capabilities() {
+		results[capability] = checker.CheckCapabilityHealth(ctx, capability)
+	}
+	return results
+}
+
+// HealthStatusOf summarizes per-capability results: healthy when every capability passed,
+// unhealthy when none did and degraded otherwise
+func HealthStatusOf(results map[types.Capability]error) models.HealthStatus {
+	if len(results) == 0 {
+		return models.HealthStatusUnknown
+	}
+
+	failed := 0
+	for _, err := range results {
+		if err != nil {
+			failed++
+		}
+	}
+
+	switch failed {
+	case 0:
+		return models.HealthStatusHealthy
+	case len(results):
+		return models.HealthStatusUnhealthy
+	default:
+		return models.HealthStatusDegraded
+	}
+}
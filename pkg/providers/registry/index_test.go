@@ -0,0 +1,49 @@
+package registry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// stubProvider is a chat provider without services; the registry only needs its identity
+type stubProvider struct{ name string }
+
+func (p stubProvider) Name() string              { return p.name }
+func (p stubProvider) Type() models.ProviderType { return models.ProviderType(p.name) }
+func (p stubProvider) Capabilities() []types.Capability {
+	return []types.Capability{types.CapabilityChat}
+}
+func (p stubProvider) Initialize(context.Context, models.ProviderConfig) error { return nil }
+func (p stubProvider) Close() error                                            { return nil }
+func (p stubProvider) HealthCheck(context.Context) error                       { return nil }
+
+func TestCheckConsistencyReportsDuplicates(t *testing.T) {
+	r := NewProviderRegistry().(*providerRegistry)
+	if err := r.Register(stubProvider{name: "stub"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	r.addToCapabilityIndex(types.CapabilityChat, "stub")
+	if got := r.capabilityIndex[types.CapabilityChat]; len(got) != 1 {
+		t.Fatalf("adding an indexed provider again duplicated it: %v", got)
+	}
+	if err := r.CheckConsistency(); err != nil {
+		t.Fatalf("CheckConsistency: %v", err)
+	}
+
+	// Corrupt the index behind the registry's back
+	r.capabilityIndex[types.CapabilityChat] = append(r.capabilityIndex[types.CapabilityChat], "stub")
+	err := r.CheckConsistency()
+	if err == nil || !strings.Contains(err.Error(), "indexed more than once") {
+		t.Errorf("expected the duplicate to be reported, got %v", err)
+	}
+
+	r.removeFromCapabilityIndex(types.CapabilityChat, "stub")
+	if _, exists := r.capabilityIndex[types.CapabilityChat]; exists {
+		t.Error("removing a provider left duplicates behind")
+	}
+}
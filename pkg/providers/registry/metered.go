@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// meteredChatService records ChatCompletion metrics for a chat service
+type meteredChatService struct {
+	interfaces.ChatService
+	name    string
+	metrics *MetricsCollector
+}
+
+// ChatCompletion records the latency and outcome of the wrapped call
+func (s *meteredChatService) ChatCompletion(ctx context.Context, messages []types.ChatMessage, options map[string]any) (string, error) {
+	start := time.Now()
+	result, err := s.ChatService.ChatCompletion(ctx, messages, options)
+	s.metrics.RecordRequest(s.name, types.CapabilityChat, time.Since(start), err)
+	return result, err
+}
+
+// meteredEmbeddingService records GenerateEmbedding and GenerateEmbeddings metrics for an embedding service
+type meteredEmbeddingService struct {
+	interfaces.EmbeddingService
+	name    string
+	metrics *MetricsCollector
+}
+
+// GenerateEmbedding records the latency and outcome of the wrapped call
+func (s *meteredEmbeddingService) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	start := time.Now()
+	result, err := s.EmbeddingService.GenerateEmbedding(ctx, text)
+	s.metrics.RecordRequest(s.name, types.CapabilityEmbedding, time.Since(start), err)
+	return result, err
+}
+
+// GenerateEmbeddings records the latency and outcome of the wrapped call
+func (s *meteredEmbeddingService) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	start := time.Now()
+	result, err := s.EmbeddingService.GenerateEmbeddings(ctx, texts)
+	s.metrics.RecordRequest(s.name, types.CapabilityEmbedding, time.Since(start), err)
+	return result, err
+}
+
+// meteredSTTService records Transcribe metrics for an STT service
+type meteredSTTService struct {
+	interfaces.STTService
+	name    string
+	metrics *MetricsCollector
+}
+
+// Transcribe records the latency and outcome of the wrapped call
+func (s *meteredSTTService) Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error) {
+	start := time.Now()
+	result, err := s.STTService.Transcribe(ctx, audioData, options)
+	s.metrics.RecordRequest(s.name, types.CapabilitySTT, time.Since(start), err)
+	return result, err
+}
+
+// meteredTTSService records Synthesize metrics for a TTS service
+type meteredTTSService struct {
+	interfaces.TTSService
+	name    string
+	metrics *MetricsCollector
+}
+
+// Synthesize records the latency and outcome of the wrapped call
+func (s *meteredTTSService) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
+	start := time.Now()
+	result, err := s.TTSService.Synthesize(ctx, text, config)
+	s.metrics.RecordRequest(s.name, types.CapabilityTTS, time.Since(start), err)
+	return result, err
+}
@@ -0,0 +1,179 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// MetricsCollector records request metrics per provider and capability
+type MetricsCollector struct {
+	// mu guards the entries map; it is held exclusively while snapshotting or resetting
+	// so that a snapshot never observes a partially recorded request
+	mu      sync.RWMutex
+	entries map[string]*metricsEntry
+}
+
+// metricsEntry holds the counters for a single provider+capability pair
+type metricsEntry struct {
+	providerName string
+	capability   types.Capability
+
+	totalRequests  atomic.Int64
+	successfulReqs atomic.Int64
+	failedReqs     atomic.Int64
+	totalLatency   atomic.Int64 // nanoseconds
+
+	mu              sync.Mutex
+	lastRequestTime time.Time
+	lastErrorTime   time.Time
+	lastError       string
+}
+
+// NewMetricsCollector creates a new metrics collector
+func NewMetricsCollector() *MetricsCollector {
+	return &MetricsCollector{
+		entries: make(map[string]*metricsEntry),
+	}
+}
+
+// RecordRequest records the outcome and latency of a single provider request
+func (m *MetricsCollector) RecordRequest(providerName string, capability types.Capability, latency time.Duration, err error) {
+	key := metricsKey(providerName, capability)
+
+	for {
+		m.mu.RLock()
+		entry, exists := m.entries[key]
+		if exists {
+			entry.record(latency, err)
+			m.mu.RUnlock()
+			return
+		}
+		m.mu.RUnlock()
+
+		m.ensureEntry(key, providerName, capability)
+	}
+}
+
+// GetMetrics returns the metrics for a provider and capability
+func (m *MetricsCollector) GetMetrics(providerName string, capability types.Capability) (*models.ProviderMetrics, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[metricsKey(providerName, capability)]
+	if !exists {
+		return nil, fmt.Errorf("no metrics recorded for provider %s capability %s", providerName, capability)
+	}
+
+	metrics := entry.snapshot()
+	return &metrics, nil
+}
+
+// ResetMetrics zeroes all counters recorded for a provider across every capability, so
+// GetMetrics reports zero counters rather than no metrics
+func (m *MetricsCollector) ResetMetrics(providerName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, entry := range m.entries {
+		if entry.providerName == providerName {
+			m.entries[key] = &metricsEntry{providerName: providerName, capability: entry.capability}
+		}
+	}
+}
+
+// RemoveMetrics drops every metrics entry for a provider, as when it is unregistered
+func (m *MetricsCollector) RemoveMetrics(providerName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, entry := range m.entries {
+		if entry.providerName == providerName {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// SnapshotAllMetrics returns a consistent copy of all recorded metrics keyed by "provider:capability"
+func (m *MetricsCollector) SnapshotAllMetrics() map[string]models.ProviderMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]models.ProviderMetrics, len(m.entries))
+	for key, entry := range m.entries {
+		snapshot[key] = entry.snapshot()
+	}
+
+	return snapshot
+}
+
+// ensureEntry creates the entry for a provider and capability if it does not exist
+func (m *MetricsCollector) ensureEntry(key, providerName string, capability types.Capability) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.entries[key]; exists {
+		return
+	}
+
+	m.entries[key] = &metricsEntry{
+		providerName: providerName,
+		capability:   capability,
+	}
+}
+
+// record updates the entry counters with a single request outcome
+func (e *metricsEntry) record(latency time.Duration, err error) {
+	e.totalRequests.Add(1)
+	e.totalLatency.Add(int64(latency))
+	if err != nil {
+		e.failedReqs.Add(1)
+	} else {
+		e.successfulReqs.Add(1)
+	}
+
+	now := time.Now()
+	e.mu.Lock()
+	e.lastRequestTime = now
+	if err != nil {
+		e.lastErrorTime = now
+		e.lastError = err.Error()
+	}
+	e.mu.Unlock()
+}
+
+// snapshot converts the entry counters to a ProviderMetrics value
+func (e *metricsEntry) snapshot() models.ProviderMetrics {
+	total := e.totalRequests.Load()
+	failed := e.failedReqs.Load()
+
+	metrics := models.ProviderMetrics{
+		ProviderName:   e.providerName,
+		Capability:     models.Capability(e.capability),
+		TotalRequests:  total,
+		SuccessfulReqs: e.successfulReqs.Load(),
+		FailedReqs:     failed,
+	}
+
+	if total > 0 {
+		metrics.AverageLatency = time.Duration(e.totalLatency.Load() / total)
+		metrics.ErrorRate = float64(failed) / float64(total)
+	}
+
+	e.mu.Lock()
+	metrics.LastRequestTime = e.lastRequestTime
+	metrics.LastErrorTime = e.lastErrorTime
+	metrics.LastError = e.lastError
+	e.mu.Unlock()
+
+	return metrics
+}
+
+// metricsKey builds the map key for a provider and capability
+func metricsKey(providerName string, capability types.Capability) string {
+	return fmt.Sprintf("%s:%s", providerName, capability)
+}
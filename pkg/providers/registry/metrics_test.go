@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/types"
+)
+
+func TestSnapshotsAreConsistent(t *testing.T) {
+	m := NewMetricsCollector()
+	failure := errors.New("failed")
+
+	const writers, requests = 4, 500
+	var wg sync.WaitGroup
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range requests {
+				var err error
+				if (i+j)%3 == 0 {
+					err = failure
+				}
+				m.RecordRequest("mock", types.CapabilityChat, time.Millisecond, err)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+
+		for key, metrics := range m.SnapshotAllMetrics() {
+			if metrics.SuccessfulReqs+metrics.FailedReqs != metrics.TotalRequests {
+				t.Fatalf("%s: %d successful and %d failed requests do not add up to %d", key, metrics.SuccessfulReqs, metrics.FailedReqs, metrics.TotalRequests)
+			}
+		}
+	}
+
+	metrics, err := m.GetMetrics("mock", types.CapabilityChat)
+	if err != nil {
+		t.Fatalf("GetMetrics: %v", err)
+	}
+	if metrics.TotalRequests != writers*requests {
+		t.Errorf("expected %d requests, got %d", writers*requests, metrics.TotalRequests)
+	}
+}
+
+func TestResetMetricsZeroesCounters(t *testing.T) {
+	m := NewMetricsCollector()
+	m.RecordRequest("mock", types.CapabilityChat, time.Millisecond, nil)
+	m.RecordRequest("mock", types.CapabilityTTS, time.Millisecond, errors.New("failed"))
+	m.RecordRequest("other", types.CapabilityChat, time.Millisecond, nil)
+
+	m.ResetMetrics("mock")
+
+	for _, capability := range []types.Capability{types.CapabilityChat, types.CapabilityTTS} {
+		metrics, err := m.GetMetrics("mock", capability)
+		if err != nil {
+			t.Fatalf("GetMetrics(%s) after reset: %v", capability, err)
+		}
+		if metrics.TotalRequests != 0 || metrics.FailedReqs != 0 || metrics.LastError != "" || !metrics.LastRequestTime.IsZero() {
+			t.Errorf("%s: expected zero counters, got %+v", capability, metrics)
+		}
+	}
+
+	if metrics, err := m.GetMetrics("other", types.CapabilityChat); err != nil || metrics.TotalRequests != 1 {
+		t.Errorf("another provider's metrics were reset: %+v, %v", metrics, err)
+	}
+
+	m.RecordRequest("mock", types.CapabilityChat, time.Millisecond, nil)
+	if metrics, _ := m.GetMetrics("mock", types.CapabilityChat); metrics.TotalRequests != 1 {
+		t.Errorf("expected counting to resume after a reset, got %d", metrics.TotalRequests)
+	}
+
+	m.RemoveMetrics("mock")
+	if _, err := m.GetMetrics("mock", types.CapabilityChat); err == nil {
+		t.Error("expected no metrics after removal")
+	}
+}
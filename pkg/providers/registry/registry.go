@@ -2,12 +2,16 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
 	"github.com/creastat/common-go/pkg/types"
 )
 
@@ -16,9 +20,15 @@ type ProviderRegistry interface {
 	// Register registers a provider with the registry
 	Register(provider interfaces.Provider) error
 
+	// RegisterWithCapabilities registers a provider for a subset of its capabilities
+	RegisterWithCapabilities(provider interfaces.Provider, capabilities []types.Capability) error
+
 	// Get retrieves a provider by name and capability
 	Get(name string, capability types.Capability) (interfaces.Provider, error)
 
+	// GetHealthy retrieves a provider like Get, probing its health first if it has never been checked
+	GetHealthy(ctx context.Context, name string, capability types.Capability) (interfaces.Provider, error)
+
 	// List returns all providers that support a given capability
 	List(capability types.Capability) []interfaces.Provider
 
@@ -36,6 +46,36 @@ type ProviderRegistry interface {
 
 	// GetAvailableProviders returns all healthy providers for a capability
 	GetAvailableProviders(capability types.Capability) []interfaces.Provider
+
+	// GetChatService retrieves a provider's chat service with metrics recording
+	GetChatService(name string) (interfaces.ChatService, error)
+
+	// GetEmbeddingService retrieves a provider's embedding service with metrics recording
+	GetEmbeddingService(name string) (interfaces.EmbeddingService, error)
+
+	// GetSTTService retrieves a provider's STT service with metrics recording
+	GetSTTService(name string) (interfaces.STTService, error)
+
+	// GetTTSService retrieves a provider's TTS service with metrics recording
+	GetTTSService(name string) (interfaces.TTSService, error)
+
+	// EstimateRequestCost estimates the cost of a request to a provider's model from its pricing
+	EstimateRequestCost(providerName, modelID string, inputTokens, outputTokens int) (float64, string, error)
+
+	// SelectCheapestProvider returns the healthy provider and model with the lowest estimated cost for a capability
+	SelectCheapestProvider(capability types.Capability, estTokens int) (interfaces.Provider, string)
+
+	// GetMetrics returns the recorded metrics for a provider and capability
+	GetMetrics(name string, capability types.Capability) (*models.ProviderMetrics, error)
+
+	// ResetMetrics zeroes the recorded metrics for a provider
+	ResetMetrics(name string)
+
+	// CloseAll closes every registered provider and clears the registry
+	CloseAll() error
+
+	// CheckConsistency verifies that the capability index matches the registered providers
+	CheckConsistency() error
 }
 
 // providerRegistry is the concrete implementation of ProviderRegistry
@@ -48,6 +88,9 @@ type providerRegistry struct {
 	// capabilityIndex maps capabilities to provider names for fast lookups
 	capabilityIndex map[types.Capability][]string
 
+	// registeredCapabilities stores the capabilities each provider was registered with
+	registeredCapabilities map[string][]types.Capability
+
 	// providerInfo stores metadata about each provider
 	providerInfo map[string]*models.ProviderInfo
 
@@ -56,16 +99,24 @@ type providerRegistry struct {
 
 	// lastHealthCheck tracks when each provider was last checked
 	lastHealthCheck map[string]time.Time
+
+	// metrics records request outcomes per provider and capability
+	metrics *MetricsCollector
 }
 
+// healthProbeTimeout bounds the lazy health check performed by GetHealthy
+const healthProbeTimeout = 5 * time.Second
+
 // NewProviderRegistry creates a new provider registry
 func NewProviderRegistry() ProviderRegistry {
 	return &providerRegistry{
-		providers:       make(map[string]interfaces.Provider),
-		capabilityIndex: make(map[types.Capability][]string),
-		providerInfo:    make(map[string]*models.ProviderInfo),
-		healthStatus:    make(map[string]models.HealthStatus),
-		lastHealthCheck: make(map[string]time.Time),
+		providers:              make(map[string]interfaces.Provider),
+		capabilityIndex:        make(map[types.Capability][]string),
+		registeredCapabilities: make(map[string][]types.Capability),
+		providerInfo:           make(map[string]*models.ProviderInfo),
+		healthStatus:           make(map[string]models.HealthStatus),
+		lastHealthCheck:        make(map[string]time.Time),
+		metrics:                NewMetricsCollector(),
 	}
 }
 
@@ -75,12 +126,21 @@ func (r *providerRegistry) Register(provider interfaces.Provider) error {
 		return fmt.Errorf("provider cannot be nil")
 	}
 
+	return r.RegisterWithCapabilities(provider, provider.Capabilities())
+}
+
+// RegisterWithCapabilities registers a provider for a subset of its capabilities.
+// Only the given capabilities are indexed, so the provider is not routable for the rest.
+func (r *providerRegistry) RegisterWithCapabilities(provider interfaces.Provider, capabilities []types.Capability) error {
+	if provider == nil {
+		return fmt.Errorf("provider cannot be nil")
+	}
+
 	name := provider.Name()
 	if name == "" {
 		return fmt.Errorf("provider name cannot be empty")
 	}
 
-	capabilities := provider.Capabilities()
 	if len(capabilities) == 0 {
 		return fmt.Errorf("provider %s must support at least one capability", name)
 	}
@@ -90,6 +150,16 @@ func (r *providerRegistry) Register(provider interfaces.Provider) error {
 		return fmt.Errorf("invalid capabilities for provider %s: %w", name, err)
 	}
 
+	// Validate the requested subset against what the provider actually supports
+	for _, capability := range capabilities {
+		if !r.hasCapability(provider, capability) {
+			return fmt.Errorf("provider %s does not support capability %s", name, capability)
+		}
+	}
+
+	// Copy to prevent external modifications, dropping repeated capabilities
+	capabilities = uniqueCapabilities(capabilities)
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -103,8 +173,9 @@ func (r *providerRegistry) Register(provider interfaces.Provider) error {
 
 	// Update capability index
 	for _, capability := range capabilities {
-		r.capabilityIndex[capability] = append(r.capabilityIndex[capability], name)
+		r.addToCapabilityIndex(capability, name)
 	}
+	r.registeredCapabilities[name] = capabilities
 
 	// Initialize provider info
 	info := models.NewProviderInfo(name, models.ProviderType(name), convertCapabilities(capabilities))
@@ -129,8 +200,8 @@ func (r *providerRegistry) Get(name string, capability types.Capability) (interf
 		return nil, fmt.Errorf("provider %s not found", name)
 	}
 
-	// Verify the provider supports the requested capability
-	if !r.hasCapability(provider, capability) {
+	// Verify the provider is registered for the requested capability
+	if !r.isRegisteredFor(name, capability) {
 		return nil, fmt.Errorf("provider %s does not support capability %s", name, capability)
 	}
 
@@ -144,6 +215,33 @@ func (r *providerRegistry) Get(name string, capability types.Capability) (interf
 	return provider, nil
 }
 
+// GetHealthy retrieves a provider like Get, probing its health first if it has never been checked
+func (r *providerRegistry) GetHealthy(ctx context.Context, name string, capability types.Capability) (interfaces.Provider, error) {
+	provider, err := r.Get(name, capability)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	status := r.healthStatus[name]
+	r.mu.RUnlock()
+
+	if status != models.HealthStatusUnknown {
+		return provider, nil
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	if err := provider.HealthCheck(probeCtx); err != nil {
+		r.recordHealth(name, err)
+		return nil, fmt.Errorf("provider %s failed health check: %w", name, err)
+	}
+	r.recordHealth(name, nil)
+
+	return provider, nil
+}
+
 // List returns all providers that support a given capability
 func (r *providerRegistry) List(capability types.Capability) []interfaces.Provider {
 	r.mu.RLock()
@@ -180,20 +278,53 @@ func (r *providerRegistry) Unregister(name string) error {
 	}
 
 	// Remove from capability index
-	capabilities := provider.Capabilities()
+	capabilities := r.registeredCapabilities[name]
 	for _, capability := range capabilities {
 		r.removeFromCapabilityIndex(capability, name)
 	}
 
 	// Remove from maps
 	delete(r.providers, name)
+	delete(r.registeredCapabilities, name)
 	delete(r.providerInfo, name)
 	delete(r.healthStatus, name)
 	delete(r.lastHealthCheck, name)
+	r.metrics.RemoveMetrics(name)
 
 	return nil
 }
 
+// CloseAll closes every registered provider and clears the registry, joining any close errors.
+// Providers are removed before they are closed, so new lookups fail with "not found" while
+// callers still holding a provider see its own closed-state errors.
+func (r *providerRegistry) CloseAll() error {
+	r.mu.Lock()
+	providers := r.providers
+	r.providers = make(map[string]interfaces.Provider)
+	r.capabilityIndex = make(map[types.Capability][]string)
+	r.registeredCapabilities = make(map[string][]types.Capability)
+	r.providerInfo = make(map[string]*models.ProviderInfo)
+	r.healthStatus = make(map[string]models.HealthStatus)
+	r.lastHealthCheck = make(map[string]time.Time)
+	r.mu.Unlock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		r.metrics.RemoveMetrics(name)
+		if err := providers[name].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close provider %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // GetProviderInfo returns metadata about a provider
 func (r *providerRegistry) GetProviderInfo(name string) (*models.ProviderInfo, error) {
 	r.mu.RLock()
@@ -247,22 +378,7 @@ func (r *providerRegistry) HealthCheck(ctx context.Context) map[string]error {
 			resultsMu.Unlock()
 
 			// Update health status
-			r.mu.Lock()
-			r.lastHealthCheck[n] = time.Now()
-			if err != nil {
-				r.healthStatus[n] = models.HealthStatusUnhealthy
-				if info, ok := r.providerInfo[n]; ok {
-					info.UpdateHealthStatus(models.HealthStatusUnhealthy)
-					info.Available = false
-				}
-			} else {
-				r.healthStatus[n] = models.HealthStatusHealthy
-				if info, ok := r.providerInfo[n]; ok {
-					info.UpdateHealthStatus(models.HealthStatusHealthy)
-					info.Available = true
-				}
-			}
-			r.mu.Unlock()
+			r.recordHealth(n, err)
 		}(name, provider)
 	}
 
@@ -270,6 +386,32 @@ func (r *providerRegistry) HealthCheck(ctx context.Context) map[string]error {
 	return results
 }
 
+// recordHealth updates the health status of a provider from a health check result
+func (r *providerRegistry) recordHealth(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Skip providers unregistered while the check was running
+	if _, exists := r.providers[name]; !exists {
+		return
+	}
+
+	r.lastHealthCheck[name] = time.Now()
+	if err != nil {
+		r.healthStatus[name] = models.HealthStatusUnhealthy
+		if info, ok := r.providerInfo[name]; ok {
+			info.UpdateHealthStatus(models.HealthStatusUnhealthy)
+			info.Available = false
+		}
+	} else {
+		r.healthStatus[name] = models.HealthStatusHealthy
+		if info, ok := r.providerInfo[name]; ok {
+			info.UpdateHealthStatus(models.HealthStatusHealthy)
+			info.Available = true
+		}
+	}
+}
+
 // GetAvailableProviders returns all healthy providers for a capability
 func (r *providerRegistry) GetAvailableProviders(capability types.Capability) []interfaces.Provider {
 	r.mu.RLock()
@@ -299,6 +441,143 @@ func (r *providerRegistry) GetAvailableProviders(capability types.Capability) []
 	return providers
 }
 
+// GetChatService retrieves a provider's chat service with metrics recording
+func (r *providerRegistry) GetChatService(name string) (interfaces.ChatService, error) {
+	provider, err := r.Get(name, types.CapabilityChat)
+	if err != nil {
+		return nil, err
+	}
+
+	service, ok := provider.(interfaces.ChatService)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not implement the chat service", name)
+	}
+
+	return &meteredChatService{ChatService: providers.NewContextChatService(name, service), name: name, metrics: r.metrics}, nil
+}
+
+// GetEmbeddingService retrieves a provider's embedding service with metrics recording
+func (r *providerRegistry) GetEmbeddingService(name string) (interfaces.EmbeddingService, error) {
+	provider, err := r.Get(name, types.CapabilityEmbedding)
+	if err != nil {
+		return nil, err
+	}
+
+	service, ok := provider.(interfaces.EmbeddingService)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not implement the embedding service", name)
+	}
+
+	return &meteredEmbeddingService{EmbeddingService: providers.NewContextEmbeddingService(name, service), name: name, metrics: r.metrics}, nil
+}
+
+// GetSTTService retrieves a provider's STT service with metrics recording
+func (r *providerRegistry) GetSTTService(name string) (interfaces.STTService, error) {
+	provider, err := r.Get(name, types.CapabilitySTT)
+	if err != nil {
+		return nil, err
+	}
+
+	service, ok := provider.(interfaces.STTService)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not implement the STT service", name)
+	}
+
+	return &meteredSTTService{STTService: providers.NewContextSTTService(name, service), name: name, metrics: r.metrics}, nil
+}
+
+// GetTTSService retrieves a provider's TTS service with metrics recording
+func (r *providerRegistry) GetTTSService(name string) (interfaces.TTSService, error) {
+	provider, err := r.Get(name, types.CapabilityTTS)
+	if err != nil {
+		return nil, err
+	}
+
+	service, ok := provider.(interfaces.TTSService)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not implement the TTS service", name)
+	}
+
+	return &meteredTTSService{TTSService: providers.NewContextTTSService(name, service), name: name, metrics: r.metrics}, nil
+}
+
+// EstimateRequestCost estimates the cost of a request to a provider's model from its pricing.
+// Unpriced models cost zero in models.UnknownCurrency.
+func (r *providerRegistry) EstimateRequestCost(providerName, modelID string, inputTokens, outputTokens int) (float64, string, error) {
+	info, err := r.modelInfo(providerName)
+	if err != nil {
+		return 0, "", err
+	}
+
+	model, ok := info.FindModel(modelID)
+	if !ok {
+		return 0, "", fmt.Errorf("model %s not found for provider %s", modelID, providerName)
+	}
+
+	cost, currency := model.EstimateCost(inputTokens, outputTokens)
+	return cost, currency, nil
+}
+
+// SelectCheapestProvider returns the healthy provider whose priced model for the capability has the
+// lowest estimated cost for estTokens tokens, split evenly between input and output, together with
+// that model's ID. The model must be requested explicitly for the estimate to hold, since a provider's
+// default model may cost more. Providers without priced models are skipped, and nil is returned when
+// none is priced. Costs are compared as-is, so pricing is expected to share a currency.
+func (r *providerRegistry) SelectCheapestProvider(capability types.Capability, estTokens int) (interfaces.Provider, string) {
+	inputTokens := estTokens / 2
+	outputTokens := estTokens - inputTokens
+
+	var cheapest interfaces.Provider
+	var cheapestModel string
+	var lowest float64
+	for _, provider := range r.GetAvailableProviders(capability) {
+		info, err := r.modelInfo(provider.Name())
+		if err != nil {
+			continue
+		}
+
+		for _, model := range info.Models[string(capability)] {
+			if model.Pricing == nil {
+				continue
+			}
+			cost, _ := model.EstimateCost(inputTokens, outputTokens)
+			if cheapest == nil || cost < lowest {
+				cheapest, cheapestModel, lowest = provider, model.ID, cost
+			}
+		}
+	}
+
+	return cheapest, cheapestModel
+}
+
+// modelInfo returns the provider info listing a provider's models. The registry's own info only
+// tracks capabilities and health, so the provider's GetProviderInfo is used when it has one.
+func (r *providerRegistry) modelInfo(name string) (*models.ProviderInfo, error) {
+	r.mu.RLock()
+	provider, exists := r.providers[name]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("provider %s not found", name)
+	}
+	if describer, ok := provider.(interface{ GetProviderInfo() *models.ProviderInfo }); ok {
+		if info := describer.GetProviderInfo(); info != nil {
+			return info, nil
+		}
+	}
+	return r.GetProviderInfo(name)
+}
+
+// GetMetrics returns the recorded metrics for a provider and capability
+func (r *providerRegistry) GetMetrics(name string, capability types.Capability) (*models.ProviderMetrics, error) {
+	return r.metrics.GetMetrics(name, capability)
+}
+
+// ResetMetrics zeroes the recorded metrics for a provider
+func (r *providerRegistry) ResetMetrics(name string) {
+	r.metrics.ResetMetrics(name)
+}
+
 // validateCapabilities validates that all capabilities are valid
 func (r *providerRegistry) validateCapabilities(capabilities []types.Capability) error {
 	validCapabilities := map[types.Capability]bool{
@@ -328,20 +607,32 @@ func (r *providerRegistry) hasCapability(provider interfaces.Provider, capabilit
 	return false
 }
 
-// removeFromCapabilityIndex removes a provider from the capability index
+// isRegisteredFor checks if a provider was registered for a specific capability
+func (r *providerRegistry) isRegisteredFor(name string, capability types.Capability) bool {
+	for _, cap := range r.registeredCapabilities[name] {
+		if cap == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// addToCapabilityIndex adds a provider to the capability index unless it is already listed
+func (r *providerRegistry) addToCapabilityIndex(capability types.Capability, name string) {
+	if slices.Contains(r.capabilityIndex[capability], name) {
+		return
+	}
+	r.capabilityIndex[capability] = append(r.capabilityIndex[capability], name)
+}
+
+// removeFromCapabilityIndex removes every occurrence of a provider from the capability index
 func (r *providerRegistry) removeFromCapabilityIndex(capability types.Capability, name string) {
 	names, exists := r.capabilityIndex[capability]
 	if !exists {
 		return
 	}
 
-	// Find and remove the provider name
-	for i, n := range names {
-		if n == name {
-			r.capabilityIndex[capability] = append(names[:i], names[i+1:]...)
-			break
-		}
-	}
+	r.capabilityIndex[capability] = slices.DeleteFunc(names, func(n string) bool { return n == name })
 
 	// Clean up empty capability entries
 	if len(r.capabilityIndex[capability]) == 0 {
@@ -349,6 +640,52 @@ func (r *providerRegistry) removeFromCapabilityIndex(capability types.Capability
 	}
 }
 
+// CheckConsistency verifies that every indexed provider is registered for its capability exactly
+// once and that every registered capability is indexed
+func (r *providerRegistry) CheckConsistency() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var errs []error
+	for capability, names := range r.capabilityIndex {
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if seen[name] {
+				errs = append(errs, fmt.Errorf("provider %s is indexed more than once for %s", name, capability))
+				continue
+			}
+			seen[name] = true
+
+			if _, exists := r.providers[name]; !exists {
+				errs = append(errs, fmt.Errorf("provider %s is indexed for %s but not registered", name, capability))
+			} else if !r.isRegisteredFor(name, capability) {
+				errs = append(errs, fmt.Errorf("provider %s is indexed for %s but not registered for it", name, capability))
+			}
+		}
+	}
+
+	for name, capabilities := range r.registeredCapabilities {
+		for _, capability := range capabilities {
+			if !slices.Contains(r.capabilityIndex[capability], name) {
+				errs = append(errs, fmt.Errorf("provider %s is registered for %s but not indexed", name, capability))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// uniqueCapabilities returns a copy of capabilities without repeats, keeping the first occurrence
+func uniqueCapabilities(capabilities []types.Capability) []types.Capability {
+	unique := make([]types.Capability, 0, len(capabilities))
+	for _, capability := range capabilities {
+		if !slices.Contains(unique, capability) {
+			unique = append(unique, capability)
+		}
+	}
+	return unique
+}
+
 // convertCapabilities converts interface capabilities to model capabilities
 func convertCapabilities(caps []types.Capability) []models.Capability {
 	result := make([]models.Capability, len(caps))
@@ -0,0 +1,225 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/mock"
+	"github.com/creastat/common-go/pkg/providers/registry"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// chatModel is a chat model priced per 1K input and output tokens
+func chatModel(id string, inputCost, outputCost float64) models.Model {
+	return models.Model{
+		ID:         id,
+		Capability: models.CapabilityChat,
+		Pricing:    &models.ModelPricing{InputCost: inputCost, OutputCost: outputCost, Currency: "USD"},
+	}
+}
+
+func TestSelectCheapestProvider(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	register := func(config mock.Config) {
+		t.Helper()
+		if _, err := mock.Register(reg, config); err != nil {
+			t.Fatalf("Register(%s): %v", config.Name, err)
+		}
+	}
+
+	register(mock.Config{Name: "premium", Models: []models.Model{chatModel("premium-large", 10, 30)}})
+	register(mock.Config{Name: "mixed", Models: []models.Model{chatModel("mixed-large", 5, 15), chatModel("mixed-mini", 0.1, 0.4)}})
+	register(mock.Config{Name: "unpriced", Models: []models.Model{{ID: "free", Capability: models.CapabilityChat}}})
+	register(mock.Config{Name: "broken", Models: []models.Model{chatModel("broken-mini", 0.01, 0.01)}, HealthErr: errors.New("down")})
+	reg.HealthCheck(context.Background())
+
+	provider, model := reg.SelectCheapestProvider(types.CapabilityChat, 1000)
+	if provider == nil || provider.Name() != "mixed" || model != "mixed-mini" {
+		t.Fatalf("expected mixed/mixed-mini, got %v/%q", provider, model)
+	}
+
+	if provider, model := reg.SelectCheapestProvider(types.CapabilityEmbedding, 1000); provider != nil || model != "" {
+		t.Errorf("expected no provider without priced embedding models, got %v/%q", provider, model)
+	}
+}
+
+func TestEstimateRequestCost(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	if _, err := mock.Register(reg, mock.Config{Name: "priced", Models: []models.Model{chatModel("mini", 0.5, 1.5)}}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	cost, currency, err := reg.EstimateRequestCost("priced", "mini", 2000, 1000)
+	if err != nil {
+		t.Fatalf("EstimateRequestCost: %v", err)
+	}
+	if cost != 2.5 || currency != "USD" {
+		t.Errorf("got %v %s, want 2.5 USD", cost, currency)
+	}
+
+	if _, _, err := reg.EstimateRequestCost("priced", "unknown", 1, 1); err == nil {
+		t.Error("expected an error for an unknown model")
+	}
+}
+
+func TestCapabilityIndexHasNoDuplicates(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	chatAndTTS := []types.Capability{types.CapabilityChat, types.CapabilityTTS}
+	provider := mock.NewMockProvider(mock.Config{Name: "mock", Capabilities: chatAndTTS})
+	other := mock.NewMockProvider(mock.Config{Name: "other", Capabilities: chatAndTTS})
+
+	// Repeated capabilities in one registration
+	if err := reg.RegisterWithCapabilities(provider, []types.Capability{types.CapabilityChat, types.CapabilityChat, types.CapabilityTTS}); err != nil {
+		t.Fatalf("RegisterWithCapabilities: %v", err)
+	}
+	// A second registration under the same name
+	if err := reg.Register(provider); err == nil {
+		t.Error("expected registering the same name twice to fail")
+	}
+	// Unregistering and registering again with overlapping capabilities
+	if err := reg.Unregister("mock"); err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if err := reg.Register(provider); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := reg.Register(other); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	for _, capability := range chatAndTTS {
+		counts := make(map[string]int)
+		for _, p := range reg.List(capability) {
+			counts[p.Name()]++
+		}
+		for _, p := range reg.GetAvailableProviders(capability) {
+			counts[p.Name()+" available"]++
+		}
+		for name, count := range counts {
+			if count != 1 {
+				t.Errorf("%s: %s listed %d times", capability, name, count)
+			}
+		}
+		if len(counts) != 4 {
+			t.Errorf("%s: expected mock and other listed and available, got %v", capability, counts)
+		}
+	}
+
+	if err := reg.CheckConsistency(); err != nil {
+		t.Errorf("CheckConsistency: %v", err)
+	}
+}
+
+func TestCapabilitySubsetIsRoutableOnlyForRegisteredCapabilities(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	if _, err := mock.Register(reg, mock.Config{Name: "mock"}, types.CapabilityChat, types.CapabilitySTT); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	for _, capability := range []types.Capability{types.CapabilityChat, types.CapabilitySTT} {
+		if _, err := reg.Get("mock", capability); err != nil {
+			t.Errorf("Get(%s): %v", capability, err)
+		}
+		if providers := reg.List(capability); len(providers) != 1 {
+			t.Errorf("List(%s): expected the provider, got %d providers", capability, len(providers))
+		}
+	}
+
+	for _, capability := range []types.Capability{types.CapabilityEmbedding, types.CapabilityTTS} {
+		if _, err := reg.Get("mock", capability); err == nil {
+			t.Errorf("Get(%s): expected an unregistered capability to fail", capability)
+		}
+		if providers := reg.List(capability); len(providers) != 0 {
+			t.Errorf("List(%s): expected no providers, got %d", capability, len(providers))
+		}
+	}
+	if _, err := reg.GetTTSService("mock"); err == nil {
+		t.Error("GetTTSService: expected an unregistered capability to fail")
+	}
+
+	// A capability the provider does not implement cannot be registered
+	chatOnly := mock.NewMockProvider(mock.Config{Name: "chat-only", Capabilities: []types.Capability{types.CapabilityChat}})
+	if err := reg.RegisterWithCapabilities(chatOnly, []types.Capability{types.CapabilityTTS}); err == nil {
+		t.Error("expected registering an unsupported capability to fail")
+	}
+}
+
+func TestGetHealthySkipsFailingProvider(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewProviderRegistry()
+	healthy, err := mock.Register(reg, mock.Config{Name: "healthy"})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	failing, err := mock.Register(reg, mock.Config{Name: "failing", HealthErr: errors.New("down")})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	// Each provider is probed once, on first use
+	for range 2 {
+		if provider, err := reg.GetHealthy(ctx, "healthy", types.CapabilityChat); err != nil || provider != healthy {
+			t.Errorf("expected the healthy provider, got %v, %v", provider, err)
+		}
+		if provider, err := reg.GetHealthy(ctx, "failing", types.CapabilityChat); err == nil {
+			t.Errorf("expected the failing provider to be skipped, got %v", provider)
+		}
+	}
+	if healthy.Calls("HealthCheck") != 1 || failing.Calls("HealthCheck") != 1 {
+		t.Errorf("expected one probe each, got %d and %d", healthy.Calls("HealthCheck"), failing.Calls("HealthCheck"))
+	}
+	if available := reg.GetAvailableProviders(types.CapabilityChat); len(available) != 1 || available[0] != healthy {
+		t.Errorf("expected only the healthy provider to be available, got %v", available)
+	}
+
+	// A recovered provider is routable again after the next health check
+	failing.SetHealthError(nil)
+	reg.HealthCheck(ctx)
+	if provider, err := reg.GetHealthy(ctx, "failing", types.CapabilityChat); err != nil || provider != failing {
+		t.Errorf("expected the recovered provider, got %v, %v", provider, err)
+	}
+}
+
+func TestCloseAllClosesEveryProvider(t *testing.T) {
+	reg := registry.NewProviderRegistry()
+	errA := errors.New("a: connection reset")
+	errC := errors.New("c: already closed")
+
+	var providers []*mock.MockProvider
+	for _, config := range []mock.Config{
+		{Name: "a", CloseErr: errA},
+		{Name: "b"},
+		{Name: "c", CloseErr: errC},
+	} {
+		provider, err := mock.Register(reg, config)
+		if err != nil {
+			t.Fatalf("Register(%s): %v", config.Name, err)
+		}
+		providers = append(providers, provider)
+	}
+
+	err := reg.CloseAll()
+
+	// A failing Close does not stop the remaining providers from closing
+	for _, provider := range providers {
+		if provider.Calls("Close") != 1 {
+			t.Errorf("%s: expected Close to be called once, got %d", provider.Name(), provider.Calls("Close"))
+		}
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errC) {
+		t.Errorf("expected both close errors, got %v", err)
+	}
+	if strings.Contains(err.Error(), "provider b") {
+		t.Errorf("the provider that closed cleanly is reported: %v", err)
+	}
+
+	if remaining := reg.List(types.CapabilityChat); len(remaining) != 0 {
+		t.Errorf("expected an empty registry, got %v", remaining)
+	}
+	if err := reg.CloseAll(); err != nil {
+		t.Errorf("closing an empty registry: %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package registry
+
+import (
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// SupportsStreaming reports whether a provider or service can stream the given capability.
+// Services that do not describe their capabilities are assumed to stream.
+func SupportsStreaming(service any, capability types.Capability) bool {
+	return providers.SupportsStreaming(service, capability)
+}
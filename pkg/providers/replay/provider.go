@@ -0,0 +1,365 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// Config holds the recorded sessions a ReplayProvider plays back
+type Config struct {
+	// Name is the provider name; defaults to "replay"
+	Name string
+
+	// STTSession is replayed by every STT call; STT is offered only when it is set
+	STTSession []Frame
+
+	// TTSSession is replayed by every TTS call; TTS is offered only when it is set
+	TTSSession []Frame
+}
+
+// ReplayProvider plays recorded sessions back without network access, so regression tests
+// see exactly what the real provider returned. Received frames are replayed in order without
+// their original timing; sent audio and text are accepted and ignored.
+type ReplayProvider struct {
+	config Config
+}
+
+var _ interfaces.SpeechProvider = (*ReplayProvider)(nil)
+
+// NewReplayProvider creates a provider replaying the given sessions
+func NewReplayProvider(config Config) *ReplayProvider {
+	if config.Name == "" {
+		config.Name = "replay"
+	}
+	return &ReplayProvider{config: config}
+}
+
+// Name returns the provider name
+func (p *ReplayProvider) Name() string {
+	return p.config.Name
+}
+
+// Type returns the provider type
+func (p *ReplayProvider) Type() models.ProviderType {
+	return models.ProviderTypeSpeech
+}
+
+// Capabilities returns the capabilities that have a recorded session
+func (p *ReplayProvider) Capabilities() []types.Capability {
+	var capabilities []types.Capability
+	if p.config.STTSession != nil {
+		capabilities = append(capabilities, types.CapabilitySTT)
+	}
+	if p.config.TTSSession != nil {
+		capabilities = append(capabilities, types.CapabilityTTS)
+	}
+	return capabilities
+}
+
+// Initialize does nothing; the recorded sessions need no setup
+func (p *ReplayProvider) Initialize(ctx context.Context, config models.ProviderConfig) error {
+	return nil
+}
+
+// Close does nothing
+func (p *ReplayProvider) Close() error {
+	return nil
+}
+
+// HealthCheck always succeeds
+func (p *ReplayProvider) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// Transcribe returns the final transcripts of the recorded STT session joined by spaces
+func (p *ReplayProvider) Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error) {
+	if p.config.STTSession == nil {
+		return "", fmt.Errorf("no recorded STT session")
+	}
+
+	var transcripts []string
+	for _, frame := range receivedFrames(p.config.STTSession) {
+		if err := frame.Err(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", err
+		}
+		if frame.Result != nil && frame.Result.IsFinal && frame.Result.Text != "" {
+			transcripts = append(transcripts, frame.Result.Text)
+		}
+	}
+	return strings.Join(transcripts, " "), nil
+}
+
+// StreamTranscribe drains the audio stream and then emits the recorded transcript
+func (p *ReplayProvider) StreamTranscribe(ctx context.Context, audioStream <-chan []byte, options map[string]any) (<-chan string, <-chan error) {
+	textCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(textCh)
+		defer close(errCh)
+
+		for {
+			select {
+			case _, ok := <-audioStream:
+				if !ok {
+					text, err := p.Transcribe(ctx, nil, options)
+					if err != nil {
+						errCh <- err
+						return
+					}
+					textCh <- text
+					return
+				}
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return textCh, errCh
+}
+
+// NewSTTClient creates a client replaying the recorded STT session
+func (p *ReplayProvider) NewSTTClient(ctx context.Context, config models.STTConfig) (interfaces.STTClient, error) {
+	if p.config.STTSession == nil {
+		return nil, fmt.Errorf("no recorded STT session")
+	}
+	return &sttClient{frameQueue: frameQueue{frames: receivedFrames(p.config.STTSession)}, streamStart: time.Now()}, nil
+}
+
+// SupportedLanguages returns nil; a recording covers whatever language it was made in
+func (p *ReplayProvider) SupportedLanguages() []string {
+	return nil
+}
+
+// Synthesize returns the audio of the recorded TTS session concatenated
+func (p *ReplayProvider) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
+	if p.config.TTSSession == nil {
+		return nil, fmt.Errorf("no recorded TTS session")
+	}
+
+	var audio []byte
+	for _, frame := range receivedFrames(p.config.TTSSession) {
+		if err := frame.Err(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		audio = append(audio, frame.Audio...)
+	}
+	return audio, nil
+}
+
+// StreamSynthesize drains the text stream and then emits the recorded audio chunks
+func (p *ReplayProvider) StreamSynthesize(ctx context.Context, textStream <-chan string, config models.TTSConfig) (<-chan []byte, <-chan error) {
+	audioCh := make(chan []byte)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(audioCh)
+		defer close(errCh)
+
+		client, err := p.NewTTSClient(ctx, config)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		for {
+			select {
+			case _, ok := <-textStream:
+				if ok {
+					continue
+				}
+				for {
+					audio, err := client.Receive(ctx)
+					if err != nil {
+						if err != io.EOF {
+							errCh <- err
+						}
+						return
+					}
+					select {
+					case audioCh <- audio:
+					case <-ctx.Done():
+						errCh <- ctx.Err()
+						return
+					}
+				}
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return audioCh, errCh
+}
+
+// NewTTSClient creates a client replaying the recorded TTS session
+func (p *ReplayProvider) NewTTSClient(ctx context.Context, config models.TTSConfig) (interfaces.TTSClient, error) {
+	if p.config.TTSSession == nil {
+		return nil, fmt.Errorf("no recorded TTS session")
+	}
+	return &ttsClient{frameQueue: frameQueue{frames: receivedFrames(p.config.TTSSession)}}, nil
+}
+
+// GetVoices returns no voices; recordings do not capture the catalog
+func (p *ReplayProvider) GetVoices(ctx context.Context) ([]models.Voice, error) {
+	return nil, nil
+}
+
+// GetVoicesByLanguage returns no voices; recordings do not capture the catalog
+func (p *ReplayProvider) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
+	return nil, nil
+}
+
+// frameQueue hands out recorded frames in order
+type frameQueue struct {
+	mu     sync.Mutex
+	frames []Frame
+	closed bool
+}
+
+// next returns the next frame, or an end-of-stream frame once all were delivered
+func (q *frameQueue) next(ctx context.Context) (Frame, error) {
+	if err := ctx.Err(); err != nil {
+		return Frame{}, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.frames) == 0 {
+		return Frame{EOF: true}, nil
+	}
+	frame := q.frames[0]
+	q.frames = q.frames[1:]
+	return frame, nil
+}
+
+// checkOpen returns an error once the client is closed
+func (q *frameQueue) checkOpen(kind string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return fmt.Errorf("%s client is closed", kind)
+	}
+	return nil
+}
+
+// close marks the client closed
+func (q *frameQueue) close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	return nil
+}
+
+// sttClient replays recorded STT results
+type sttClient struct {
+	frameQueue
+	streamStart time.Time
+}
+
+// Send accepts and ignores the audio chunk
+func (c *sttClient) Send(ctx context.Context, audioData []byte) error {
+	return c.checkOpen("STT")
+}
+
+// Receive returns the next recorded result or error, or io.EOF at the end of the session
+func (c *sttClient) Receive(ctx context.Context) (*models.STTResult, error) {
+	for {
+		frame, err := c.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := frame.Err(); err != nil {
+			return nil, err
+		}
+		if frame.Result != nil {
+			return frame.Result, nil
+		}
+	}
+}
+
+// StreamTo invokes handler for each recorded result until end of session
+func (c *sttClient) StreamTo(ctx context.Context, handler func(*models.STTResult) error) error {
+	for {
+		result, err := c.Receive(ctx)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := handler(result); err != nil {
+			return err
+		}
+	}
+}
+
+// StreamStartTime returns when the client was created
+func (c *sttClient) StreamStartTime() time.Time {
+	return c.streamStart
+}
+
+// Close closes the client
+func (c *sttClient) Close() error {
+	return c.close()
+}
+
+// ttsClient replays recorded TTS audio
+type ttsClient struct {
+	frameQueue
+}
+
+// Send accepts and ignores the text
+func (c *ttsClient) Send(ctx context.Context, text string) error {
+	return c.checkOpen("TTS")
+}
+
+// Receive returns the next recorded audio chunk or error, or io.EOF at the end of the session
+func (c *ttsClient) Receive(ctx context.Context) ([]byte, error) {
+	for {
+		frame, err := c.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := frame.Err(); err != nil {
+			return nil, err
+		}
+		if frame.Audio != nil {
+			return frame.Audio, nil
+		}
+	}
+}
+
+// GetVoices returns no voices
+func (c *ttsClient) GetVoices(ctx context.Context) ([]models.Voice, error) {
+	return nil, nil
+}
+
+// Close closes the client; the remaining recorded audio can still be received
+func (c *ttsClient) Close() error {
+	return c.close()
+}
+
+var (
+	_ interfaces.STTClient = (*sttClient)(nil)
+	_ interfaces.TTSClient = (*ttsClient)(nil)
+)
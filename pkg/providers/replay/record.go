@@ -0,0 +1,209 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// sessionSeq keeps session file names unique within a process
+var sessionSeq atomic.Int64
+
+// RecordSTTClient wraps client so every audio chunk sent and every result received is recorded.
+// Closing the client records a closed frame and closes the recorder.
+func RecordSTTClient(client interfaces.STTClient, recorder *Recorder) interfaces.STTClient {
+	return &recordingSTTClient{STTClient: client, session: session{recorder: recorder}}
+}
+
+// RecordTTSClient wraps client so every text sent and every audio chunk received is recorded.
+// Closing the client records a closed frame and closes the recorder, so audio received after
+// Close is not recorded.
+func RecordTTSClient(client interfaces.TTSClient, recorder *Recorder) interfaces.TTSClient {
+	return &recordingTTSClient{TTSClient: client, session: session{recorder: recorder}}
+}
+
+// RecordSTTService wraps service so each client from NewSTTClient records its session to a new file in dir
+func RecordSTTService(service interfaces.STTService, dir string) interfaces.STTService {
+	return &recordingSTTService{STTService: service, dir: dir}
+}
+
+// RecordTTSService wraps service so each client from NewTTSClient records its session to a new file in dir
+func RecordTTSService(service interfaces.TTSService, dir string) interfaces.TTSService {
+	return &recordingTTSService{TTSService: service, dir: dir}
+}
+
+// recordingSTTService records the sessions of its STT clients
+type recordingSTTService struct {
+	interfaces.STTService
+	dir string
+}
+
+// NewSTTClient creates a client whose session is recorded
+func (s *recordingSTTService) NewSTTClient(ctx context.Context, config models.STTConfig) (interfaces.STTClient, error) {
+	client, err := s.STTService.NewSTTClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, err := CreateRecorder(sessionPath(s.dir, "stt"))
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return RecordSTTClient(client, recorder), nil
+}
+
+// recordingTTSService records the sessions of its TTS clients
+type recordingTTSService struct {
+	interfaces.TTSService
+	dir string
+}
+
+// NewTTSClient creates a client whose session is recorded
+func (s *recordingTTSService) NewTTSClient(ctx context.Context, config models.TTSConfig) (interfaces.TTSClient, error) {
+	client, err := s.TTSService.NewTTSClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, err := CreateRecorder(sessionPath(s.dir, "tts"))
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return RecordTTSClient(client, recorder), nil
+}
+
+// session records the frames of one client and closes its recorder when the client is closed
+type session struct {
+	recorder *Recorder
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// record appends a frame to the session
+func (s *session) record(frame Frame) error {
+	return s.recorder.Record(frame)
+}
+
+// end records the terminal error of the stream
+func (s *session) end(err error) {
+	s.recorder.Record(errorFrame(err))
+}
+
+// close records that the client was closed and closes the recorder; later calls return the first result
+func (s *session) close() error {
+	s.closeOnce.Do(func() {
+		s.closeErr = s.recorder.Record(Frame{Direction: DirectionSend, Closed: true})
+		if err := s.recorder.Close(); s.closeErr == nil {
+			s.closeErr = err
+		}
+	})
+	return s.closeErr
+}
+
+// recordingSTTClient records the frames of an STT session
+type recordingSTTClient struct {
+	interfaces.STTClient
+	session session
+}
+
+// Send records the audio chunk and forwards it
+func (c *recordingSTTClient) Send(ctx context.Context, audioData []byte) error {
+	if err := c.session.record(Frame{Direction: DirectionSend, Audio: audioData}); err != nil {
+		return err
+	}
+	return c.STTClient.Send(ctx, audioData)
+}
+
+// Receive forwards to the client and records the result or error
+func (c *recordingSTTClient) Receive(ctx context.Context) (*models.STTResult, error) {
+	result, err := c.STTClient.Receive(ctx)
+	if err != nil {
+		c.session.end(err)
+		return nil, err
+	}
+	c.session.record(Frame{Direction: DirectionReceive, Result: result})
+	return result, nil
+}
+
+// StreamTo records each result before handing it to handler, and the terminal error
+func (c *recordingSTTClient) StreamTo(ctx context.Context, handler func(*models.STTResult) error) error {
+	err := c.STTClient.StreamTo(ctx, func(result *models.STTResult) error {
+		c.session.record(Frame{Direction: DirectionReceive, Result: result})
+		return handler(result)
+	})
+	if err != nil {
+		c.session.end(err)
+	} else {
+		c.session.end(io.EOF)
+	}
+	return err
+}
+
+// Close closes the client and the recorder
+func (c *recordingSTTClient) Close() error {
+	err := c.STTClient.Close()
+	if closeErr := c.session.close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// recordingTTSClient records the frames of a TTS session
+type recordingTTSClient struct {
+	interfaces.TTSClient
+	session session
+}
+
+// Send records the text and forwards it
+func (c *recordingTTSClient) Send(ctx context.Context, text string) error {
+	if err := c.session.record(Frame{Direction: DirectionSend, Text: text}); err != nil {
+		return err
+	}
+	return c.TTSClient.Send(ctx, text)
+}
+
+// Receive forwards to the client and records the audio or error
+func (c *recordingTTSClient) Receive(ctx context.Context) ([]byte, error) {
+	audio, err := c.TTSClient.Receive(ctx)
+	if err != nil {
+		c.session.end(err)
+		return nil, err
+	}
+	c.session.record(Frame{Direction: DirectionReceive, Audio: audio})
+	return audio, nil
+}
+
+// Close closes the client and the recorder
+func (c *recordingTTSClient) Close() error {
+	err := c.TTSClient.Close()
+	if closeErr := c.session.close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// errorFrame records a receive error; io.EOF is recorded as end of stream
+func errorFrame(err error) Frame {
+	if err == io.EOF {
+		return Frame{Direction: DirectionReceive, EOF: true}
+	}
+	return Frame{Direction: DirectionReceive, Error: err.Error()}
+}
+
+// sessionPath returns a new session file path in dir, such as stt-1760000000000000000-1.jsonl
+func sessionPath(dir, kind string) string {
+	name := fmt.Sprintf("%s-%d-%d.jsonl", kind, time.Now().UnixNano(), sessionSeq.Add(1))
+	return filepath.Join(dir, name)
+}
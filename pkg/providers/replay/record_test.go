@@ -0,0 +1,185 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/mock"
+)
+
+// loadOnlySession reads the single session file recorded in dir
+func loadOnlySession(t *testing.T, dir string) []Frame {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("expected one session file, got %v (%v)", paths, err)
+	}
+	frames, err := LoadSession(paths[0])
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	return frames
+}
+
+// checkClosedLast fails unless the session ends with the frame Close records
+func checkClosedLast(t *testing.T, frames []Frame) {
+	t.Helper()
+
+	if len(frames) == 0 || !frames[len(frames)-1].Closed {
+		t.Errorf("expected the session to end with a closed frame, got %+v", frames)
+	}
+}
+
+func TestRecordedSTTSessionReplays(t *testing.T) {
+	ctx := context.Background()
+	want := []*models.STTResult{
+		{Text: "hel", Confidence: 0.5},
+		{Text: "hello", Confidence: 0.9, IsFinal: true, Language: "en"},
+	}
+
+	dir := t.TempDir()
+	service := RecordSTTService(mock.NewMockProvider(mock.Config{STTResults: want}), dir)
+	client, err := service.NewSTTClient(ctx, models.STTConfig{})
+	if err != nil {
+		t.Fatalf("NewSTTClient: %v", err)
+	}
+	if err := client.Send(ctx, []byte{1, 2}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	recorded := receiveResults(t, client)
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	frames := loadOnlySession(t, dir)
+	if frames[0].Direction != DirectionSend || !bytes.Equal(frames[0].Audio, []byte{1, 2}) {
+		t.Errorf("expected the sent audio first, got %+v", frames[0])
+	}
+	checkClosedLast(t, frames)
+
+	replayed, err := NewReplayProvider(Config{STTSession: frames}).NewSTTClient(ctx, models.STTConfig{})
+	if err != nil {
+		t.Fatalf("NewSTTClient: %v", err)
+	}
+	if got := receiveResults(t, replayed); !reflect.DeepEqual(got, recorded) {
+		t.Errorf("replayed %+v, recorded %+v", got, recorded)
+	}
+
+	transcript, err := NewReplayProvider(Config{STTSession: frames}).Transcribe(ctx, nil, nil)
+	if err != nil || transcript != "hello" {
+		t.Errorf("Transcribe returned %q, %v", transcript, err)
+	}
+}
+
+// receiveResults drains an STT client until end of stream
+func receiveResults(t *testing.T, client interfaces.STTClient) []*models.STTResult {
+	t.Helper()
+
+	var results []*models.STTResult
+	for {
+		result, err := client.Receive(context.Background())
+		if err == io.EOF {
+			return results
+		}
+		if err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+		results = append(results, result)
+	}
+}
+
+func TestRecordedTTSSessionReplays(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	service := RecordTTSService(mock.NewMockProvider(mock.Config{Audio: []byte{7, 8, 9}}), dir)
+	client, err := service.NewTTSClient(ctx, models.TTSConfig{})
+	if err != nil {
+		t.Fatalf("NewTTSClient: %v", err)
+	}
+
+	var recorded [][]byte
+	for _, text := range []string{"one", "two"} {
+		if err := client.Send(ctx, text); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+		audio, err := client.Receive(ctx)
+		if err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+		recorded = append(recorded, audio)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	frames := loadOnlySession(t, dir)
+	checkClosedLast(t, frames)
+
+	replayed, err := NewReplayProvider(Config{TTSSession: frames}).NewTTSClient(ctx, models.TTSConfig{})
+	if err != nil {
+		t.Fatalf("NewTTSClient: %v", err)
+	}
+	var got [][]byte
+	for {
+		audio, err := replayed.Receive(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+		got = append(got, audio)
+	}
+	if !reflect.DeepEqual(got, recorded) {
+		t.Errorf("replayed %v, recorded %v", got, recorded)
+	}
+}
+
+func TestCloseReleasesUndrainedSession(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := CreateRecorder(path)
+	if err != nil {
+		t.Fatalf("CreateRecorder: %v", err)
+	}
+
+	provider := mock.NewMockProvider(mock.Config{Transcript: "never read"})
+	inner, err := provider.NewSTTClient(ctx, models.STTConfig{})
+	if err != nil {
+		t.Fatalf("NewSTTClient: %v", err)
+	}
+	client := RecordSTTClient(inner, recorder)
+
+	// Closed before the stream reached end of stream
+	for range 2 {
+		if err := client.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+
+	if err := recorder.Record(Frame{Direction: DirectionSend}); err == nil {
+		t.Error("expected the recorder to be closed")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	frames, err := ReadSession(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadSession: %v", err)
+	}
+	if len(frames) != 1 {
+		t.Fatalf("expected only the closed frame, got %+v", frames)
+	}
+	checkClosedLast(t, frames)
+}
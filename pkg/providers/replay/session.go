@@ -0,0 +1,149 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// Direction tells whether a frame went to the provider or came back from it
+type Direction string
+
+const (
+	DirectionSend    Direction = "send"
+	DirectionReceive Direction = "receive"
+)
+
+// Frame is one request or response of a recorded provider session
+type Frame struct {
+	Direction Direction         `json:"direction"`
+	OffsetMs  int64             `json:"offset_ms"` // Time since the session started
+	Audio     []byte            `json:"audio,omitempty"`
+	Text      string            `json:"text,omitempty"`
+	Result    *models.STTResult `json:"result,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	EOF       bool              `json:"eof,omitempty"`    // The provider reported end of stream
+	Closed    bool              `json:"closed,omitempty"` // The client closed the session
+}
+
+// Err returns the error a received frame carries: io.EOF for end of stream, nil for data
+func (f Frame) Err() error {
+	switch {
+	case f.EOF:
+		return io.EOF
+	case f.Error != "":
+		return errors.New(f.Error)
+	default:
+		return nil
+	}
+}
+
+// Recorder writes the frames of one provider session as JSON lines. It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+	start   time.Time
+	closed  bool
+	err     error
+}
+
+// NewRecorder creates a recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	recorder := &Recorder{
+		encoder: json.NewEncoder(w),
+		start:   time.Now(),
+	}
+	if closer, ok := w.(io.Closer); ok {
+		recorder.closer = closer
+	}
+	return recorder
+}
+
+// CreateRecorder creates a recorder writing to a new file at path
+func CreateRecorder(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session file: %w", err)
+	}
+	return NewRecorder(file), nil
+}
+
+// Record appends a frame, stamping its offset. After the first write error every call returns it,
+// and after Close every call fails.
+func (r *Recorder) Record(frame Frame) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+	if r.closed {
+		return fmt.Errorf("session recorder is closed")
+	}
+	frame.OffsetMs = time.Since(r.start).Milliseconds()
+	if err := r.encoder.Encode(frame); err != nil {
+		r.err = fmt.Errorf("failed to record frame: %w", err)
+	}
+	return r.err
+}
+
+// Close stops recording and closes the underlying writer when it is closable
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	if r.closer == nil {
+		return nil
+	}
+	closer := r.closer
+	r.closer = nil
+	return closer.Close()
+}
+
+// ReadSession reads the frames written by a Recorder
+func ReadSession(r io.Reader) ([]Frame, error) {
+	var frames []Frame
+
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var frame Frame
+		if err := decoder.Decode(&frame); err != nil {
+			if err == io.EOF {
+				return frames, nil
+			}
+			return nil, fmt.Errorf("failed to read frame %d: %w", len(frames)+1, err)
+		}
+		frames = append(frames, frame)
+	}
+}
+
+// LoadSession reads the frames of a session file
+func LoadSession(path string) ([]Frame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadSession(file)
+}
+
+// receivedFrames returns the frames that came back from the provider
+func receivedFrames(frames []Frame) []Frame {
+	var received []Frame
+	for _, frame := range frames {
+		if frame.Direction == DirectionReceive {
+			received = append(received, frame)
+		}
+	}
+	return received
+}
@@ -0,0 +1,65 @@
+package providers
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// defaultRetryBackoffFactor is used when the policy does not set a backoff factor
+const defaultRetryBackoffFactor = 2.0
+
+// Retry calls fn until it succeeds, returns an error retryable rejects, or the policy's
+// attempts are exhausted. A nil policy or MaxAttempts <= 1 calls fn once.
+func Retry(ctx context.Context, policy *models.RetryPolicy, retryable func(error) bool, fn func(ctx context.Context) error) error {
+	maxAttempts := 1
+	if policy != nil && policy.MaxAttempts > 1 {
+		maxAttempts = policy.MaxAttempts
+	}
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(RetryDelay(policy, attempt-1))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			}
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		// Stop early when the parent context is done or the failure is permanent
+		if ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// RetryDelay computes the exponential backoff delay for an attempt, capped by MaxDelay
+func RetryDelay(policy *models.RetryPolicy, attempt int) time.Duration {
+	if policy == nil || policy.InitialDelay <= 0 {
+		return 0
+	}
+
+	factor := policy.BackoffFactor
+	if factor <= 0 {
+		factor = defaultRetryBackoffFactor
+	}
+
+	delay := time.Duration(float64(policy.InitialDelay) * math.Pow(factor, float64(attempt)))
+	if policy.MaxDelay > 0 && (delay > policy.MaxDelay || delay <= 0) {
+		delay = policy.MaxDelay
+	}
+
+	return delay
+}
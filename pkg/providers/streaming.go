@@ -0,0 +1,36 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// SupportsStreaming reports whether a provider or service can stream the given capability.
+// Services that do not describe their capabilities are assumed to stream.
+func SupportsStreaming(service any, capability types.Capability) bool {
+	describer, ok := service.(interfaces.CapabilityDescriber)
+	if !ok {
+		return true
+	}
+	return describer.DescribeCapabilities().SupportsStreaming(capability)
+}
+
+// checkStreaming returns an error when service cannot stream the capability
+func checkStreaming(providerID string, service any, capability types.Capability) error {
+	if SupportsStreaming(service, capability) {
+		return nil
+	}
+	return fmt.Errorf("provider %s does not support streaming %s", providerID, capability)
+}
+
+// failedStream returns a closed result channel and an error channel carrying err
+func failedStream[T any](err error) (<-chan T, <-chan error) {
+	results := make(chan T)
+	errs := make(chan error, 1)
+	errs <- err
+	close(results)
+	close(errs)
+	return results, errs
+}
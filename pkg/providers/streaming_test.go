@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// describedChat is a chat stub that reports whether it streams
+type describedChat struct {
+	stubChat
+	streaming bool
+}
+
+func (s describedChat) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{Chat: &models.ChatCapability{Streaming: s.streaming}}
+}
+
+// describedTTS is a TTS stub that reports whether it streams
+type describedTTS struct {
+	stubTTS
+	streaming bool
+}
+
+func (s describedTTS) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{TTS: &models.TTSCapability{Streaming: s.streaming}}
+}
+
+func TestSupportsStreaming(t *testing.T) {
+	r := &recorder{}
+	tests := []struct {
+		name    string
+		service any
+		want    bool
+	}{
+		{name: "streaming", service: describedChat{stubChat: stubChat{recorder: r}, streaming: true}, want: true},
+		{name: "not streaming", service: describedChat{stubChat: stubChat{recorder: r}}, want: false},
+		{name: "undescribed services are assumed to stream", service: stubChat{recorder: r}, want: true},
+	}
+	for _, tt := range tests {
+		if got := SupportsStreaming(tt.service, types.CapabilityChat); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStreamingCallsRequireStreamingSupport(t *testing.T) {
+	ctx := context.Background()
+
+	r := &recorder{}
+	chat := NewContextChatService("batch", describedChat{stubChat: stubChat{recorder: r}})
+	tts := NewContextTTSService("batch", describedTTS{stubTTS: stubTTS{recorder: r}})
+
+	_, errCh := chat.StreamChatCompletion(ctx, nil, nil)
+	streamErrs := map[string]error{
+		"StreamChatCompletion": <-errCh,
+		"StreamCompletion":     chat.StreamCompletion(ctx, interfaces.ChatRequest{}, nil),
+	}
+	_, streamErrs["NewTTSClient"] = tts.NewTTSClient(ctx, models.TTSConfig{})
+	_, errCh = tts.StreamSynthesize(ctx, nil, models.TTSConfig{})
+	streamErrs["StreamSynthesize"] = <-errCh
+
+	for method, err := range streamErrs {
+		if err == nil || !strings.Contains(err.Error(), "provider batch does not support streaming") {
+			t.Errorf("%s: expected a clear streaming error, got %v", method, err)
+		}
+	}
+	if len(r.calls) != 0 {
+		t.Errorf("streaming calls reached a provider that cannot stream: %+v", r.calls)
+	}
+
+	// Calls that do not stream still go through
+	chat.ChatCompletion(ctx, nil, nil)
+	tts.Synthesize(ctx, "hi", models.TTSConfig{})
+	if len(r.calls) != 2 {
+		t.Errorf("expected the non-streaming calls to be delegated, got %+v", r.calls)
+	}
+
+	// A provider that streams is delegated to
+	streaming := NewContextChatService("live", describedChat{stubChat: stubChat{recorder: r}, streaming: true})
+	if err := streaming.StreamCompletion(ctx, interfaces.ChatRequest{}, nil); err != nil {
+		t.Errorf("StreamCompletion on a streaming provider: %v", err)
+	}
+}
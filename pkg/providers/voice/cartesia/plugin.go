@@ -3,13 +3,23 @@ package cartesia
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
 	"time"
 
+	"github.com/creastat/common-go/pkg/audio"
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicecache"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
 	"github.com/creastat/common-go/pkg/types"
 )
 
+// supportedLanguages lists the languages supported by Cartesia's STT and TTS models
+var supportedLanguages = []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko"}
+
 // CartesiaProvider implements the Provider interface for Cartesia
 type CartesiaProvider struct {
 	name         string
@@ -18,6 +28,7 @@ type CartesiaProvider struct {
 	capabilities []types.Capability
 	initialized  bool
 	logger       types.Logger
+	voiceCache   voicecache.Cache // live voice catalog shared by every TTS service of the provider
 }
 
 // NewCartesiaProvider creates a new Cartesia provider instance
@@ -59,6 +70,7 @@ func (p *CartesiaProvider) Initialize(ctx context.Context, config models.Provide
 
 	// Store configuration
 	p.config = config
+	providers.WarnUnknownOptions(p, models.OptionScopeProvider, config.Options, p.logger)
 	p.apiKey = config.APIKey
 
 	// Mark as initialized - API key will be validated on first use
@@ -99,19 +111,100 @@ func (p *CartesiaProvider) validateAPIKey(ctx context.Context) error {
 	return nil
 }
 
-// HealthCheck performs a health check on the provider
+// validateSTT validates STT access by opening a streaming client and closing it immediately
+func (p *CartesiaProvider) validateSTT(ctx context.Context) error {
+	client, err := NewCartesiaSTTService(p).NewSTTClient(ctx, models.STTConfig{})
+	if err != nil {
+		return fmt.Errorf("STT connection failed: %w", err)
+	}
+	return client.Close()
+}
+
+// probeTTS checks TTS access by listing a single voice, which opens no streaming session and is
+// not billed
+func (p *CartesiaProvider) probeTTS(ctx context.Context) error {
+	req, err := NewCartesiaTTSService(p).newAPIRequest(ctx, cartesiaVoicesURL+"?limit=1")
+	if err != nil {
+		return err
+	}
+	return probe(req, http.StatusOK)
+}
+
+// probeSTT checks STT access by posting a transcription request without audio. The batch STT
+// endpoint checks the key before the request, so a valid key is answered with a validation error
+// for the missing file and nothing is transcribed or billed.
+func (p *CartesiaProvider) probeSTT(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", cartesiaSTTURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", p.apiKey)
+	req.Header.Set("Cartesia-Version", cartesiaAPIVersion)
+	return probe(req, http.StatusOK, http.StatusBadRequest, http.StatusUnprocessableEntity)
+}
+
+// probe sends a health probe request and fails unless the response has one of the accepted statuses
+func probe(req *http.Request, accepted ...int) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API probe failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(accepted, resp.StatusCode) {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("API probe failed (status: %d): %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// HealthCheck performs a health check on the provider; it fails when either STT or TTS is unavailable.
+// By default each capability is checked with a REST call to its own endpoint; set
+// Options["deep_health_check"] to true to open a streaming client for each capability instead.
 func (p *CartesiaProvider) HealthCheck(ctx context.Context) error {
 	if !p.initialized {
 		return fmt.Errorf("provider not initialized")
 	}
 
+	for _, capability := range p.capabilities {
+		if err := p.CheckCapabilityHealth(ctx, capability); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckCapabilityHealth checks a single capability with a REST probe, or by opening a streaming
+// client for it when Options["deep_health_check"] is set
+func (p *CartesiaProvider) CheckCapabilityHealth(ctx context.Context, capability types.Capability) error {
+	if !p.initialized {
+		return fmt.Errorf("provider not initialized")
+	}
+
+	if capability != types.CapabilitySTT && capability != types.CapabilityTTS {
+		return fmt.Errorf("capability %s is not supported by %s", capability, p.name)
+	}
+
 	// Create a context with timeout for health check
 	healthCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// Perform a lightweight health check by validating the API key
-	if err := p.validateAPIKey(healthCtx); err != nil {
-		return fmt.Errorf("health check failed: %w", err)
+	deep, _ := p.config.Options["deep_health_check"].(bool)
+
+	var err error
+	switch {
+	case !deep && capability == types.CapabilitySTT:
+		err = p.probeSTT(healthCtx)
+	case !deep:
+		err = p.probeTTS(healthCtx)
+	case capability == types.CapabilitySTT:
+		err = p.validateSTT(healthCtx)
+	default:
+		err = p.validateAPIKey(healthCtx)
+	}
+	if err != nil {
+		return fmt.Errorf("%s health check failed: %w", capability, err)
 	}
 
 	return nil
@@ -171,7 +264,7 @@ func (p *CartesiaProvider) GetProviderInfo() *models.ProviderInfo {
 			Metadata: map[string]any{
 				"sample_rate": 16000,
 				"encoding":    "pcm_s16le",
-				"languages":   []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko"},
+				"languages":   supportedLanguages,
 			},
 		},
 	}
@@ -191,7 +284,7 @@ func (p *CartesiaProvider) GetProviderInfo() *models.ProviderInfo {
 			Metadata: map[string]any{
 				"sample_rate":   16000,
 				"encoding":      "pcm_s16le",
-				"languages":     []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko"},
+				"languages":     supportedLanguages,
 				"default_voice": "694f9389-aac1-45b6-b726-9d9369183238",
 			},
 		},
@@ -209,3 +302,37 @@ func (p *CartesiaProvider) GetProviderInfo() *models.ProviderInfo {
 
 	return info
 }
+
+// DescribeCapabilities reports the provider's STT and TTS capabilities; both stream over WebSocket
+func (p *CartesiaProvider) DescribeCapabilities() *models.ProviderCapabilities {
+	languages := models.NormalizeLanguages(supportedLanguages)
+	return &models.ProviderCapabilities{
+		STT: &models.STTCapability{Streaming: true, Languages: languages},
+		TTS: &models.TTSCapability{Streaming: true, Languages: languages},
+	}
+}
+
+// SupportedOptions describes the Options keys the provider honors
+func (p *CartesiaProvider) SupportedOptions() []models.OptionDescriptor {
+	return []models.OptionDescriptor{
+		voicecache.TTLOption,
+		{Name: "deep_health_check", Type: models.OptionTypeBool, Scope: models.OptionScopeProvider, Description: "make health checks open a streaming client per capability instead of a REST probe"},
+		models.CapabilityTimeoutOption(models.CapabilitySTT),
+		models.CapabilityTimeoutOption(models.CapabilityTTS),
+		{Name: "min_volume", Type: models.OptionTypeNumber, Scope: models.OptionScopeSTT, Description: "volume below which audio is treated as silence"},
+		{Name: "max_silence_duration_secs", Type: models.OptionTypeNumber, Scope: models.OptionScopeSTT, Description: "silence after which an utterance is finalized"},
+		audio.NormalizeGainOption,
+		audio.AutoResampleOption,
+		{Name: "container", Type: models.OptionTypeString, Scope: models.OptionScopeTTS, Description: "output container: raw (default), wav or mp3"},
+		{Name: "bit_rate", Type: models.OptionTypeInt, Scope: models.OptionScopeTTS, Description: "MP3 bit rate, 128000 by default"},
+		{Name: "speed_name", Type: models.OptionTypeString, Scope: models.OptionScopeTTS, Description: "named speed (slowest, slow, normal, fast, fastest) used instead of the numeric speed"},
+		{Name: "validate_voice", Type: models.OptionTypeBool, Scope: models.OptionScopeTTS, Description: "check that the voice exists before connecting"},
+		{Name: "ping_interval_ms", Type: models.OptionTypeInt, Scope: models.OptionScopeTTS, Description: "WebSocket ping interval; zero or less disables pings"},
+		wsutil.MaxMessageBytesOption(models.OptionScopeSTT),
+		wsutil.CompressionOption(models.OptionScopeSTT),
+		wsutil.SubprotocolsOption(models.OptionScopeSTT),
+		wsutil.MaxMessageBytesOption(models.OptionScopeTTS),
+		wsutil.CompressionOption(models.OptionScopeTTS),
+		wsutil.SubprotocolsOption(models.OptionScopeTTS),
+	}
+}
@@ -0,0 +1,124 @@
+package cartesia
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// roundTripFunc serves HTTP requests without a network
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHealthCheckProbesEachCapability(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses map[string]int // response status per probed path
+		stt      string         // expected error substring, empty when healthy
+		tts      string
+	}{
+		{
+			name:     "both healthy",
+			statuses: map[string]int{"/stt": http.StatusBadRequest, "/voices": http.StatusOK},
+		},
+		{
+			name:     "revoked key",
+			statuses: map[string]int{"/stt": http.StatusUnauthorized, "/voices": http.StatusUnauthorized},
+			stt:      "stt health check failed: API probe failed (status: 401)",
+			tts:      "tts health check failed: API probe failed (status: 401)",
+		},
+		{
+			name:     "STT outage",
+			statuses: map[string]int{"/stt": http.StatusServiceUnavailable, "/voices": http.StatusOK},
+			stt:      "status: 503",
+		},
+		{
+			name:     "TTS outage",
+			statuses: map[string]int{"/stt": http.StatusUnprocessableEntity, "/voices": http.StatusInternalServerError},
+			tts:      "status: 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []*http.Request
+			original := http.DefaultTransport
+			t.Cleanup(func() { http.DefaultTransport = original })
+			http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req)
+				return &http.Response{
+					StatusCode: tt.statuses[req.URL.Path],
+					Body:       io.NopCloser(strings.NewReader(`{"data":[]}`)),
+					Request:    req,
+				}, nil
+			})
+
+			provider := NewCartesiaProvider(nil)
+			if err := provider.Initialize(context.Background(), models.ProviderConfig{APIKey: "test-key"}); err != nil {
+				t.Fatalf("Initialize: %v", err)
+			}
+
+			for capability, want := range map[types.Capability]string{types.CapabilitySTT: tt.stt, types.CapabilityTTS: tt.tts} {
+				err := provider.CheckCapabilityHealth(context.Background(), capability)
+				if want == "" && err != nil {
+					t.Errorf("%s: expected healthy, got %v", capability, err)
+				}
+				if want != "" && (err == nil || !strings.Contains(err.Error(), want)) {
+					t.Errorf("%s: expected an error containing %q, got %v", capability, want, err)
+				}
+			}
+
+			if len(requests) != 2 {
+				t.Fatalf("expected one REST request per capability, got %d", len(requests))
+			}
+			for _, req := range requests {
+				switch req.URL.Path {
+				case "/stt":
+					if req.Method != http.MethodPost || req.ContentLength > 0 {
+						t.Errorf("expected an empty STT request, got %s with %d bytes", req.Method, req.ContentLength)
+					}
+				case "/voices":
+					if req.Method != http.MethodGet || req.URL.Query().Get("limit") != "1" {
+						t.Errorf("unexpected TTS probe %s %s", req.Method, req.URL)
+					}
+				default:
+					t.Errorf("unexpected probe request %s", req.URL)
+				}
+				if req.Header.Get("X-API-Key") != "test-key" {
+					t.Errorf("the probe of %s did not carry the API key", req.URL.Path)
+				}
+			}
+
+			wantHealthy := tt.stt == "" && tt.tts == ""
+			if err := provider.HealthCheck(context.Background()); (err == nil) != wantHealthy {
+				t.Errorf("HealthCheck: expected healthy %v, got %v", wantHealthy, err)
+			}
+		})
+	}
+}
+
+func TestSupportedLanguages(t *testing.T) {
+	provider := NewCartesiaProvider(nil)
+	want := []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko"}
+
+	// Cartesia transcribes and synthesizes the same languages
+	for name, got := range map[string][]string{
+		"STT":            (&CartesiaSTTServiceWrapper{provider: provider}).SupportedLanguages(),
+		"TTS":            (&CartesiaTTSServiceWrapper{provider: provider}).SupportedLanguages(),
+		"STT capability": provider.DescribeCapabilities().STT.Languages,
+		"TTS capability": provider.DescribeCapabilities().TTS.Languages,
+	} {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+	}
+}
@@ -28,7 +28,7 @@ func (w *CartesiaSTTServiceWrapper) Initialize(ctx context.Context, config model
 	return nil
 }
 func (w *CartesiaSTTServiceWrapper) HealthCheck(ctx context.Context) error {
-	return w.provider.HealthCheck(ctx)
+	return w.provider.CheckCapabilityHealth(ctx, types.CapabilitySTT)
 }
 func (w *CartesiaSTTServiceWrapper) Close() error { return nil }
 func (w *CartesiaSTTServiceWrapper) GetProviderInfo() *models.ProviderInfo {
@@ -37,6 +37,9 @@ func (w *CartesiaSTTServiceWrapper) GetProviderInfo() *models.ProviderInfo {
 
 // STTService interface methods (new interface with different signatures)
 func (w *CartesiaSTTServiceWrapper) Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error) {
+	ctx, cancel := models.WithCapabilityTimeout(ctx, w.provider.config, models.CapabilitySTT)
+	defer cancel()
+
 	// Convert []byte to io.Reader for the old implementation
 	sttService := NewCartesiaSTTService(w.provider)
 	return sttService.Transcribe(ctx, io.NopCloser(bytes.NewReader(audioData)), models.STTConfig{
@@ -82,7 +85,7 @@ func (w *CartesiaTTSServiceWrapper) Initialize(ctx context.Context, config model
 	return nil
 }
 func (w *CartesiaTTSServiceWrapper) HealthCheck(ctx context.Context) error {
-	return w.provider.HealthCheck(ctx)
+	return w.provider.CheckCapabilityHealth(ctx, types.CapabilityTTS)
 }
 func (w *CartesiaTTSServiceWrapper) Close() error { return nil }
 func (w *CartesiaTTSServiceWrapper) GetProviderInfo() *models.ProviderInfo {
@@ -91,6 +94,9 @@ func (w *CartesiaTTSServiceWrapper) GetProviderInfo() *models.ProviderInfo {
 
 // TTSService interface methods
 func (w *CartesiaTTSServiceWrapper) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
+	ctx, cancel := models.WithCapabilityTimeout(ctx, w.provider.config, models.CapabilityTTS)
+	defer cancel()
+
 	ttsService := NewCartesiaTTSService(w.provider)
 	return ttsService.Synthesize(ctx, text, config)
 }
@@ -120,3 +126,28 @@ func (w *CartesiaTTSServiceWrapper) GetVoices(ctx context.Context) ([]models.Voi
 	ttsService := NewCartesiaTTSService(w.provider)
 	return ttsService.GetVoices(ctx)
 }
+
+func (w *CartesiaTTSServiceWrapper) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
+	ttsService := NewCartesiaTTSService(w.provider)
+	return ttsService.GetVoicesByLanguage(ctx, language)
+}
+
+// SupportedLanguages returns the normalized language codes Cartesia can transcribe
+func (w *CartesiaSTTServiceWrapper) SupportedLanguages() []string {
+	return models.NormalizeLanguages(supportedLanguages)
+}
+
+// SupportedLanguages returns the normalized language codes Cartesia can synthesize
+func (w *CartesiaTTSServiceWrapper) SupportedLanguages() []string {
+	return models.NormalizeLanguages(supportedLanguages)
+}
+
+// DescribeCapabilities reports the STT capability of the wrapped provider
+func (w *CartesiaSTTServiceWrapper) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{STT: w.provider.DescribeCapabilities().STT}
+}
+
+// DescribeCapabilities reports the TTS capability of the wrapped provider
+func (w *CartesiaTTSServiceWrapper) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{TTS: w.provider.DescribeCapabilities().TTS}
+}
@@ -5,10 +5,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/creastat/common-go/pkg/audio"
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
+	"github.com/creastat/common-go/pkg/types"
 
 	"github.com/gorilla/websocket"
 )
@@ -27,9 +35,19 @@ func NewCartesiaSTTService(provider *CartesiaProvider) *CartesiaSTTService {
 
 // NewSTTClient creates a new STT client for streaming audio
 func (s *CartesiaSTTService) NewSTTClient(ctx context.Context, config models.STTConfig) (interfaces.STTClient, error) {
+	client, err := s.newSTTClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newSTTClient creates a new STT client and returns its concrete type
+func (s *CartesiaSTTService) newSTTClient(ctx context.Context, config models.STTConfig) (*cartesiaSTTClient, error) {
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeSTT, config.Options, s.provider.logger)
 
 	// Set defaults if not provided
 	if config.Model == "" {
@@ -41,10 +59,18 @@ func (s *CartesiaSTTService) NewSTTClient(ctx context.Context, config models.STT
 	if config.SampleRate == 0 {
 		config.SampleRate = 16000
 	}
-	if config.Encoding == "" {
-		config.Encoding = "pcm_s16le"
+	// Bit depth and companding refine the requested encoding
+	if err := config.ResolveEncoding(string(models.AudioEncodingPCM16)); err != nil {
+		return nil, err
 	}
 
+	// Map the requested encoding to Cartesia's native name
+	encoding, err := models.ResolveProviderEncoding(models.ProviderTypeCartesia, config.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	config.Encoding = encoding
+
 	// Extract Cartesia-specific options
 	minVolume := 0.05         // Default: 5% threshold for speech detection
 	maxSilenceDuration := 1.0 // Default: 1 second of silence before finalizing
@@ -68,9 +94,10 @@ func (s *CartesiaSTTService) NewSTTClient(ctx context.Context, config models.STT
 		minVolume,
 		maxSilenceDuration,
 	)
+	wsURL = appendContextPhrases(wsURL, config.ContextPhrases, s.provider.logger)
 
 	// Create WebSocket connection
-	dialer := websocket.DefaultDialer
+	dialer := wsutil.Dialer(config.Options)
 	header := make(map[string][]string)
 	header["X-API-Key"] = []string{s.provider.GetAPIKey()}
 	header["Cartesia-Version"] = []string{"2024-06-10"}
@@ -81,32 +108,102 @@ func (s *CartesiaSTTService) NewSTTClient(ctx context.Context, config models.STT
 	}
 
 	client := &cartesiaSTTClient{
-		conn:     conn,
-		config:   config,
-		resultCh: make(chan *models.STTResult, 10),
-		errCh:    make(chan error, 1),
-		doneCh:   make(chan struct{}),
-		closed:   false,
+		conn:            conn,
+		config:          config,
+		resultCh:        make(chan *models.STTResult, 10),
+		errCh:           make(chan error, 1),
+		lc:              lifecycle.New(),
+		maxMessageBytes: wsutil.ApplyReadLimit(conn, config.Options),
+		streamStart:     time.Now(),
+	}
+
+	if audio.IsPCM16(config.Encoding) {
+		client.gain = audio.GainNormalizerFromOption(config.Options["normalize_gain"])
+		client.resampler = audio.ResamplerFromOption(config.Options["auto_resample"], config.SampleRate, config.Channels)
 	}
 
 	// Start reading messages in background
-	go client.readMessages()
+	client.lc.Go(client.readMessages)
 
 	return client, nil
 }
 
+// maxSTTURLLength bounds the WebSocket URL so context phrases cannot push it past server limits
+const maxSTTURLLength = 8192
+
+// appendContextPhrases adds each context phrase as a "context" query parameter,
+// dropping the phrases that would exceed maxSTTURLLength
+func appendContextPhrases(wsURL string, phrases []string, logger types.Logger) string {
+	var b strings.Builder
+	b.WriteString(wsURL)
+
+	kept := 0
+	for i, phrase := range phrases {
+		phrase = strings.TrimSpace(phrase)
+		if phrase == "" {
+			continue
+		}
+
+		param := "&context=" + url.QueryEscape(phrase)
+		if b.Len()+len(param) > maxSTTURLLength {
+			logger.Warn("Cartesia STT context phrases truncated to fit URL length limit",
+				"kept", kept,
+				"dropped", countNonBlank(phrases[i:]),
+				"max_url_length", maxSTTURLLength,
+			)
+			break
+		}
+		b.WriteString(param)
+		kept++
+	}
+
+	return b.String()
+}
+
+// countNonBlank returns the number of phrases that are not empty after trimming
+func countNonBlank(phrases []string) int {
+	count := 0
+	for _, phrase := range phrases {
+		if strings.TrimSpace(phrase) != "" {
+			count++
+		}
+	}
+	return count
+}
+
 // Transcribe transcribes audio data to text (non-streaming)
 func (s *CartesiaSTTService) Transcribe(ctx context.Context, audio io.Reader, config models.STTConfig) (string, error) {
 	// Create a streaming client
-	client, err := s.NewSTTClient(ctx, config)
+	client, err := s.newSTTClient(ctx, config)
 	if err != nil {
 		return "", fmt.Errorf("failed to create STT client: %w", err)
 	}
-	defer client.Close()
+
+	return client.transcribe(ctx, audio)
+}
+
+// transcribe streams audio through the client and returns the final transcript, closing the
+// client when done. A cancelled transcription aborts rather than waiting out the grace period
+// for trailing results.
+func (c *cartesiaSTTClient) transcribe(ctx context.Context, audio io.Reader) (string, error) {
+	defer func() {
+		if ctx.Err() != nil {
+			c.abort()
+			return
+		}
+		c.Close()
+	}()
 
 	// Read audio data and send to client
 	buffer := make([]byte, 4096)
 	for {
+		// Stop sending promptly on cancel; the deferred abort tears the stream down
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		default:
+		}
+
 		n, err := audio.Read(buffer)
 		if err != nil {
 			if err == io.EOF {
@@ -116,7 +213,7 @@ func (s *CartesiaSTTService) Transcribe(ctx context.Context, audio io.Reader, co
 		}
 
 		if n > 0 {
-			if err := client.Send(ctx, buffer[:n]); err != nil {
+			if err := c.Send(ctx, buffer[:n]); err != nil {
 				return "", fmt.Errorf("failed to send audio: %w", err)
 			}
 		}
@@ -125,7 +222,7 @@ func (s *CartesiaSTTService) Transcribe(ctx context.Context, audio io.Reader, co
 	// Collect all results
 	var fullText string
 	for {
-		result, err := client.Receive(ctx)
+		result, err := c.Receive(ctx)
 		if err != nil {
 			if err == io.EOF {
 				break
@@ -156,13 +253,18 @@ func (s *CartesiaSTTService) GetModels(ctx context.Context) ([]models.Model, err
 
 // cartesiaSTTClient implements the STTClient interface
 type cartesiaSTTClient struct {
-	conn     *websocket.Conn
-	config   models.STTConfig
-	resultCh chan *models.STTResult
-	errCh    chan error
-	doneCh   chan struct{}
-	mu       sync.Mutex
-	closed   bool
+	conn      *websocket.Conn
+	config    models.STTConfig
+	gain      *audio.GainNormalizer // optional pre-Send normalization
+	resampler *audio.Resampler      // optional pre-Send sample rate conversion
+	resultCh  chan *models.STTResult
+	errCh     chan error
+	lc        *lifecycle.Lifecycle
+	mu        sync.Mutex // serializes writes to conn
+	flushed   bool
+
+	maxMessageBytes int64     // read limit applied to conn
+	streamStart     time.Time // when the stream was opened; result offsets are relative to it
 }
 
 // Send sends audio data to the STT service
@@ -170,10 +272,20 @@ func (c *cartesiaSTTClient) Send(ctx context.Context, audio []byte) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() || c.flushed {
 		return fmt.Errorf("STT client is closed")
 	}
 
+	// Convert input at the auto_resample rate to the configured sample rate; a chunk too short to
+	// yield a frame is held until the next one
+	audio = c.resampler.Apply(audio)
+	if c.resampler != nil && len(audio) == 0 {
+		return nil
+	}
+
+	// Amplify quiet input when normalize_gain is set
+	audio = c.gain.Apply(audio)
+
 	if err := c.conn.WriteMessage(websocket.BinaryMessage, audio); err != nil {
 
 		return fmt.Errorf("failed to send audio: %w", err)
@@ -184,30 +296,28 @@ func (c *cartesiaSTTClient) Send(ctx context.Context, audio []byte) error {
 
 // Receive receives transcription results from the STT service
 func (c *cartesiaSTTClient) Receive(ctx context.Context) (*models.STTResult, error) {
-	select {
-	case result := <-c.resultCh:
-		return result, nil
-	case err := <-c.errCh:
-		return nil, err
-	case <-c.doneCh:
-		return nil, io.EOF
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
+	return lifecycle.Receive(ctx, c.lc, c.resultCh, c.errCh)
+}
+
+// StreamTo invokes handler for each transcription result until the stream ends
+func (c *cartesiaSTTClient) StreamTo(ctx context.Context, handler func(*models.STTResult) error) error {
+	return lifecycle.StreamTo(ctx, c.Receive, handler, c.Flush)
+}
+
+// StreamStartTime returns when the stream was opened
+func (c *cartesiaSTTClient) StreamStartTime() time.Time {
+	return c.streamStart
 }
 
 // Close closes the STT client and releases resources
 func (c *cartesiaSTTClient) Close() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.closed {
-		return nil
-	}
+	return c.lc.Close(c.Flush, c.conn.Close)
+}
 
-	c.closed = true
-	close(c.doneCh)
-	return c.conn.Close()
+// abort closes the client without waiting for trailing results
+func (c *cartesiaSTTClient) abort() error {
+	c.lc.Abort()
+	return c.Close()
 }
 
 // Finalize flushes any buffered audio and forces Cartesia to send transcript
@@ -216,7 +326,7 @@ func (c *cartesiaSTTClient) Finalize() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() || c.flushed {
 		return nil
 	}
 
@@ -233,50 +343,30 @@ func (c *cartesiaSTTClient) Flush() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.flushed {
 		return nil
 	}
+	c.flushed = true
 
 	if err := c.conn.WriteMessage(websocket.TextMessage, []byte("done")); err != nil {
 
 		return fmt.Errorf("failed to send done command: %w", err)
 	}
 
-	c.closed = true
 	return nil
 }
 
 // readMessages reads messages from STT WebSocket
 func (c *cartesiaSTTClient) readMessages() {
-	defer func() {
-		c.mu.Lock()
-		if !c.closed {
-			close(c.doneCh)
-		}
-		c.mu.Unlock()
-	}()
-
 	for {
-		c.mu.Lock()
-		if c.closed {
-			c.mu.Unlock()
-			return
-		}
-		c.mu.Unlock()
-
 		_, message, err := c.conn.ReadMessage()
 		if err != nil {
-			c.mu.Lock()
-			wasClosed := c.closed
-			c.mu.Unlock()
-
-			if !wasClosed {
+			if !c.lc.IsClosing() {
 
 				select {
-				case c.errCh <- fmt.Errorf("STT read error: %w", err):
+				case c.errCh <- fmt.Errorf("STT read error: %w", wsutil.ReadLimitError(err, c.maxMessageBytes)):
 				default:
 				}
-				c.Close()
 			}
 			return
 		}
@@ -295,7 +385,7 @@ func (c *cartesiaSTTClient) readMessages() {
 			result := c.parseTranscriptResult(rawResult)
 			select {
 			case c.resultCh <- result:
-			case <-c.doneCh:
+			case <-c.lc.Aborted():
 				return
 			}
 
@@ -306,14 +396,12 @@ func (c *cartesiaSTTClient) readMessages() {
 			case c.errCh <- fmt.Errorf("Cartesia STT error: %s", errMsg):
 			default:
 			}
-			c.Close()
 			return
 
 		case "flush_done":
 			// Connection stays open, continue processing
 
 		case "done":
-			c.Close()
 			return
 		}
 	}
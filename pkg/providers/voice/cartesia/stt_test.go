@@ -0,0 +1,138 @@
+package cartesia
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+	"github.com/creastat/common-go/pkg/types"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestSTTClient returns a client streaming to a stub server running handler
+func newTestSTTClient(t *testing.T, handler func(*websocket.Conn)) *cartesiaSTTClient {
+	t.Helper()
+
+	client := &cartesiaSTTClient{
+		conn:        voicetest.Dial(t, handler),
+		resultCh:    make(chan *models.STTResult, 10),
+		errCh:       make(chan error, 1),
+		lc:          lifecycle.New(),
+		streamStart: time.Now(),
+	}
+	client.lc.Go(client.readMessages)
+	return client
+}
+
+func TestSTTClientClose(t *testing.T) {
+	// Cartesia answers the done command with a final done message
+	client := newTestSTTClient(t, func(conn *websocket.Conn) {
+		if voicetest.ReadUntil(conn, "done") {
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"done"}`))
+		}
+	})
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestSTTClientCloseUnresponsiveServer(t *testing.T) {
+	client := newTestSTTClient(t, func(conn *websocket.Conn) {
+		voicetest.ReadUntil(conn, "never sent")
+	})
+	client.lc.GracePeriod = 50 * time.Millisecond
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestSupportedOptionsDescribeReadLimit(t *testing.T) {
+	descriptors := NewCartesiaProvider(nil).SupportedOptions()
+	options := map[string]any{"max_message_bytes": 1024}
+	for _, scope := range []models.OptionScope{models.OptionScopeSTT, models.OptionScopeTTS} {
+		if unknown := models.UnknownOptions(descriptors, scope, options); len(unknown) > 0 {
+			t.Errorf("%s options %v are not described", scope, unknown)
+		}
+	}
+}
+
+// warnRecorder keeps the key-value arguments of each warning
+type warnRecorder struct {
+	types.NoOpLogger
+	warnings [][]any
+}
+
+func (l *warnRecorder) Warn(msg string, args ...any) {
+	l.warnings = append(l.warnings, args)
+}
+
+func TestAppendContextPhrases(t *testing.T) {
+	const base = "wss://api.cartesia.ai/stt/websocket?model=ink-whisper"
+
+	logger := &warnRecorder{}
+	got := appendContextPhrases(base, []string{" Acme Corp ", "", "R&D / Q3", "   "}, logger)
+
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("parse %q: %v", got, err)
+	}
+	if phrases := u.Query()["context"]; !reflect.DeepEqual(phrases, []string{"Acme Corp", "R&D / Q3"}) {
+		t.Errorf("expected trimmed, encoded phrases without blanks, got %v", phrases)
+	}
+	if u.Query().Get("model") != "ink-whisper" {
+		t.Errorf("existing parameters were lost: %s", got)
+	}
+	if len(logger.warnings) != 0 {
+		t.Errorf("expected no truncation warning, got %v", logger.warnings)
+	}
+}
+
+func TestAppendContextPhrasesTruncates(t *testing.T) {
+	const base = "wss://api.cartesia.ai/stt/websocket?model=ink-whisper"
+
+	// Blank phrases are interleaved so they cannot be mistaken for kept or dropped ones
+	long := strings.Repeat("x", 3000)
+	phrases := []string{"", long, " ", long, long, "", long}
+
+	logger := &warnRecorder{}
+	got := appendContextPhrases(base, phrases, logger)
+
+	if len(got) > maxSTTURLLength {
+		t.Errorf("URL length %d exceeds %d", len(got), maxSTTURLLength)
+	}
+	if kept := strings.Count(got, "&context="); kept != 2 {
+		t.Errorf("expected 2 phrases to fit, got %d", kept)
+	}
+
+	if len(logger.warnings) != 1 {
+		t.Fatalf("expected one truncation warning, got %v", logger.warnings)
+	}
+	fields := map[any]any{}
+	args := logger.warnings[0]
+	for i := 0; i+1 < len(args); i += 2 {
+		fields[args[i]] = args[i+1]
+	}
+	if fields["kept"] != 2 || fields["dropped"] != 2 {
+		t.Errorf("expected kept=2 dropped=2, got %v", fields)
+	}
+}
+
+func TestTranscribeReturnsPromptlyOnCancel(t *testing.T) {
+	client := newTestSTTClient(t, func(conn *websocket.Conn) {
+		voicetest.ReadUntil(conn, "never sent")
+	})
+	client.lc.GracePeriod = time.Minute
+
+	voicetest.CheckPromptCancel(t, client.transcribe)
+}
@@ -6,16 +6,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicecache"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
+	"github.com/creastat/common-go/pkg/sanitize"
 	"github.com/creastat/common-go/pkg/types"
 
 	"github.com/gorilla/websocket"
 )
 
+// defaultPingInterval is how often WebSocket pings are sent when ping_interval_ms is not set
+const defaultPingInterval = 15 * time.Second
+
+// defaultMP3BitRate is the MP3 bit rate used when the bit_rate option is not set
+const defaultMP3BitRate = 128000
+
 // CartesiaTTSService implements the TextToSpeechService interface for Cartesia
 type CartesiaTTSService struct {
 	provider *CartesiaProvider
@@ -35,6 +48,7 @@ func (s *CartesiaTTSService) NewTTSClient(ctx context.Context, config models.TTS
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeTTS, config.Options, s.logger)
 
 	// Set defaults if not provided
 	if config.Model == "" {
@@ -49,17 +63,54 @@ func (s *CartesiaTTSService) NewTTSClient(ctx context.Context, config models.TTS
 	if config.SampleRate == 0 {
 		config.SampleRate = 16000
 	}
-	if config.Encoding == "" {
-		config.Encoding = "pcm_s16le"
+	if validate, _ := config.Options["validate_voice"].(bool); validate {
+		if err := s.ValidateVoice(ctx, config.Voice); err != nil {
+			return nil, err
+		}
+	}
+	// Bit depth and companding refine the requested encoding
+	if err := config.ResolveEncoding(string(models.AudioEncodingPCM16)); err != nil {
+		return nil, err
+	}
+
+	// Select the output container (raw PCM by default)
+	container := "raw"
+	if c, ok := config.Options["container"].(string); ok && c != "" {
+		container = strings.ToLower(c)
+	}
+	bitRate := defaultMP3BitRate
+	switch container {
+	case "raw", "wav":
+		// Map the requested encoding to Cartesia's native name
+		encoding, err := models.ResolveProviderEncoding(models.ProviderTypeCartesia, config.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		config.Encoding = encoding
+	case "mp3":
+		// MP3 carries its own encoding; only the bit rate is configurable
+		if br, ok := config.Options["bit_rate"].(int); ok && br > 0 {
+			bitRate = br
+		} else if br, ok := config.Options["bit_rate"].(float64); ok && br > 0 {
+			bitRate = int(br)
+		}
+		config.Encoding = string(models.AudioEncodingMP3)
+	default:
+		return nil, fmt.Errorf("unsupported Cartesia output container: %s (supported: raw, wav, mp3)", container)
+	}
+
+	speed, err := resolveSpeed(config)
+	if err != nil {
+		return nil, err
 	}
 
 	// Connect to Cartesia TTS WebSocket
 	wsURL := "wss://api.cartesia.ai/tts/websocket"
 
-	dialer := websocket.DefaultDialer
+	dialer := wsutil.Dialer(config.Options)
 	header := make(map[string][]string)
 	header["X-API-Key"] = []string{s.provider.GetAPIKey()}
-	header["Cartesia-Version"] = []string{"2025-04-16"}
+	header["Cartesia-Version"] = []string{cartesiaAPIVersion}
 
 	conn, _, err := dialer.Dial(wsURL, header)
 	if err != nil {
@@ -67,17 +118,27 @@ func (s *CartesiaTTSService) NewTTSClient(ctx context.Context, config models.TTS
 	}
 
 	client := &cartesiaTTSClient{
-		conn:    conn,
-		config:  config,
-		audioCh: make(chan []byte, 10),
-		errCh:   make(chan error, 1),
-		doneCh:  make(chan struct{}),
-		closed:  false,
-		logger:  s.logger,
+		conn:      conn,
+		config:    config,
+		audioCh:   make(chan []byte, 10),
+		errCh:     make(chan error, 1),
+		lc:        lifecycle.New(),
+		logger:    s.logger,
+		container: container,
+		bitRate:   bitRate,
+		speed:     speed,
+		pending:   make(map[string]bool),
+
+		maxMessageBytes: wsutil.ApplyReadLimit(conn, config.Options),
 	}
 
 	// Start reading messages in background
-	go client.readMessages()
+	client.lc.Go(client.readMessages)
+
+	// Keep the connection alive between turns
+	if interval := pingInterval(config.Options); interval > 0 {
+		go client.keepAlive(interval)
+	}
 
 	return client, nil
 }
@@ -113,47 +174,29 @@ func (s *CartesiaTTSService) Synthesize(ctx context.Context, text string, config
 	return audioData, nil
 }
 
-// GetVoices returns available voices
+// GetVoicesByLanguage returns the available voices for a language; "ru" matches "ru-RU"
+func (s *CartesiaTTSService) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
+	voices, err := s.GetVoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.FilterVoicesByLanguage(voices, language), nil
+}
+
+// GetVoices returns the voices in the live Cartesia catalog, cached for the voice_cache_ttl_secs option
+// (an hour by default). A small built-in list is returned when the catalog cannot be fetched.
 func (s *CartesiaTTSService) GetVoices(ctx context.Context) ([]models.Voice, error) {
-	// Cartesia has many voices, here are some popular ones
-	voices := []models.Voice{
-		{
-			ID:          "694f9389-aac1-45b6-b726-9d9369183238",
-			Name:        "Sonic (Default)",
-			Language:    "en",
-			Gender:      "neutral",
-			Description: "Default Sonic voice with natural tone",
-		},
-		{
-			ID:          "a0e99841-438c-4a64-b679-ae501e7d6091",
-			Name:        "Barbershop Man",
-			Language:    "en",
-			Gender:      "male",
-			Description: "Friendly male voice",
-		},
-		{
-			ID:          "79a125e8-cd45-4c13-8a67-188112f4dd22",
-			Name:        "British Lady",
-			Language:    "en",
-			Gender:      "female",
-			Description: "British accent female voice",
-		},
-		{
-			ID:          "2ee87190-8f84-4925-97da-e52547f9462c",
-			Name:        "Calm Lady",
-			Language:    "en",
-			Gender:      "female",
-			Description: "Calm and soothing female voice",
-		},
-		{
-			ID:          "41534374-4c8c-4e8f-a7d5-4b8e0d8e0e0e",
-			Name:        "Professional Man",
-			Language:    "en",
-			Gender:      "male",
-			Description: "Professional male voice",
-		},
+	if voices, ok := s.provider.voiceCache.Get(); ok {
+		return voices, nil
 	}
 
+	voices, err := s.FetchVoices(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to fetch Cartesia voice catalog, using built-in voices", "error", err)
+		return slices.Clone(fallbackVoices), nil
+	}
+
+	s.provider.voiceCache.Set(voices, voicecache.TTL(s.provider.config.Options))
 	return voices, nil
 }
 
@@ -163,21 +206,31 @@ type cartesiaTTSClient struct {
 	config  models.TTSConfig
 	audioCh chan []byte
 	errCh   chan error
-	doneCh  chan struct{}
-	mu      sync.Mutex
-	closed  bool
+	lc      *lifecycle.Lifecycle
+	writeMu sync.Mutex // serializes all writes to conn, including pings
 	logger  types.Logger
+
+	pendingMu sync.Mutex
+	pending   map[string]bool // context IDs still generating on the server
+
+	container       string // raw, wav or mp3
+	bitRate         int    // used by the mp3 container only
+	speed           any    // named speed string or numeric speed; nil leaves Cartesia's default
+	maxMessageBytes int64  // read limit applied to conn
 }
 
 // Send sends text to be synthesized
 func (c *cartesiaTTSClient) Send(ctx context.Context, text string) error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() {
 		return fmt.Errorf("TTS client is closed")
 	}
 
+	// Control characters and invalid UTF-8 break the JSON payload
+	text = sanitize.Text(text)
+
 	// Generate a unique context ID for this synthesis request
 	contextID := fmt.Sprintf("ctx_%d", time.Now().UnixNano())
 
@@ -189,23 +242,20 @@ func (c *cartesiaTTSClient) Send(ctx context.Context, text string) error {
 			"mode": "id",
 			"id":   c.config.Voice,
 		},
-		"output_format": map[string]any{
-			"container":   "raw",
-			"encoding":    c.config.Encoding,
-			"sample_rate": c.config.SampleRate,
-		},
-		"language":   c.config.Language,
-		"context_id": contextID,
+		"output_format": c.outputFormat(),
+		"language":      c.config.Language,
+		"context_id":    contextID,
 	}
 
 	// Add optional parameters
-	if c.config.Speed > 0 {
-		request["speed"] = c.config.Speed
+	if c.speed != nil {
+		request["speed"] = c.speed
 	}
 
 	if err := c.conn.WriteJSON(request); err != nil {
 		return fmt.Errorf("failed to send TTS request: %w", err)
 	}
+	c.setPending(contextID, true)
 
 	c.logger.Debug("Sent TTS request",
 		"model", c.config.Model,
@@ -217,65 +267,168 @@ func (c *cartesiaTTSClient) Send(ctx context.Context, text string) error {
 	return nil
 }
 
+// cartesiaSpeedNames lists the named speeds Cartesia accepts
+var cartesiaSpeedNames = []string{"slowest", "slow", "normal", "fast", "fastest"}
+
+// resolveSpeed returns the speed to send: Options["speed_name"] as a named speed, or the numeric
+// config.Speed. Setting both is an error since Cartesia takes a single speed value.
+func resolveSpeed(config models.TTSConfig) (any, error) {
+	name, _ := config.Options["speed_name"].(string)
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if name == "" {
+		if config.Speed > 0 {
+			return config.Speed, nil
+		}
+		return nil, nil
+	}
+
+	if config.Speed > 0 {
+		return nil, fmt.Errorf("set either speed or speed_name for Cartesia TTS, not both")
+	}
+	if !slices.Contains(cartesiaSpeedNames, name) {
+		return nil, fmt.Errorf("unsupported Cartesia speed name: %s (supported: %s)", name, strings.Join(cartesiaSpeedNames, ", "))
+	}
+
+	return name, nil
+}
+
+// pingInterval reads ping_interval_ms from the options; a non-positive value disables pings
+func pingInterval(options map[string]any) time.Duration {
+	switch ms := options["ping_interval_ms"].(type) {
+	case int:
+		return time.Duration(ms) * time.Millisecond
+	case float64:
+		return time.Duration(ms) * time.Millisecond
+	default:
+		return defaultPingInterval
+	}
+}
+
+// keepAlive pings the server every interval until the client is closed or the reader exits
+func (c *cartesiaTTSClient) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.lc.Closing():
+			return
+		case <-c.lc.Done():
+			return
+		case <-ticker.C:
+			if err := c.ping(); err != nil {
+				c.logger.Debug("Failed to ping Cartesia TTS", "error", err)
+				return
+			}
+		}
+	}
+}
+
+// ping writes a WebSocket ping under the write lock
+func (c *cartesiaTTSClient) ping() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.lc.IsClosing() {
+		return nil
+	}
+
+	if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+		return fmt.Errorf("failed to send ping: %w", err)
+	}
+
+	return nil
+}
+
+// outputFormat builds the output_format for the selected container
+func (c *cartesiaTTSClient) outputFormat() map[string]any {
+	if c.container == "mp3" {
+		return map[string]any{
+			"container":   "mp3",
+			"sample_rate": c.config.SampleRate,
+			"bit_rate":    c.bitRate,
+		}
+	}
+
+	return map[string]any{
+		"container":   c.container,
+		"encoding":    c.config.Encoding,
+		"sample_rate": c.config.SampleRate,
+	}
+}
+
 // Receive receives synthesized audio data
 func (c *cartesiaTTSClient) Receive(ctx context.Context) ([]byte, error) {
-	select {
-	case audio := <-c.audioCh:
-		return audio, nil
-	case err := <-c.errCh:
-		return nil, err
-	case <-c.doneCh:
-		return nil, io.EOF
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
+	return lifecycle.Receive(ctx, c.lc, c.audioCh, c.errCh)
 }
 
 // Close closes the TTS client and releases resources
 func (c *cartesiaTTSClient) Close() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	// Closing the socket alone does not stop generation server-side, so unfinished contexts
+	// are cancelled first; this is what stops a cancelled Synthesize from using up quota
+	if err := c.cancelPending(); err != nil {
+		c.logger.Debug("Failed to cancel Cartesia TTS generation", "error", err)
+	}
+
+	// Cartesia has no end-of-stream message, so the connection is closed directly
+	return c.lc.Close(nil, c.conn.Close)
+}
+
+// setPending marks a context ID as generating or finished
+func (c *cartesiaTTSClient) setPending(contextID string, pending bool) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+
+	if pending {
+		c.pending[contextID] = true
+	} else {
+		delete(c.pending, contextID)
+	}
+}
+
+// cancelPending asks Cartesia to stop generating every context that has not finished yet
+func (c *cartesiaTTSClient) cancelPending() error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() {
 		return nil
 	}
 
-	c.closed = true
-	close(c.doneCh)
-	return c.conn.Close()
+	c.pendingMu.Lock()
+	contextIDs := make([]string, 0, len(c.pending))
+	for contextID := range c.pending {
+		contextIDs = append(contextIDs, contextID)
+	}
+	clear(c.pending)
+	c.pendingMu.Unlock()
+
+	for _, contextID := range contextIDs {
+		request := map[string]any{
+			"context_id": contextID,
+			"cancel":     true,
+		}
+		if err := c.conn.WriteJSON(request); err != nil {
+			return fmt.Errorf("failed to send TTS cancel: %w", err)
+		}
+		c.logger.Debug("Cancelled TTS generation", "context_id", contextID)
+	}
+
+	return nil
 }
 
 // readMessages reads messages from TTS WebSocket
 func (c *cartesiaTTSClient) readMessages() {
-	defer func() {
-		c.mu.Lock()
-		if !c.closed {
-			close(c.doneCh)
-		}
-		c.mu.Unlock()
-	}()
-
 	for {
-		c.mu.Lock()
-		if c.closed {
-			c.mu.Unlock()
-			return
-		}
-		c.mu.Unlock()
-
 		messageType, message, err := c.conn.ReadMessage()
 		if err != nil {
-			c.mu.Lock()
-			wasClosed := c.closed
-			c.mu.Unlock()
-
-			if !wasClosed {
+			if !c.lc.IsClosing() {
 
 				select {
-				case c.errCh <- fmt.Errorf("TTS read error: %w", err):
+				case c.errCh <- fmt.Errorf("TTS read error: %w", wsutil.ReadLimitError(err, c.maxMessageBytes)):
 				default:
 				}
-				c.Close()
 			}
 			return
 		}
@@ -284,7 +437,7 @@ func (c *cartesiaTTSClient) readMessages() {
 			// Legacy: Binary audio data (shouldn't happen with new API)
 			select {
 			case c.audioCh <- message:
-			case <-c.doneCh:
+			case <-c.lc.Aborted():
 				return
 			}
 		} else {
@@ -314,22 +467,26 @@ func (c *cartesiaTTSClient) readMessages() {
 						c.logger.Debug("Received audio chunk",
 							"size", len(audioData),
 						)
-					case <-c.doneCh:
+					case <-c.lc.Aborted():
 						return
 					}
 				}
 
 			case "done":
-				c.Close()
+				if contextID, ok := result["context_id"].(string); ok {
+					c.setPending(contextID, false)
+				}
 				return
 
 			case "error":
+				if contextID, ok := result["context_id"].(string); ok {
+					c.setPending(contextID, false)
+				}
 				errMsg := c.extractErrorMessage(result)
 				select {
 				case c.errCh <- fmt.Errorf("TTS error: %s", errMsg):
 				default:
 				}
-				c.Close()
 				return
 			}
 		}
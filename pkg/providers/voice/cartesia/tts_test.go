@@ -0,0 +1,108 @@
+package cartesia
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+	"github.com/creastat/common-go/pkg/types"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestTTSClient returns a client synthesizing against a stub server running handler
+func newTestTTSClient(t *testing.T, handler func(*websocket.Conn)) *cartesiaTTSClient {
+	t.Helper()
+
+	client := &cartesiaTTSClient{
+		conn:      voicetest.Dial(t, handler),
+		config:    models.TTSConfig{Voice: "voice", Model: "sonic-2", SampleRate: 24000, Encoding: "pcm_s16le"},
+		audioCh:   make(chan []byte, 10),
+		errCh:     make(chan error, 1),
+		lc:        lifecycle.New(),
+		logger:    &types.NoOpLogger{},
+		container: "raw",
+		pending:   make(map[string]bool),
+	}
+	client.lc.Go(client.readMessages)
+	return client
+}
+
+func TestTTSClientClose(t *testing.T) {
+	cancelled := make(chan bool, 1)
+	client := newTestTTSClient(t, func(conn *websocket.Conn) {
+		cancelled <- voicetest.ReadUntil(conn, `"cancel":true`)
+	})
+
+	if err := client.Send(context.Background(), "hello"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+
+	if !<-cancelled {
+		t.Error("expected Close to cancel the unfinished generation")
+	}
+}
+
+func TestResolveSpeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  models.TTSConfig
+		want    any
+		wantErr string
+	}{
+		{name: "unset", want: nil},
+		{name: "numeric", config: models.TTSConfig{Speed: 1.2}, want: 1.2},
+		{name: "named", config: models.TTSConfig{Options: map[string]any{"speed_name": "fast"}}, want: "fast"},
+		{name: "named is normalized", config: models.TTSConfig{Options: map[string]any{"speed_name": " Slowest "}}, want: "slowest"},
+		{name: "both set", config: models.TTSConfig{Speed: 1.2, Options: map[string]any{"speed_name": "fast"}}, wantErr: "not both"},
+		{name: "unknown name", config: models.TTSConfig{Options: map[string]any{"speed_name": "ludicrous"}}, wantErr: "unsupported Cartesia speed name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSpeed(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveSpeed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTTSClientSendsSpeed(t *testing.T) {
+	for _, speed := range []any{"slow", 0.8} {
+		requests := make(chan map[string]any, 1)
+		client := newTestTTSClient(t, func(conn *websocket.Conn) {
+			var request map[string]any
+			if err := conn.ReadJSON(&request); err == nil {
+				requests <- request
+			}
+			voicetest.ReadUntil(conn, "never sent")
+		})
+		client.speed = speed
+
+		if err := client.Send(context.Background(), "hello"); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+		if request := <-requests; request["speed"] != speed {
+			t.Errorf("expected speed %v in the request, got %v", speed, request["speed"])
+		}
+		client.Close()
+	}
+}
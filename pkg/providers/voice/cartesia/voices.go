@@ -0,0 +1,216 @@
+package cartesia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+const (
+	// cartesiaAPIVersion is sent as the Cartesia-Version header on every request
+	cartesiaAPIVersion = "2025-04-16"
+
+	// cartesiaVoicesURL lists the voices available to the API key
+	cartesiaVoicesURL = "https://api.cartesia.ai/voices"
+
+	// cartesiaSTTURL transcribes uploaded audio files; health checks probe it without audio
+	cartesiaSTTURL = "https://api.cartesia.ai/stt"
+
+	// voicesPageSize and maxVoicePages bound catalog pagination
+	voicesPageSize = 100
+	maxVoicePages  = 50
+)
+
+// fallbackVoices are returned by GetVoices when the live catalog cannot be fetched
+var fallbackVoices = []models.Voice{
+	{
+		ID:          "694f9389-aac1-45b6-b726-9d9369183238",
+		Name:        "Sonic (Default)",
+		Language:    "en",
+		Gender:      "neutral",
+		Description: "Default Sonic voice with natural tone",
+	},
+	{
+		ID:          "a0e99841-438c-4a64-b679-ae501e7d6091",
+		Name:        "Barbershop Man",
+		Language:    "en",
+		Gender:      "male",
+		Description: "Friendly male voice",
+	},
+	{
+		ID:          "79a125e8-cd45-4c13-8a67-188112f4dd22",
+		Name:        "British Lady",
+		Language:    "en",
+		Gender:      "female",
+		Description: "British accent female voice",
+	},
+	{
+		ID:          "2ee87190-8f84-4925-97da-e52547f9462c",
+		Name:        "Calm Lady",
+		Language:    "en",
+		Gender:      "female",
+		Description: "Calm and soothing female voice",
+	},
+}
+
+// cartesiaVoice is a voice as listed by the Cartesia API
+type cartesiaVoice struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Language    string `json:"language"`
+	Gender      string `json:"gender"`
+}
+
+// cartesiaVoicePage is one page of the voice listing
+type cartesiaVoicePage struct {
+	Data    []cartesiaVoice `json:"data"`
+	HasMore bool            `json:"has_more"`
+}
+
+// FetchVoices lists the voices in the live Cartesia catalog, bypassing the cache
+func (s *CartesiaTTSService) FetchVoices(ctx context.Context) ([]models.Voice, error) {
+	if !s.provider.IsInitialized() {
+		return nil, fmt.Errorf("provider not initialized")
+	}
+
+	httpClient := &http.Client{Timeout: s.provider.config.Timeout}
+
+	var voices []models.Voice
+	startingAfter := ""
+	for range maxVoicePages {
+		page, err := s.fetchVoicePage(ctx, httpClient, startingAfter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, voice := range page.Data {
+			voices = append(voices, voice.toModel())
+		}
+
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		startingAfter = page.Data[len(page.Data)-1].ID
+	}
+
+	return voices, nil
+}
+
+// fetchVoicePage requests one page of the catalog, starting after the given voice ID
+func (s *CartesiaTTSService) fetchVoicePage(ctx context.Context, httpClient *http.Client, startingAfter string) (*cartesiaVoicePage, error) {
+	query := url.Values{}
+	query.Set("limit", fmt.Sprintf("%d", voicesPageSize))
+	if startingAfter != "" {
+		query.Set("starting_after", startingAfter)
+	}
+
+	req, err := s.newAPIRequest(ctx, cartesiaVoicesURL+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Cartesia voices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Cartesia voice listing failed (status: %d): %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Older API versions return a bare array instead of a page
+	var page cartesiaVoicePage
+	if err := json.Unmarshal(body, &page); err != nil {
+		if err := json.Unmarshal(body, &page.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return &page, nil
+}
+
+// ValidateVoice checks that a voice exists in the Cartesia catalog, so a bad voice ID fails with a clear
+// error instead of an opaque error event once synthesis starts. A fresh cached catalog is consulted first.
+func (s *CartesiaTTSService) ValidateVoice(ctx context.Context, voiceID string) error {
+	if !s.provider.IsInitialized() {
+		return fmt.Errorf("provider not initialized")
+	}
+	if voiceID == "" {
+		return fmt.Errorf("voice ID is required")
+	}
+
+	if voices, ok := s.provider.voiceCache.Get(); ok {
+		for _, voice := range voices {
+			if voice.ID == voiceID {
+				return nil
+			}
+		}
+	}
+
+	req, err := s.newAPIRequest(ctx, cartesiaVoicesURL+"/"+url.PathEscape(voiceID))
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{Timeout: s.provider.config.Timeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to look up Cartesia voice %s: %w", voiceID, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("Cartesia voice %s does not exist", voiceID)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Cartesia voice lookup failed (status: %d): %s", resp.StatusCode, string(body))
+	}
+}
+
+// newAPIRequest creates an authenticated GET request to the Cartesia REST API
+func (s *CartesiaTTSService) newAPIRequest(ctx context.Context, requestURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-API-Key", s.provider.GetAPIKey())
+	req.Header.Set("Cartesia-Version", cartesiaAPIVersion)
+	return req, nil
+}
+
+// toModel converts an API voice, mapping Cartesia's gender names to the ones used across providers
+func (v cartesiaVoice) toModel() models.Voice {
+	gender := v.Gender
+	switch gender {
+	case "masculine":
+		gender = "male"
+	case "feminine":
+		gender = "female"
+	case "gender_neutral":
+		gender = "neutral"
+	}
+
+	return models.Voice{
+		ID:          v.ID,
+		Name:        v.Name,
+		Language:    v.Language,
+		Gender:      gender,
+		Description: v.Description,
+	}
+}
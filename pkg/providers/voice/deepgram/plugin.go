@@ -7,11 +7,17 @@ import (
 	"io"
 	"time"
 
+	"github.com/creastat/common-go/pkg/audio"
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
 	"github.com/creastat/common-go/pkg/types"
 )
 
+// supportedLanguages lists the languages recognized by Deepgram's multilingual models
+var supportedLanguages = []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko", "hi", "ar"}
+
 // DeepgramProvider implements the Provider interface for Deepgram
 type DeepgramProvider struct {
 	name         string
@@ -60,6 +66,7 @@ func (p *DeepgramProvider) Initialize(ctx context.Context, config models.Provide
 
 	// Store configuration
 	p.config = config
+	providers.WarnUnknownOptions(p, models.OptionScopeProvider, config.Options, p.logger)
 	p.apiKey = config.APIKey
 
 	// Mark as initialized - API key will be validated on first use
@@ -144,6 +151,9 @@ func (p *DeepgramProvider) IsInitialized() bool {
 
 // STTService interface methods (new interface with different signatures)
 func (p *DeepgramProvider) Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error) {
+	ctx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilitySTT)
+	defer cancel()
+
 	// Convert []byte to io.Reader for the old implementation
 	sttService := NewDeepgramSTTService(p)
 	return sttService.Transcribe(ctx, io.NopCloser(bytes.NewReader(audioData)), models.STTConfig{
@@ -151,6 +161,7 @@ func (p *DeepgramProvider) Transcribe(ctx context.Context, audioData []byte, opt
 	})
 }
 
+// StreamTranscribe forwards audio chunks to a streaming client and emits final transcripts
 func (p *DeepgramProvider) StreamTranscribe(ctx context.Context, audioStream <-chan []byte, options map[string]any) (<-chan string, <-chan error) {
 	// Create output channels
 	resultChan := make(chan string)
@@ -160,8 +171,63 @@ func (p *DeepgramProvider) StreamTranscribe(ctx context.Context, audioStream <-c
 		defer close(resultChan)
 		defer close(errChan)
 
-		// For now, return an error as streaming is handled via NewClient
-		errChan <- fmt.Errorf("use NewClient for streaming transcription")
+		sttService := NewDeepgramSTTService(p)
+		client, err := sttService.NewSTTClient(ctx, models.STTConfig{
+			Options: options,
+		})
+		if err != nil {
+			errChan <- fmt.Errorf("failed to create STT client: %w", err)
+			return
+		}
+		defer client.Close()
+
+		// Forward audio until the input closes, then finalize so trailing results are flushed
+		sendErrCh := make(chan error, 1)
+		receiveDone := make(chan struct{})
+		go func() {
+			defer close(sendErrCh)
+			for {
+				select {
+				case audio, ok := <-audioStream:
+					if !ok {
+						if finalizer, ok := client.(interface{ Finalize() error }); ok {
+							if err := finalizer.Finalize(); err != nil {
+								sendErrCh <- err
+							}
+						}
+						return
+					}
+					if err := client.Send(ctx, audio); err != nil {
+						sendErrCh <- fmt.Errorf("failed to send audio: %w", err)
+						return
+					}
+				case <-receiveDone:
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		// Drain results until the stream ends
+		err = client.StreamTo(ctx, func(result *models.STTResult) error {
+			if !result.IsFinal || result.Text == "" {
+				return nil
+			}
+			select {
+			case resultChan <- result.Text:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		close(receiveDone)
+		if sendErr := <-sendErrCh; err == nil {
+			err = sendErr
+		}
+		if err != nil {
+			errChan <- err
+		}
 	}()
 
 	return resultChan, errChan
@@ -200,7 +266,7 @@ func (p *DeepgramProvider) GetProviderInfo() *models.ProviderInfo {
 			Metadata: map[string]any{
 				"sample_rate": 16000,
 				"encoding":    "linear16",
-				"languages":   []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko", "hi", "ar"},
+				"languages":   supportedLanguages,
 			},
 		},
 		{
@@ -236,7 +302,7 @@ func (p *DeepgramProvider) GetProviderInfo() *models.ProviderInfo {
 			Metadata: map[string]any{
 				"sample_rate": 16000,
 				"encoding":    "linear16",
-				"languages":   []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko", "hi", "ar"},
+				"languages":   supportedLanguages,
 			},
 		},
 	}
@@ -253,3 +319,41 @@ func (p *DeepgramProvider) GetProviderInfo() *models.ProviderInfo {
 
 	return info
 }
+
+// SupportedLanguages returns the normalized language codes Deepgram can transcribe
+func (p *DeepgramProvider) SupportedLanguages() []string {
+	return models.NormalizeLanguages(supportedLanguages)
+}
+
+// DescribeCapabilities reports the provider's STT capability; transcription streams over WebSocket
+func (p *DeepgramProvider) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{
+		STT: &models.STTCapability{Streaming: true, Languages: models.NormalizeLanguages(supportedLanguages)},
+	}
+}
+
+// SupportedOptions describes the Options keys the provider honors
+func (p *DeepgramProvider) SupportedOptions() []models.OptionDescriptor {
+	return []models.OptionDescriptor{
+		models.CapabilityTimeoutOption(models.CapabilitySTT),
+		{Name: "channels", Type: models.OptionTypeInt, Scope: models.OptionScopeSTT, Description: "number of audio channels, 1 by default"},
+		{Name: "multichannel", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "transcribe each channel separately"},
+		{Name: "smart_format", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "format numbers, dates and punctuation, enabled by default"},
+		{Name: "diarize", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "label words with speaker indexes"},
+		{Name: "utterance_end_ms", Type: models.OptionTypeInt, Scope: models.OptionScopeSTT, Description: "silence that ends an utterance; enables interim results"},
+		{Name: "vad_events", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "emit speech-started events"},
+		{Name: "filler_words", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "keep filler words such as \"uh\" in transcripts"},
+		{Name: "sentiment", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "add sentiment analysis to result metadata"},
+		{Name: "topics", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "add topic detection to result metadata"},
+		{Name: "intents", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "add intent recognition to result metadata"},
+		{Name: "keywords", Type: models.OptionTypeStringList, Scope: models.OptionScopeSTT, Description: "terms to boost, optionally as term:intensity"},
+		{Name: "keyterms", Type: models.OptionTypeStringList, Scope: models.OptionScopeSTT, Description: "key terms to prompt Nova-3 models with"},
+		{Name: "keepalive_interval_ms", Type: models.OptionTypeInt, Scope: models.OptionScopeSTT, Description: "KeepAlive message interval; zero or less disables keepalives"},
+		wsutil.MaxMessageBytesOption(models.OptionScopeSTT),
+		wsutil.CompressionOption(models.OptionScopeSTT),
+		wsutil.SubprotocolsOption(models.OptionScopeSTT),
+		audio.NormalizeGainOption,
+		audio.AutoResampleOption,
+		audio.TrackSequenceOption,
+	}
+}
@@ -0,0 +1,18 @@
+package deepgram
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSupportedLanguages(t *testing.T) {
+	provider := NewDeepgramProvider(nil)
+	want := []string{"en", "es", "fr", "de", "it", "pt", "nl", "pl", "ru", "zh", "ja", "ko", "hi", "ar"}
+
+	if got := provider.SupportedLanguages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := provider.DescribeCapabilities().STT.Languages; !reflect.DeepEqual(got, want) {
+		t.Errorf("STT capability languages: got %v, want %v", got, want)
+	}
+}
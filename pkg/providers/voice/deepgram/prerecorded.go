@@ -0,0 +1,144 @@
+package deepgram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+)
+
+// prerecordedURL is Deepgram's REST endpoint for pre-recorded audio
+const prerecordedURL = "https://api.deepgram.com/v1/listen"
+
+// TranscribePrerecorded transcribes a complete audio file in a single REST request.
+// Unlike Transcribe it does not stream over the WebSocket, which makes it better suited to large files.
+// When config.Encoding is empty Deepgram detects the container format from the audio itself.
+func (s *DeepgramSTTService) TranscribePrerecorded(ctx context.Context, audio io.Reader, config models.STTConfig) (*models.STTResult, error) {
+	if !s.provider.IsInitialized() {
+		return nil, fmt.Errorf("provider not initialized")
+	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeSTT, config.Options, s.logger)
+
+	if config.Model == "" {
+		config.Model = "nova-3"
+	}
+
+	// Build query parameters
+	query := url.Values{}
+	query.Set("model", config.Model)
+	if config.Language != "" {
+		query.Set("language", config.Language)
+	}
+	if config.PunctuationEnabled {
+		query.Set("punctuate", "true")
+	}
+	if d, ok := config.Options["diarize"].(bool); ok && d {
+		query.Set("diarize", "true")
+	}
+	if sf, ok := config.Options["smart_format"].(bool); ok {
+		query.Set("smart_format", fmt.Sprintf("%t", sf))
+	}
+
+	// Raw audio needs its encoding and sample rate; containers are detected by Deepgram
+	if err := config.ResolveEncoding(""); err != nil {
+		return nil, err
+	}
+	if config.Encoding != "" {
+		encoding, err := models.ResolveProviderEncoding(models.ProviderTypeDeepgram, config.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("encoding", encoding)
+		if config.SampleRate == 0 {
+			config.SampleRate = 16000
+		}
+		query.Set("sample_rate", fmt.Sprintf("%d", config.SampleRate))
+	}
+
+	keywords, err := parseKeywords(stringListOption(config.Options["keywords"]))
+	if err != nil {
+		return nil, err
+	}
+	for _, keyword := range keywords {
+		query.Add("keywords", keyword)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", prerecordedURL+"?"+query.Encode(), audio)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("token %s", s.provider.GetAPIKey()))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	httpClient := &http.Client{Timeout: s.provider.config.Timeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transcribe pre-recorded audio: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("Deepgram pre-recorded transcription failed (status: %d): %s", resp.StatusCode, string(body))
+	}
+
+	var raw map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return parsePrerecordedResponse(raw), nil
+}
+
+// parsePrerecordedResponse converts a pre-recorded response into a final STTResult
+func parsePrerecordedResponse(raw map[string]any) *models.STTResult {
+	result := &models.STTResult{
+		IsFinal:   true,
+		Timestamp: time.Now(),
+		Metadata:  make(map[string]any),
+	}
+
+	if metadata, ok := raw["metadata"].(map[string]any); ok {
+		if duration, ok := metadata["duration"].(float64); ok {
+			result.Duration = duration
+			result.EndTime = duration
+		}
+		if requestID, ok := metadata["request_id"].(string); ok {
+			result.Metadata["request_id"] = requestID
+		}
+	}
+
+	results, ok := raw["results"].(map[string]any)
+	if !ok {
+		return result
+	}
+
+	channels, ok := results["channels"].([]any)
+	if !ok || len(channels) == 0 {
+		return result
+	}
+
+	channel, ok := channels[0].(map[string]any)
+	if !ok {
+		return result
+	}
+
+	if language, ok := channel["detected_language"].(string); ok {
+		result.Language = language
+		result.DetectedLanguage = language
+	}
+
+	if alternatives, ok := channel["alternatives"].([]any); ok && len(alternatives) > 0 {
+		if alt, ok := alternatives[0].(map[string]any); ok {
+			parseAlternative(alt, result)
+		}
+	}
+
+	return result
+}
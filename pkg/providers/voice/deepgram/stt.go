@@ -6,10 +6,17 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/creastat/common-go/pkg/audio"
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
 	"github.com/creastat/common-go/pkg/types"
 
 	"github.com/gorilla/websocket"
@@ -31,9 +38,19 @@ func NewDeepgramSTTService(provider *DeepgramProvider) *DeepgramSTTService {
 
 // NewSTTClient creates a new STT client for streaming audio
 func (s *DeepgramSTTService) NewSTTClient(ctx context.Context, config models.STTConfig) (interfaces.STTClient, error) {
+	client, err := s.newSTTClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newSTTClient creates a new STT client and returns its concrete type
+func (s *DeepgramSTTService) newSTTClient(ctx context.Context, config models.STTConfig) (*deepgramSTTClient, error) {
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeSTT, config.Options, s.logger)
 
 	// Set defaults if not provided
 	if config.Model == "" {
@@ -45,15 +62,77 @@ func (s *DeepgramSTTService) NewSTTClient(ctx context.Context, config models.STT
 	if config.SampleRate == 0 {
 		config.SampleRate = 16000
 	}
-	if config.Encoding == "" {
-		config.Encoding = "linear16"
+	// Bit depth and companding refine the requested encoding
+	if err := config.ResolveEncoding(string(models.AudioEncodingPCM16)); err != nil {
+		return nil, err
+	}
+
+	// Map the requested encoding to Deepgram's native name
+	encoding, err := models.ResolveProviderEncoding(models.ProviderTypeDeepgram, config.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	config.Encoding = encoding
+
+	listenURL, err := buildListenURL(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create WebSocket connection
+	dialer := wsutil.Dialer(config.Options)
+	header := make(map[string][]string)
+	header["Authorization"] = []string{fmt.Sprintf("token %s", s.provider.GetAPIKey())}
+
+	conn, resp, err := dialer.Dial(listenURL, header)
+	if err != nil {
+		if resp != nil {
+			body := make([]byte, 1024)
+			n, _ := resp.Body.Read(body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to connect to Deepgram STT (status: %d): %s - %w", resp.StatusCode, string(body[:n]), err)
+		}
+		return nil, fmt.Errorf("failed to connect to Deepgram STT: %w", err)
+	}
+
+	client := &deepgramSTTClient{
+		conn:            conn,
+		config:          config,
+		resultCh:        make(chan *models.STTResult, 10),
+		errCh:           make(chan error, 1),
+		lc:              lifecycle.New(),
+		logger:          s.logger,
+		maxMessageBytes: wsutil.ApplyReadLimit(conn, config.Options),
+		streamStart:     time.Now(),
 	}
 
-	// Map generic "raw" encoding to Deepgram's "linear16"
-	if config.Encoding == "raw" {
-		config.Encoding = "linear16"
+	if audio.IsPCM16(config.Encoding) {
+		client.gain = audio.GainNormalizerFromOption(config.Options["normalize_gain"])
+		client.resampler = audio.ResamplerFromOption(config.Options["auto_resample"], config.SampleRate, config.Channels)
 	}
+	client.sequence = audio.SequenceTrackerFromOption(config.Options["track_sequence"], config.Encoding, config.SampleRate, config.Channels)
+
+	s.logger.Debug("Connected to Deepgram STT",
+		"model", config.Model,
+		"language", config.Language,
+		"sample_rate", config.SampleRate,
+		"encoding", config.Encoding,
+	)
+
+	// Start reading messages in background
+	client.lc.Go(client.readMessages)
 
+	// Keep the socket open through long silences
+	if interval := keepAliveInterval(config.Options); interval > 0 {
+		go client.keepAlive(interval)
+	}
+
+	return client, nil
+}
+
+// buildListenURL builds the streaming endpoint URL with the query parameters for config. It enables
+// interim results on config when utterance_end_ms requires them.
+func buildListenURL(config *models.STTConfig) (string, error) {
 	// Extract Deepgram-specific options
 	channels := 1
 	multichannel := false
@@ -61,6 +140,10 @@ func (s *DeepgramSTTService) NewSTTClient(ctx context.Context, config models.STT
 	diarize := false
 	utteranceEndMs := 0 // Disabled by default (requires interim_results)
 	vadEvents := false  // Disabled by default
+	fillerWords := false
+	sentiment := false
+	topics := false
+	intents := false
 
 	if config.Options != nil {
 		if ch, ok := config.Options["channels"].(int); ok {
@@ -81,6 +164,18 @@ func (s *DeepgramSTTService) NewSTTClient(ctx context.Context, config models.STT
 		if ve, ok := config.Options["vad_events"].(bool); ok {
 			vadEvents = ve
 		}
+		if fw, ok := config.Options["filler_words"].(bool); ok {
+			fillerWords = fw
+		}
+		if st, ok := config.Options["sentiment"].(bool); ok {
+			sentiment = st
+		}
+		if tp, ok := config.Options["topics"].(bool); ok {
+			topics = tp
+		}
+		if in, ok := config.Options["intents"].(bool); ok {
+			intents = in
+		}
 	}
 
 	// If utterance_end_ms is set, interim_results must be enabled
@@ -110,6 +205,20 @@ func (s *DeepgramSTTService) NewSTTClient(ctx context.Context, config models.STT
 		query.Set("vad_events", "true")
 	}
 
+	// Audio intelligence add-ons
+	if fillerWords {
+		query.Set("filler_words", "true")
+	}
+	if sentiment {
+		query.Set("sentiment", "true")
+	}
+	if topics {
+		query.Set("topics", "true")
+	}
+	if intents {
+		query.Set("intents", "true")
+	}
+
 	if config.Language != "" {
 		query.Set("language", config.Language)
 	}
@@ -118,55 +227,105 @@ func (s *DeepgramSTTService) NewSTTClient(ctx context.Context, config models.STT
 		query.Set("punctuate", "true")
 	}
 
+	// Keyword boosting (keywords) and Nova-3 key term prompting (keyterm)
+	keywords, err := parseKeywords(stringListOption(config.Options["keywords"]))
+	if err != nil {
+		return "", err
+	}
+	for _, keyword := range keywords {
+		query.Add("keywords", keyword)
+	}
+	for _, keyterm := range stringListOption(config.Options["keyterms"]) {
+		if keyterm = strings.TrimSpace(keyterm); keyterm != "" {
+			query.Add("keyterm", keyterm)
+		}
+	}
+
 	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
 
-	// Create WebSocket connection
-	dialer := websocket.DefaultDialer
-	header := make(map[string][]string)
-	header["Authorization"] = []string{fmt.Sprintf("token %s", s.provider.GetAPIKey())}
+// defaultKeepAliveInterval is how often KeepAlive messages are sent when keepalive_interval_ms is not set
+const defaultKeepAliveInterval = 8 * time.Second
 
-	conn, resp, err := dialer.Dial(u.String(), header)
-	if err != nil {
-		if resp != nil {
-			body := make([]byte, 1024)
-			n, _ := resp.Body.Read(body)
-			resp.Body.Close()
-			return nil, fmt.Errorf("failed to connect to Deepgram STT (status: %d): %s - %w", resp.StatusCode, string(body[:n]), err)
-		}
-		return nil, fmt.Errorf("failed to connect to Deepgram STT: %w", err)
+// keepAliveInterval reads keepalive_interval_ms from the options; a non-positive value disables keepalives
+func keepAliveInterval(options map[string]any) time.Duration {
+	switch ms := options["keepalive_interval_ms"].(type) {
+	case int:
+		return time.Duration(ms) * time.Millisecond
+	case float64:
+		return time.Duration(ms) * time.Millisecond
+	default:
+		return defaultKeepAliveInterval
 	}
+}
 
-	client := &deepgramSTTClient{
-		conn:     conn,
-		config:   config,
-		resultCh: make(chan *models.STTResult, 10),
-		errCh:    make(chan error, 1),
-		doneCh:   make(chan struct{}),
-		closed:   false,
-		logger:   s.logger,
+// stringListOption reads a list of strings from an option that may be []string or []any
+func stringListOption(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				list = append(list, str)
+			}
+		}
+		return list
+	default:
+		return nil
 	}
+}
 
-	s.logger.Debug("Connected to Deepgram STT",
-		"model", config.Model,
-		"language", config.Language,
-		"sample_rate", config.SampleRate,
-		"encoding", config.Encoding,
-	)
+// parseKeywords validates keywords of the form "term" or "term:intensity"
+func parseKeywords(keywords []string) ([]string, error) {
+	parsed := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword == "" {
+			continue
+		}
 
-	// Start reading messages in background
-	go client.readMessages()
+		if idx := strings.LastIndex(keyword, ":"); idx >= 0 {
+			term, intensity := strings.TrimSpace(keyword[:idx]), strings.TrimSpace(keyword[idx+1:])
+			if term == "" {
+				return nil, fmt.Errorf("invalid Deepgram keyword %q: empty term", keyword)
+			}
+			if _, err := strconv.ParseFloat(intensity, 64); err != nil {
+				return nil, fmt.Errorf("invalid Deepgram keyword %q: intensity %q is not a number", keyword, intensity)
+			}
+			keyword = term + ":" + intensity
+		}
 
-	return client, nil
+		parsed = append(parsed, keyword)
+	}
+
+	return parsed, nil
 }
 
 // Transcribe transcribes audio data to text (non-streaming)
 func (s *DeepgramSTTService) Transcribe(ctx context.Context, audio io.Reader, config models.STTConfig) (string, error) {
 	// Create a streaming client
-	client, err := s.NewSTTClient(ctx, config)
+	client, err := s.newSTTClient(ctx, config)
 	if err != nil {
 		return "", fmt.Errorf("failed to create STT client: %w", err)
 	}
-	defer client.Close()
+
+	return client.transcribe(ctx, audio)
+}
+
+// transcribe streams audio through the client and returns the final transcript, closing the
+// client when done. A cancelled transcription aborts rather than waiting out the grace period
+// for trailing results.
+func (c *deepgramSTTClient) transcribe(ctx context.Context, audio io.Reader) (string, error) {
+	defer func() {
+		if ctx.Err() != nil {
+			c.abort()
+			return
+		}
+		c.Close()
+	}()
 
 	// Channel to collect results
 	resultCh := make(chan string, 1)
@@ -176,7 +335,7 @@ func (s *DeepgramSTTService) Transcribe(ctx context.Context, audio io.Reader, co
 	go func() {
 		var fullText string
 		for {
-			result, err := client.Receive(ctx)
+			result, err := c.Receive(ctx)
 			if err != nil {
 				if err == io.EOF {
 					resultCh <- fullText
@@ -196,6 +355,13 @@ func (s *DeepgramSTTService) Transcribe(ctx context.Context, audio io.Reader, co
 	buffer := make([]byte, 4096)
 	totalBytes := 0
 	for {
+		// Stop sending promptly on cancel; the deferred abort tears the stream down
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		default:
+		}
+
 		n, err := audio.Read(buffer)
 		if err != nil {
 			if err == io.EOF {
@@ -206,18 +372,15 @@ func (s *DeepgramSTTService) Transcribe(ctx context.Context, audio io.Reader, co
 
 		if n > 0 {
 			totalBytes += n
-			if err := client.Send(ctx, buffer[:n]); err != nil {
+			if err := c.Send(ctx, buffer[:n]); err != nil {
 				return "", fmt.Errorf("failed to send audio: %w", err)
 			}
 		}
 	}
 
 	// Finalize to signal end of audio stream
-	// Cast to concrete type to access Finalize method
-	if deepgramClient, ok := client.(*deepgramSTTClient); ok {
-		if err := deepgramClient.Finalize(); err != nil {
-			return "", fmt.Errorf("failed to finalize audio stream: %w", err)
-		}
+	if err := c.Finalize(); err != nil {
+		return "", fmt.Errorf("failed to finalize audio stream: %w", err)
 	}
 
 	// Wait for results
@@ -261,14 +424,20 @@ func (s *DeepgramSTTService) GetModels(ctx context.Context) ([]models.Model, err
 
 // deepgramSTTClient implements the STTClient interface
 type deepgramSTTClient struct {
-	conn     *websocket.Conn
-	config   models.STTConfig
-	resultCh chan *models.STTResult
-	errCh    chan error
-	doneCh   chan struct{}
-	mu       sync.Mutex
-	closed   bool
-	logger   types.Logger
+	conn      *websocket.Conn
+	config    models.STTConfig
+	gain      *audio.GainNormalizer  // optional pre-Send normalization
+	resampler *audio.Resampler       // optional pre-Send sample rate conversion
+	sequence  *audio.SequenceTracker // optional sent-audio gap detection
+	resultCh  chan *models.STTResult
+	errCh     chan error
+	lc        *lifecycle.Lifecycle
+	mu        sync.Mutex // serializes writes to conn
+	finalized bool
+	logger    types.Logger
+
+	maxMessageBytes int64     // read limit applied to conn
+	streamStart     time.Time // when the stream was opened; result offsets are relative to it
 }
 
 // Send sends audio data to the STT service
@@ -276,53 +445,108 @@ func (c *deepgramSTTClient) Send(ctx context.Context, audio []byte) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() || c.finalized {
 		return fmt.Errorf("STT client is closed")
 	}
 
+	// Convert input at the auto_resample rate to the configured sample rate; a chunk too short to
+	// yield a frame is held until the next one
+	audio = c.resampler.Apply(audio)
+	if c.resampler != nil && len(audio) == 0 {
+		return nil
+	}
+
+	// Amplify quiet input when normalize_gain is set
+	audio = c.gain.Apply(audio)
+
 	if err := c.conn.WriteMessage(websocket.BinaryMessage, audio); err != nil {
 		return fmt.Errorf("failed to send audio: %w", err)
 	}
+	c.sequence.RecordSend(len(audio))
 
 	return nil
 }
 
 // Receive receives transcription results from the STT service
 func (c *deepgramSTTClient) Receive(ctx context.Context) (*models.STTResult, error) {
-	select {
-	case result := <-c.resultCh:
-		return result, nil
-	case err := <-c.errCh:
-		return nil, err
-	case <-c.doneCh:
-		return nil, io.EOF
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
+	return lifecycle.Receive(ctx, c.lc, c.resultCh, c.errCh)
+}
+
+// StreamTo invokes handler for each transcription result until the stream ends
+func (c *deepgramSTTClient) StreamTo(ctx context.Context, handler func(*models.STTResult) error) error {
+	return lifecycle.StreamTo(ctx, c.Receive, handler, c.Finalize)
+}
+
+// StreamStartTime returns when the stream was opened
+func (c *deepgramSTTClient) StreamStartTime() time.Time {
+	return c.streamStart
 }
 
 // Close closes the STT client and releases resources
 func (c *deepgramSTTClient) Close() error {
+	return c.lc.Close(c.sendCloseStream, c.conn.Close)
+}
+
+// abort closes the client without waiting for trailing results
+func (c *deepgramSTTClient) abort() error {
+	c.lc.Abort()
+	return c.Close()
+}
+
+// Finalize sends a CloseStream message to complete the transcription
+func (c *deepgramSTTClient) Finalize() error {
+	if c.lc.IsClosing() {
+		return fmt.Errorf("STT client is closed")
+	}
+
+	return c.sendCloseStream()
+}
+
+// keepAlive sends KeepAlive messages every interval until the client is closed or the reader exits
+func (c *deepgramSTTClient) keepAlive(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.lc.Closing():
+			return
+		case <-c.lc.Done():
+			return
+		case <-ticker.C:
+			if err := c.sendKeepAlive(); err != nil {
+				c.logger.Debug("Failed to send Deepgram keepalive", "error", err)
+				return
+			}
+		}
+	}
+}
+
+// sendKeepAlive writes a KeepAlive message, serialized with audio writes
+func (c *deepgramSTTClient) sendKeepAlive() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() || c.finalized {
 		return nil
 	}
 
-	c.closed = true
-	close(c.doneCh)
-	return c.conn.Close()
+	if err := c.conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"KeepAlive"}`)); err != nil {
+		return fmt.Errorf("failed to send keepalive message: %w", err)
+	}
+
+	return nil
 }
 
-// Finalize sends a CloseStream message to complete the transcription
-func (c *deepgramSTTClient) Finalize() error {
+// sendCloseStream sends the CloseStream message once
+func (c *deepgramSTTClient) sendCloseStream() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
-		return fmt.Errorf("STT client is closed")
+	if c.finalized {
+		return nil
 	}
+	c.finalized = true
 
 	// Deepgram expects a CloseStream message, not Finalize
 	closeMessage := map[string]any{
@@ -343,42 +567,17 @@ func (c *deepgramSTTClient) Finalize() error {
 
 // readMessages reads messages from STT WebSocket
 func (c *deepgramSTTClient) readMessages() {
-	defer func() {
-		c.mu.Lock()
-		if !c.closed {
-			close(c.doneCh)
-		}
-		c.mu.Unlock()
-	}()
-
 	for {
-		c.mu.Lock()
-		if c.closed {
-			c.mu.Unlock()
-			return
-		}
-		c.mu.Unlock()
-
 		messageType, message, err := c.conn.ReadMessage()
 		if err != nil {
-			c.mu.Lock()
-			wasClosed := c.closed
-			c.mu.Unlock()
-
-			if !wasClosed {
-				// Check if it's a normal close (1000)
-				if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-					// Normal close - just signal done
-					c.Close()
-					return
-				}
+			// A normal close (1000) or a read interrupted by Close just ends the stream
+			if c.lc.IsClosing() || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return
+			}
 
-				// Other errors - send to error channel
-				select {
-				case c.errCh <- fmt.Errorf("STT read error: %w", err):
-				default:
-				}
-				c.Close()
+			select {
+			case c.errCh <- fmt.Errorf("STT read error: %w", wsutil.ReadLimitError(err, c.maxMessageBytes)):
+			default:
 			}
 			return
 		}
@@ -397,6 +596,8 @@ func (c *deepgramSTTClient) readMessages() {
 			case "Results":
 				result := c.parseResultsMessage(rawResult)
 				if result != nil {
+					c.trackSequence(rawResult, result)
+
 					// Log transcript at trace level
 					if result.Text != "" {
 						c.logger.Debug("Deepgram STT result",
@@ -408,13 +609,22 @@ func (c *deepgramSTTClient) readMessages() {
 
 					select {
 					case c.resultCh <- result:
-					case <-c.doneCh:
+					case <-c.lc.Aborted():
 						return
 					}
 				}
 
 			case "Metadata":
-				// Handle metadata separately if needed
+				// Sent once the stream is closed; its duration is the total audio Deepgram received
+				if duration, ok := rawResult["duration"].(float64); ok && c.sequence != nil {
+					c.sequence.ObserveReceived(duration)
+					if gap := c.sequence.Finish(); gap != nil {
+						c.logger.Warn("Deepgram STT received less audio than was sent",
+							"received_secs", gap.Start,
+							"sent_secs", gap.End,
+						)
+					}
+				}
 
 			case "UtteranceEnd":
 				// Handle utterance end
@@ -428,6 +638,27 @@ func (c *deepgramSTTClient) readMessages() {
 	}
 }
 
+// trackSequence checks final results for discontinuities in the audio timeline and
+// annotates the result with the sent-audio counters
+func (c *deepgramSTTClient) trackSequence(raw map[string]any, result *models.STTResult) {
+	if c.sequence == nil {
+		return
+	}
+
+	if result.IsFinal {
+		duration, _ := raw["duration"].(float64)
+		if gap := c.sequence.ObserveFinal(result.StartTime, result.StartTime+duration); gap != nil {
+			result.Metadata["audio_gap"] = *gap
+			c.logger.Warn("Deepgram STT detected an audio gap",
+				"gap_start", gap.Start,
+				"gap_end", gap.End,
+			)
+		}
+	}
+
+	c.sequence.Annotate(result.Metadata)
+}
+
 // parseResultsMessage parses a Results message into STTResult
 func (c *deepgramSTTClient) parseResultsMessage(raw map[string]any) *models.STTResult {
 	result := &models.STTResult{
@@ -465,43 +696,125 @@ func (c *deepgramSTTClient) parseResultsMessage(raw map[string]any) *models.STTR
 	}
 
 	if channelMap != nil {
+		if language, ok := channelMap["detected_language"].(string); ok {
+			result.DetectedLanguage = language
+		}
 		if alternatives, ok := channelMap["alternatives"].([]any); ok && len(alternatives) > 0 {
 			if alt, ok := alternatives[0].(map[string]any); ok {
-				// Extract transcript
-				if transcript, ok := alt["transcript"].(string); ok {
-					result.Text = transcript
-				}
+				parseAlternative(alt, result)
+			}
+		}
+	}
 
-				// Extract confidence
-				if confidence, ok := alt["confidence"].(float64); ok {
-					result.Confidence = confidence
-				}
+	// Extract audio intelligence add-on results
+	c.parseAddOns(raw, result)
 
-				// Extract words with timing information
-				if words, ok := alt["words"].([]any); ok {
-					result.Words = make([]models.WordInfo, 0, len(words))
-					for _, w := range words {
-						if wordMap, ok := w.(map[string]any); ok {
-							word := models.WordInfo{}
-							if wordText, ok := wordMap["word"].(string); ok {
-								word.Word = wordText
-							}
-							if start, ok := wordMap["start"].(float64); ok {
-								word.StartTime = start
-							}
-							if end, ok := wordMap["end"].(float64); ok {
-								word.EndTime = end
-							}
-							if confidence, ok := wordMap["confidence"].(float64); ok {
-								word.Confidence = confidence
-							}
-							result.Words = append(result.Words, word)
-						}
-					}
+	return result
+}
+
+// parseAlternative extracts the transcript, confidence, and word timings from a transcript alternative
+func parseAlternative(alt map[string]any, result *models.STTResult) {
+	// Extract transcript
+	if transcript, ok := alt["transcript"].(string); ok {
+		result.Text = transcript
+	}
+
+	// Extract confidence
+	if confidence, ok := alt["confidence"].(float64); ok {
+		result.Confidence = confidence
+	}
+
+	// Multilingual models list the languages spoken in the alternative, dominant first
+	if languages, ok := alt["languages"].([]any); ok && len(languages) > 0 {
+		if language, ok := languages[0].(string); ok && language != "" {
+			result.DetectedLanguage = language
+		}
+	}
+
+	// Extract words with timing information
+	if words, ok := alt["words"].([]any); ok {
+		result.Words = make([]models.WordInfo, 0, len(words))
+		for _, w := range words {
+			if wordMap, ok := w.(map[string]any); ok {
+				word := models.WordInfo{}
+				if wordText, ok := wordMap["word"].(string); ok {
+					word.Word = wordText
+				}
+				if start, ok := wordMap["start"].(float64); ok {
+					word.StartTime = start
+				}
+				if end, ok := wordMap["end"].(float64); ok {
+					word.EndTime = end
+				}
+				if confidence, ok := wordMap["confidence"].(float64); ok {
+					word.Confidence = confidence
+				}
+				if speaker, ok := wordMap["speaker"].(float64); ok {
+					word.Speaker = int(speaker)
 				}
+				result.Words = append(result.Words, word)
 			}
 		}
 	}
+}
 
-	return result
+// parseAddOns extracts sentiment, topics, and intents results into the result metadata
+func (c *deepgramSTTClient) parseAddOns(raw map[string]any, result *models.STTResult) {
+	if sentiments, ok := raw["sentiments"].(map[string]any); ok {
+		if average, ok := sentiments["average"].(map[string]any); ok {
+			if label, ok := average["sentiment"].(string); ok {
+				result.Metadata["sentiment"] = label
+			}
+			if score, ok := average["sentiment_score"].(float64); ok {
+				result.Metadata["sentiment_score"] = score
+			}
+		}
+	}
+
+	if topics := parseSegmentLabels(raw, "topics", "topic"); len(topics) > 0 {
+		result.Metadata["topics"] = topics
+	}
+
+	if intents := parseSegmentLabels(raw, "intents", "intent"); len(intents) > 0 {
+		result.Metadata["intents"] = intents
+	}
+}
+
+// parseSegmentLabels collects unique labels from a Deepgram segmented add-on result
+// such as {"topics": {"segments": [{"topics": [{"topic": "..."}]}]}}
+func parseSegmentLabels(raw map[string]any, field, labelKey string) []string {
+	container, ok := raw[field].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	segments, ok := container["segments"].([]any)
+	if !ok {
+		return nil
+	}
+
+	var labels []string
+	seen := make(map[string]bool)
+	for _, s := range segments {
+		segment, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		items, ok := segment[field].([]any)
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			itemMap, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if label, ok := itemMap[labelKey].(string); ok && label != "" && !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	return labels
 }
@@ -0,0 +1,197 @@
+package deepgram
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
+	"github.com/creastat/common-go/pkg/types"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestSTTClient returns a client streaming to a stub server running handler, with the
+// WebSocket options applied as NewSTTClient does
+func newTestSTTClient(t *testing.T, options map[string]any, handler func(*websocket.Conn)) *deepgramSTTClient {
+	t.Helper()
+
+	conn := voicetest.Dial(t, handler)
+	client := &deepgramSTTClient{
+		conn:            conn,
+		maxMessageBytes: wsutil.ApplyReadLimit(conn, options),
+		resultCh:        make(chan *models.STTResult, 10),
+		errCh:           make(chan error, 1),
+		lc:              lifecycle.New(),
+		logger:          &types.NoOpLogger{},
+		streamStart:     time.Now(),
+	}
+	client.lc.Go(client.readMessages)
+	return client
+}
+
+// closeOnCloseStream ends the stream once the client sends CloseStream, like Deepgram does
+func closeOnCloseStream(conn *websocket.Conn) {
+	if voicetest.ReadUntil(conn, "CloseStream") {
+		voicetest.CloseNormally(conn)
+	}
+}
+
+func TestSTTClientClose(t *testing.T) {
+	client := newTestSTTClient(t, nil, closeOnCloseStream)
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestSTTClientCloseUnresponsiveServer(t *testing.T) {
+	client := newTestSTTClient(t, nil, func(conn *websocket.Conn) {
+		voicetest.ReadUntil(conn, "never sent")
+	})
+	client.lc.GracePeriod = 50 * time.Millisecond
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestSTTClientReportsOversizedMessage(t *testing.T) {
+	client := newTestSTTClient(t, map[string]any{"max_message_bytes": 1024}, func(conn *websocket.Conn) {
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"Results","metadata":"`+strings.Repeat("x", 2048)+`"}`))
+		voicetest.ReadUntil(conn, "CloseStream")
+	})
+	defer client.Close()
+
+	_, err := client.Receive(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "max_message_bytes limit of 1024 bytes") {
+		t.Errorf("expected a read limit error, got %v", err)
+	}
+}
+
+func TestSupportedOptionsDescribeReadLimit(t *testing.T) {
+	options := map[string]any{"max_message_bytes": 1024}
+	if unknown := models.UnknownOptions(NewDeepgramProvider(&types.NoOpLogger{}).SupportedOptions(), models.OptionScopeSTT, options); len(unknown) > 0 {
+		t.Errorf("STT options %v are not described", unknown)
+	}
+}
+
+func TestBuildListenURLAddOns(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		set     map[string]string
+		unset   []string
+	}{
+		{
+			name:  "defaults",
+			unset: []string{"sentiment", "topics", "intents", "filler_words", "utterance_end_ms"},
+		},
+		{
+			name:    "add-ons enabled",
+			options: map[string]any{"sentiment": true, "topics": true, "intents": true, "filler_words": true},
+			set:     map[string]string{"sentiment": "true", "topics": "true", "intents": "true", "filler_words": "true"},
+		},
+		{
+			name:    "add-ons disabled",
+			options: map[string]any{"sentiment": false, "topics": false},
+			unset:   []string{"sentiment", "topics"},
+		},
+		{
+			name:    "utterance end forces interim results",
+			options: map[string]any{"utterance_end_ms": 1000},
+			set:     map[string]string{"utterance_end_ms": "1000", "interim_results": "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := models.STTConfig{Model: "nova-3", Language: "en", SampleRate: 16000, Encoding: "linear16", Options: tt.options}
+			listenURL, err := buildListenURL(&config)
+			if err != nil {
+				t.Fatalf("buildListenURL: %v", err)
+			}
+			u, err := url.Parse(listenURL)
+			if err != nil {
+				t.Fatalf("parse %q: %v", listenURL, err)
+			}
+			query := u.Query()
+
+			if query.Get("model") != "nova-3" || query.Get("encoding") != "linear16" || query.Get("sample_rate") != "16000" {
+				t.Errorf("unexpected base parameters: %s", u.RawQuery)
+			}
+			for key, want := range tt.set {
+				if got := query.Get(key); got != want {
+					t.Errorf("%s: got %q, want %q", key, got, want)
+				}
+			}
+			for _, key := range tt.unset {
+				if query.Has(key) {
+					t.Errorf("%s should not be sent, got %q", key, query.Get(key))
+				}
+			}
+		})
+	}
+}
+
+func TestParseResultsMessageAddOns(t *testing.T) {
+	frame := `{
+		"type": "Results",
+		"is_final": true,
+		"start": 1.5,
+		"duration": 2,
+		"channel": {"alternatives": [{"transcript": "cancel my order", "confidence": 0.9}]},
+		"sentiments": {"average": {"sentiment": "negative", "sentiment_score": -0.6}},
+		"topics": {"segments": [
+			{"topics": [{"topic": "Orders", "confidence_score": 0.8}]},
+			{"topics": [{"topic": "Orders"}, {"topic": "Refunds"}]}
+		]},
+		"intents": {"segments": [{"intents": [{"intent": "Cancel order"}, {"intent": ""}]}]}
+	}`
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(frame), &raw); err != nil {
+		t.Fatalf("decode frame: %v", err)
+	}
+
+	client := &deepgramSTTClient{logger: &types.NoOpLogger{}}
+	result := client.parseResultsMessage(raw)
+
+	if result.Text != "cancel my order" || !result.IsFinal || result.StartTime != 1.5 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if result.Metadata["sentiment"] != "negative" || result.Metadata["sentiment_score"] != -0.6 {
+		t.Errorf("unexpected sentiment %v / %v", result.Metadata["sentiment"], result.Metadata["sentiment_score"])
+	}
+	if topics := result.Metadata["topics"]; !reflect.DeepEqual(topics, []string{"Orders", "Refunds"}) {
+		t.Errorf("expected unique topics in order, got %v", topics)
+	}
+	if intents := result.Metadata["intents"]; !reflect.DeepEqual(intents, []string{"Cancel order"}) {
+		t.Errorf("expected the non-empty intent, got %v", intents)
+	}
+
+	// Frames without add-ons carry no add-on metadata
+	plain := client.parseResultsMessage(map[string]any{"is_final": true})
+	for _, key := range []string{"sentiment", "topics", "intents"} {
+		if _, ok := plain.Metadata[key]; ok {
+			t.Errorf("unexpected %s metadata without the add-on", key)
+		}
+	}
+}
+
+func TestTranscribeReturnsPromptlyOnCancel(t *testing.T) {
+	client := newTestSTTClient(t, nil, func(conn *websocket.Conn) {
+		voicetest.ReadUntil(conn, "never sent")
+	})
+	client.lc.GracePeriod = time.Minute
+
+	voicetest.CheckPromptCancel(t, client.transcribe)
+}
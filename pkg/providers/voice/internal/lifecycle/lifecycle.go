@@ -0,0 +1,183 @@
+package lifecycle
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultGracePeriod is how long Close waits for the reader to drain after finalizing
+// before it forcibly closes the underlying connection
+const DefaultGracePeriod = 2 * time.Second
+
+// Lifecycle coordinates the teardown of a streaming STT/TTS client.
+//
+// Close runs exactly once in a fixed order:
+//  1. signal closing so further sends are rejected
+//  2. finalize the stream (e.g. CloseSend or an end-of-stream message)
+//  3. wait up to GracePeriod for the reader to deliver trailing results and exit
+//  4. signal abort so a reader blocked on delivery gives up
+//  5. close the connection, which unblocks a reader stuck on a read
+//  6. wait for the reader to exit and signal done
+//
+// The reader goroutine must never call Close itself; it simply returns,
+// which signals done so Receive reports io.EOF.
+type Lifecycle struct {
+	// GracePeriod bounds the wait for the reader after finalizing
+	GracePeriod time.Duration
+
+	closing   chan struct{}
+	aborted   chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	abortOnce sync.Once
+	doneOnce  sync.Once
+	started   atomic.Bool
+	wg        sync.WaitGroup
+	closeErr  error
+}
+
+// New creates a new client lifecycle
+func New() *Lifecycle {
+	return &Lifecycle{
+		GracePeriod: DefaultGracePeriod,
+		closing:     make(chan struct{}),
+		aborted:     make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Go starts the reader goroutine. Done is signalled when it returns.
+func (l *Lifecycle) Go(reader func()) {
+	l.started.Store(true)
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer l.markDone()
+		reader()
+	}()
+}
+
+// Closing returns a channel that is closed once Close has been called
+func (l *Lifecycle) Closing() <-chan struct{} {
+	return l.closing
+}
+
+// Aborted returns a channel that is closed once the grace period is over or Abort is called.
+// Readers select on it when delivering results so they never block teardown.
+func (l *Lifecycle) Aborted() <-chan struct{} {
+	return l.aborted
+}
+
+// Done returns a channel that is closed once the reader has exited
+func (l *Lifecycle) Done() <-chan struct{} {
+	return l.done
+}
+
+// IsClosing reports whether Close has been called
+func (l *Lifecycle) IsClosing() bool {
+	select {
+	case <-l.closing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close tears the client down. finalize may be nil when the protocol has no
+// end-of-stream signal; closeConn must release the underlying connection.
+// It is safe to call Close multiple times and concurrently with Receive.
+func (l *Lifecycle) Close(finalize func() error, closeConn func() error) error {
+	l.closeOnce.Do(func() {
+		close(l.closing)
+
+		if finalize != nil {
+			// Best effort: the connection may already be gone
+			_ = finalize()
+
+			if l.started.Load() {
+				select {
+				case <-l.done:
+				case <-l.aborted:
+				case <-time.After(l.GracePeriod):
+				}
+			}
+		}
+		l.Abort()
+
+		if closeConn != nil {
+			l.closeErr = closeConn()
+		}
+
+		l.wg.Wait()
+		l.markDone()
+	})
+
+	return l.closeErr
+}
+
+// Abort ends the grace period early so a pending or in-progress Close
+// tears the connection down without waiting for trailing results
+func (l *Lifecycle) Abort() {
+	l.abortOnce.Do(func() {
+		close(l.aborted)
+	})
+}
+
+// markDone closes the done channel once
+func (l *Lifecycle) markDone() {
+	l.doneOnce.Do(func() {
+		close(l.done)
+	})
+}
+
+// Receive waits for the next result, error, or end of stream.
+// Results and errors delivered before the reader exited are returned ahead of io.EOF.
+func Receive[T any](ctx context.Context, l *Lifecycle, results <-chan T, errs <-chan error) (T, error) {
+	var zero T
+
+	select {
+	case result := <-results:
+		return result, nil
+	case err := <-errs:
+		return zero, err
+	case <-l.Done():
+		select {
+		case result := <-results:
+			return result, nil
+		default:
+		}
+		select {
+		case err := <-errs:
+			return zero, err
+		default:
+		}
+		return zero, io.EOF
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// StreamTo pushes each received value to handler until end of stream.
+// It returns nil on io.EOF and otherwise the terminal error. When handler returns
+// an error the stream is finalized and that error is returned.
+func StreamTo[T any](ctx context.Context, receive func(context.Context) (T, error), handler func(T) error, finalize func() error) error {
+	for {
+		value, err := receive(ctx)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if err := handler(value); err != nil {
+			if finalize != nil {
+				_ = finalize()
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// sliceReceiver returns values in order, then end, the terminal error
+func sliceReceiver(values []int, end error) (func(context.Context) (int, error), *int) {
+	calls := 0
+	return func(ctx context.Context) (int, error) {
+		calls++
+		if calls > len(values) {
+			return 0, end
+		}
+		return values[calls-1], nil
+	}, &calls
+}
+
+func TestStreamTo(t *testing.T) {
+	errStream := errors.New("stream failed")
+	errHandler := errors.New("handler failed")
+
+	tests := []struct {
+		name      string
+		end       error
+		failOn    int
+		wantErr   error
+		handled   []int
+		receives  int
+		finalized bool
+	}{
+		{name: "end of stream", end: io.EOF, handled: []int{1, 2, 3}, receives: 4},
+		{name: "stream error", end: errStream, wantErr: errStream, handled: []int{1, 2, 3}, receives: 4},
+		{name: "handler error", end: io.EOF, failOn: 2, wantErr: errHandler, handled: []int{1, 2}, receives: 2, finalized: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receive, receives := sliceReceiver([]int{1, 2, 3}, tt.end)
+			var handled []int
+			handler := func(v int) error {
+				handled = append(handled, v)
+				if v == tt.failOn {
+					return errHandler
+				}
+				return nil
+			}
+			finalized := false
+			finalize := func() error {
+				finalized = true
+				return nil
+			}
+
+			err := StreamTo(context.Background(), receive, handler, finalize)
+			if err != tt.wantErr {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(handled, tt.handled) {
+				t.Errorf("handled %v, want %v", handled, tt.handled)
+			}
+			if *receives != tt.receives {
+				t.Errorf("expected %d receives, got %d", tt.receives, *receives)
+			}
+			if finalized != tt.finalized {
+				t.Errorf("finalized: got %v, want %v", finalized, tt.finalized)
+			}
+		})
+	}
+
+	// A nil finalize is allowed when the handler fails
+	receive, _ := sliceReceiver([]int{1}, io.EOF)
+	if err := StreamTo(context.Background(), receive, func(int) error { return errHandler }, nil); err != errHandler {
+		t.Errorf("got %v, want %v", err, errHandler)
+	}
+}
+
+func TestReceiveDrainsBeforeEOF(t *testing.T) {
+	l := New()
+	results := make(chan int, 1)
+	errs := make(chan error, 1)
+	results <- 7
+	l.Go(func() {})
+	<-l.Done()
+
+	if v, err := Receive(context.Background(), l, results, errs); err != nil || v != 7 {
+		t.Errorf("expected the buffered result, got %v, %v", v, err)
+	}
+	if _, err := Receive(context.Background(), l, results, errs); err != io.EOF {
+		t.Errorf("expected io.EOF once drained, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package voicecache
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// DefaultTTL is how long a fetched voice catalog is reused when voice_cache_ttl_secs is not set
+const DefaultTTL = time.Hour
+
+// Cache holds the last fetched voice catalog until it expires. The zero value is an empty cache.
+type Cache struct {
+	mu        sync.Mutex
+	voices    []models.Voice
+	expiresAt time.Time
+}
+
+// Get returns a copy of the cached voices while they are fresh
+func (c *Cache) Get() ([]models.Voice, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.voices == nil || time.Now().After(c.expiresAt) {
+		return nil, false
+	}
+	return slices.Clone(c.voices), true
+}
+
+// Set caches voices for ttl
+func (c *Cache) Set(voices []models.Voice, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.voices = slices.Clone(voices)
+	c.expiresAt = time.Now().Add(ttl)
+}
+
+// TTLOption describes the "voice_cache_ttl_secs" provider option read by TTL
+var TTLOption = models.OptionDescriptor{
+	Name:        "voice_cache_ttl_secs",
+	Type:        models.OptionTypeNumber,
+	Scope:       models.OptionScopeProvider,
+	Description: "seconds the fetched voice catalog is reused, an hour by default",
+}
+
+// TTL reads the "voice_cache_ttl_secs" option, falling back to DefaultTTL
+func TTL(options map[string]any) time.Duration {
+	switch secs := options["voice_cache_ttl_secs"].(type) {
+	case int:
+		if secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	case float64:
+		if secs > 0 {
+			return time.Duration(secs * float64(time.Second))
+		}
+	}
+	return DefaultTTL
+}
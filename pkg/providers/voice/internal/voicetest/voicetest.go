@@ -0,0 +1,205 @@
+// Package voicetest provides WebSocket servers and teardown checks for testing the streaming
+// STT/TTS clients against local stubs.
+package voicetest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// upgrader accepts every origin and negotiates compression when the client offers it
+var upgrader = websocket.Upgrader{
+	CheckOrigin:       func(*http.Request) bool { return true },
+	EnableCompression: true,
+}
+
+// handle upgrades each request and runs handler on the connection, closing it afterwards
+func handle(t testing.TB, handler func(*websocket.Conn)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	})
+}
+
+// NewServer starts a WebSocket server running handler for each connection and returns its ws:// URL
+func NewServer(t testing.TB, handler func(*websocket.Conn)) string {
+	t.Helper()
+
+	server := httptest.NewServer(handle(t, handler))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// Dial starts a WebSocket server running handler and returns a client connection to it
+func Dial(t testing.TB, handler func(*websocket.Conn)) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial(NewServer(t, handler), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// Redirect starts a TLS WebSocket server running handler and points dialer at it, so a client
+// dialing a provider's fixed wss:// URL reaches the stub instead
+func Redirect(t testing.TB, dialer *websocket.Dialer, handler func(*websocket.Conn)) {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handle(t, handler))
+	t.Cleanup(server.Close)
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	// The test certificate is issued for example.com
+	tlsConfig.ServerName = "example.com"
+	dialer.TLSClientConfig = tlsConfig
+	dialer.NetDialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, network, server.Listener.Addr().String())
+	}
+}
+
+// ReadUntil reads messages until one contains marker or the connection fails, and reports
+// whether the marker was seen
+func ReadUntil(conn *websocket.Conn, marker string) bool {
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return false
+		}
+		if strings.Contains(string(message), marker) {
+			return true
+		}
+	}
+}
+
+// CloseNormally sends a normal closure frame, as providers do once a stream has finished
+func CloseNormally(conn *websocket.Conn) {
+	conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+}
+
+// closeTimeout bounds how long CheckClose waits for Close and Receive to return
+const closeTimeout = 5 * time.Second
+
+// CheckClose calls Close twice from concurrent goroutines while others block in receive, then
+// once more, and checks that every call returns and that each receiver ends with io.EOF.
+// Run it with -race to catch unsynchronized teardown.
+func CheckClose(t testing.TB, closeClient func() error, receive func(context.Context) error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+
+	const receivers = 3
+	receiveErrs := make(chan error, receivers)
+	for range receivers {
+		go func() {
+			for {
+				if err := receive(ctx); err != nil {
+					receiveErrs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	closeErrs := make([]error, 2)
+	for i := range closeErrs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			closeErrs[i] = closeClient()
+		}()
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-ctx.Done():
+		t.Fatal("concurrent Close calls did not return")
+	}
+
+	if !errors.Is(closeErrs[0], closeErrs[1]) {
+		t.Errorf("concurrent Close calls returned different errors: %v and %v", closeErrs[0], closeErrs[1])
+	}
+	if err := closeClient(); !errors.Is(err, closeErrs[0]) {
+		t.Errorf("repeated Close returned %v, want %v", err, closeErrs[0])
+	}
+
+	for range receivers {
+		select {
+		case err := <-receiveErrs:
+			if err != io.EOF {
+				t.Errorf("expected Receive to end with io.EOF, got %v", err)
+			}
+		case <-ctx.Done():
+			t.Fatal("Receive did not return after Close")
+		}
+	}
+
+	if err := receive(ctx); err != io.EOF {
+		t.Errorf("expected Receive after Close to return io.EOF, got %v", err)
+	}
+}
+
+// cancellingReader yields endless silence and cancels a context on its first read
+type cancellingReader struct {
+	cancel context.CancelFunc
+}
+
+func (r cancellingReader) Read(p []byte) (int, error) {
+	r.cancel()
+	clear(p)
+	return len(p), nil
+}
+
+// CancellingReader returns an audio reader that cancels the caller's context partway through,
+// as a caller abandoning a long file would
+func CancellingReader(cancel context.CancelFunc) io.Reader {
+	return cancellingReader{cancel: cancel}
+}
+
+// CheckPromptCancel runs transcribe with a context cancelled mid-file and checks that it returns
+// context.Canceled within closeTimeout, well before a grace period of a minute would end
+func CheckPromptCancel(t testing.TB, transcribe func(ctx context.Context, audio io.Reader) (string, error)) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := transcribe(ctx, CancellingReader(cancel))
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(closeTimeout):
+		t.Fatal("transcription did not return promptly after cancel")
+	}
+}
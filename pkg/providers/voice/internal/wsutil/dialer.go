@@ -0,0 +1,51 @@
+package wsutil
+
+import (
+	"github.com/creastat/common-go/pkg/models"
+
+	"github.com/gorilla/websocket"
+)
+
+// CompressionOption describes the "ws_compression" option read by Dialer for a scope
+func CompressionOption(scope models.OptionScope) models.OptionDescriptor {
+	return models.OptionDescriptor{
+		Name:        "ws_compression",
+		Type:        models.OptionTypeBool,
+		Scope:       scope,
+		Description: "offer permessage-deflate compression; used only if the server accepts it",
+	}
+}
+
+// SubprotocolsOption describes the "ws_subprotocols" option read by Dialer for a scope
+func SubprotocolsOption(scope models.OptionScope) models.OptionDescriptor {
+	return models.OptionDescriptor{
+		Name:        "ws_subprotocols",
+		Type:        models.OptionTypeStringList,
+		Scope:       scope,
+		Description: "WebSocket subprotocols to request, in order of preference",
+	}
+}
+
+// Dialer returns a copy of the default dialer configured from the "ws_compression" and
+// "ws_subprotocols" options. Compression is negotiated, so servers without it still connect.
+func Dialer(options map[string]any) *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	dialer.EnableCompression, _ = options["ws_compression"].(bool)
+
+	switch v := options["ws_subprotocols"].(type) {
+	case []string:
+		dialer.Subprotocols = append([]string(nil), v...)
+	case []any:
+		for _, item := range v {
+			if protocol, ok := item.(string); ok && protocol != "" {
+				dialer.Subprotocols = append(dialer.Subprotocols, protocol)
+			}
+		}
+	case string:
+		if v != "" {
+			dialer.Subprotocols = []string{v}
+		}
+	}
+
+	return &dialer
+}
@@ -0,0 +1,71 @@
+package wsutil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDialerNegotiatesCompression(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    map[string]any
+		compressed bool
+	}{
+		{name: "unset", options: nil},
+		{name: "disabled", options: map[string]any{"ws_compression": false}},
+		{name: "enabled", options: map[string]any{"ws_compression": true}, compressed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialer := Dialer(tt.options)
+			if dialer.EnableCompression != tt.compressed {
+				t.Errorf("EnableCompression: got %v, want %v", dialer.EnableCompression, tt.compressed)
+			}
+
+			url := voicetest.NewServer(t, func(conn *websocket.Conn) {
+				voicetest.ReadUntil(conn, "never sent")
+			})
+			conn, resp, err := dialer.Dial(url, nil)
+			if err != nil {
+				t.Fatalf("dial: %v", err)
+			}
+			defer conn.Close()
+
+			extensions := resp.Header.Get("Sec-WebSocket-Extensions")
+			if got := strings.Contains(extensions, "permessage-deflate"); got != tt.compressed {
+				t.Errorf("expected compression negotiated %v, got extensions %q", tt.compressed, extensions)
+			}
+		})
+	}
+}
+
+func TestDialerSubprotocols(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		want    []string
+	}{
+		{name: "unset", options: nil},
+		{name: "string", options: map[string]any{"ws_subprotocols": "v1"}, want: []string{"v1"}},
+		{name: "string list", options: map[string]any{"ws_subprotocols": []string{"v2", "v1"}}, want: []string{"v2", "v1"}},
+		{name: "decoded list", options: map[string]any{"ws_subprotocols": []any{"v2", "", 3, "v1"}}, want: []string{"v2", "v1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Dialer(tt.options).Subprotocols; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if websocket.DefaultDialer.EnableCompression || websocket.DefaultDialer.Subprotocols != nil {
+		t.Error("the default dialer was modified")
+	}
+}
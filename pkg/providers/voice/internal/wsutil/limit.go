@@ -0,0 +1,60 @@
+package wsutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/creastat/common-go/pkg/models"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultMaxMessageBytes bounds a single incoming WebSocket message when
+// config.Options["max_message_bytes"] is not set. It is large enough for
+// long synthesized audio frames while still protecting against runaway peers.
+const DefaultMaxMessageBytes int64 = 16 << 20
+
+// MaxMessageBytesOption describes the "max_message_bytes" option read by MaxMessageBytes for a scope
+func MaxMessageBytesOption(scope models.OptionScope) models.OptionDescriptor {
+	return models.OptionDescriptor{
+		Name:        "max_message_bytes",
+		Type:        models.OptionTypeInt,
+		Scope:       scope,
+		Description: "largest incoming WebSocket message accepted, 16 MiB by default",
+	}
+}
+
+// MaxMessageBytes reads the "max_message_bytes" option, falling back to DefaultMaxMessageBytes
+func MaxMessageBytes(options map[string]any) int64 {
+	switch v := options["max_message_bytes"].(type) {
+	case int:
+		if v > 0 {
+			return int64(v)
+		}
+	case int64:
+		if v > 0 {
+			return v
+		}
+	case float64:
+		if v > 0 {
+			return int64(v)
+		}
+	}
+	return DefaultMaxMessageBytes
+}
+
+// ApplyReadLimit sets the read limit on conn from the options and returns the limit applied
+func ApplyReadLimit(conn *websocket.Conn, options map[string]any) int64 {
+	limit := MaxMessageBytes(options)
+	conn.SetReadLimit(limit)
+	return limit
+}
+
+// ReadLimitError explains a read error caused by a message larger than limit.
+// Other errors are returned unchanged.
+func ReadLimitError(err error, limit int64) error {
+	if errors.Is(err, websocket.ErrReadLimit) {
+		return fmt.Errorf("message exceeds max_message_bytes limit of %d bytes: %w", limit, err)
+	}
+	return err
+}
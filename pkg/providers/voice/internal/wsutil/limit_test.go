@@ -0,0 +1,76 @@
+package wsutil
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestMaxMessageBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		want    int64
+	}{
+		{name: "unset", want: DefaultMaxMessageBytes},
+		{name: "int", options: map[string]any{"max_message_bytes": 1024}, want: 1024},
+		{name: "int64", options: map[string]any{"max_message_bytes": int64(2048)}, want: 2048},
+		{name: "JSON number", options: map[string]any{"max_message_bytes": float64(4096)}, want: 4096},
+		{name: "non-positive", options: map[string]any{"max_message_bytes": 0}, want: DefaultMaxMessageBytes},
+	}
+	for _, tt := range tests {
+		if got := MaxMessageBytes(tt.options); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyReadLimit(t *testing.T) {
+	const limit = 1 << 20
+	options := map[string]any{"max_message_bytes": limit}
+
+	tests := []struct {
+		name    string
+		size    int
+		wantErr bool
+	}{
+		{name: "large frame within the limit", size: limit},
+		{name: "frame over the limit", size: limit + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := bytes.Repeat([]byte{'a'}, tt.size)
+			conn := voicetest.Dial(t, func(conn *websocket.Conn) {
+				conn.WriteMessage(websocket.BinaryMessage, frame)
+				voicetest.ReadUntil(conn, "never sent")
+			})
+
+			applied := ApplyReadLimit(conn, options)
+			if applied != limit {
+				t.Fatalf("applied limit %d, want %d", applied, limit)
+			}
+
+			_, message, err := conn.ReadMessage()
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("ReadMessage: %v", err)
+				}
+				if len(message) != tt.size {
+					t.Errorf("read %d bytes, want %d", len(message), tt.size)
+				}
+				return
+			}
+
+			err = ReadLimitError(err, applied)
+			if !errors.Is(err, websocket.ErrReadLimit) || !strings.Contains(err.Error(), "max_message_bytes limit of 1048576 bytes") {
+				t.Errorf("expected a clear read limit error, got %v", err)
+			}
+		})
+	}
+}
@@ -3,13 +3,20 @@ package minimax
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicecache"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
 	"github.com/creastat/common-go/pkg/types"
 )
 
+// supportedLanguages lists the languages supported by MiniMax TTS models
+var supportedLanguages = []string{"en", "zh"}
+
 // MinimaxProvider implements the Provider interface for MiniMax
 type MinimaxProvider struct {
 	name         string
@@ -18,6 +25,7 @@ type MinimaxProvider struct {
 	capabilities []types.Capability
 	initialized  bool
 	logger       types.Logger
+	voiceCache   voicecache.Cache // live voice catalog shared by every TTS service of the provider
 }
 
 // NewMinimaxProvider creates a new MiniMax provider instance
@@ -58,6 +66,7 @@ func (p *MinimaxProvider) Initialize(ctx context.Context, config models.Provider
 
 	// Store configuration
 	p.config = config
+	providers.WarnUnknownOptions(p, models.OptionScopeProvider, config.Options, p.logger)
 	p.apiKey = config.APIKey
 
 	// Mark as initialized - API key will be validated on first use
@@ -147,6 +156,9 @@ func (p *MinimaxProvider) NewTTSClient(ctx context.Context, config models.TTSCon
 
 // Synthesize synthesizes text to audio (non-streaming)
 func (p *MinimaxProvider) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
+	ctx, cancel := models.WithCapabilityTimeout(ctx, p.config, models.CapabilityTTS)
+	defer cancel()
+
 	ttsService := NewMinimaxTTSService(p)
 	return ttsService.Synthesize(ctx, text, config)
 }
@@ -161,13 +173,97 @@ func (p *MinimaxProvider) StreamSynthesize(ctx context.Context, textStream <-cha
 		defer close(audioChan)
 		defer close(errChan)
 
-		// For now, return an error as streaming is handled via NewClient
-		errChan <- fmt.Errorf("use NewClient for streaming synthesis")
+		ttsService := NewMinimaxTTSService(p)
+		ttsClient, err := ttsService.NewTTSClient(ctx, config)
+		if err != nil {
+			errChan <- fmt.Errorf("failed to create TTS client: %w", err)
+			return
+		}
+
+		if err := streamSynthesize(ctx, ttsClient.(*minimaxTTSClient), textStream, audioChan); err != nil {
+			errChan <- err
+		}
 	}()
 
 	return audioChan, errChan
 }
 
+// streamSynthesize sends each text segment to client and forwards its audio to audioChan until the
+// input closes and the trailing audio has been delivered. It never blocks on a consumer that stopped
+// reading once ctx is cancelled: cancellation aborts the client instead of waiting for task_finished.
+func streamSynthesize(ctx context.Context, client *minimaxTTSClient, textStream <-chan string, audioChan chan<- []byte) error {
+	defer client.Close()
+
+	// Send text segments until the input closes, then finish the task without waiting; the
+	// loop below keeps receiving trailing audio until task_finished ends the stream
+	sendErrCh := make(chan error, 1)
+	receiveDone := make(chan struct{})
+	go func() {
+		defer close(sendErrCh)
+		for {
+			select {
+			case text, ok := <-textStream:
+				if !ok {
+					if err := client.finishTask(); err != nil {
+						sendErrCh <- err
+						client.abort()
+						return
+					}
+
+					// Bound the wait for task_finished like Close does
+					select {
+					case <-time.After(taskFinishTimeout):
+						client.abort()
+					case <-receiveDone:
+					case <-ctx.Done():
+					}
+					return
+				}
+				if text == "" {
+					continue
+				}
+				if err := client.Send(ctx, text); err != nil {
+					sendErrCh <- fmt.Errorf("failed to send text: %w", err)
+					client.abort()
+					return
+				}
+			case <-receiveDone:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Forward audio until the stream ends
+	for {
+		audio, err := client.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				// Stop waiting for trailing audio nobody will read
+				client.abort()
+			}
+			close(receiveDone)
+			if sendErr := <-sendErrCh; sendErr != nil {
+				return sendErr
+			}
+			if err != io.EOF {
+				return fmt.Errorf("failed to receive audio: %w", err)
+			}
+			return nil
+		}
+
+		select {
+		case audioChan <- audio:
+		case <-ctx.Done():
+			client.abort()
+			close(receiveDone)
+			<-sendErrCh
+			return ctx.Err()
+		}
+	}
+}
+
 // GetVoices returns available voices
 func (p *MinimaxProvider) GetVoices(ctx context.Context) ([]models.Voice, error) {
 	ttsService := NewMinimaxTTSService(p)
@@ -181,9 +277,9 @@ func (p *MinimaxProvider) GetVoicesByLanguage(ctx context.Context, language stri
 }
 
 // GetDefaultVoiceForLanguage returns the default voice for a language
-func (p *MinimaxProvider) GetDefaultVoiceForLanguage(language string) string {
+func (p *MinimaxProvider) GetDefaultVoiceForLanguage(ctx context.Context, language string) string {
 	ttsService := NewMinimaxTTSService(p)
-	return ttsService.GetDefaultVoiceForLanguage(language)
+	return ttsService.GetDefaultVoiceForLanguage(ctx, language)
 }
 
 // GetProviderInfo returns metadata about the MiniMax provider
@@ -205,9 +301,9 @@ func (p *MinimaxProvider) GetProviderInfo() *models.ProviderInfo {
 			Features:    []string{"streaming", "multi-language", "high-quality"},
 			Metadata: map[string]any{
 				"sample_rate":   32000,
-				"bitrate":       128000,
+				"bitrate":       defaultBitrate,
 				"formats":       []string{"mp3", "wav", "pcm"},
-				"languages":     []string{"en", "zh"},
+				"languages":     supportedLanguages,
 				"default_voice": "male-qn-qingse",
 			},
 		},
@@ -225,3 +321,37 @@ func (p *MinimaxProvider) GetProviderInfo() *models.ProviderInfo {
 
 	return info
 }
+
+// SupportedLanguages returns the normalized language codes MiniMax can synthesize
+func (p *MinimaxProvider) SupportedLanguages() []string {
+	return models.NormalizeLanguages(supportedLanguages)
+}
+
+// DescribeCapabilities reports the provider's TTS capability; synthesis streams over WebSocket
+func (p *MinimaxProvider) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{
+		TTS: &models.TTSCapability{Streaming: true, Languages: models.NormalizeLanguages(supportedLanguages)},
+	}
+}
+
+// SupportedOptions describes the Options keys the provider honors
+func (p *MinimaxProvider) SupportedOptions() []models.OptionDescriptor {
+	return []models.OptionDescriptor{
+		{Name: "voices", Type: models.OptionTypeMap, Scope: models.OptionScopeProvider, Description: "voices by language, overlaid on the catalog"},
+		{Name: "default_voices", Type: models.OptionTypeMap, Scope: models.OptionScopeProvider, Description: "default voice ID by language"},
+		{Name: "format", Type: models.OptionTypeString, Scope: models.OptionScopeProvider, Description: "default output format, mp3 by default"},
+		{Name: "sample_rate", Type: models.OptionTypeInt, Scope: models.OptionScopeProvider, Description: "default sample rate, 32000 by default"},
+		{Name: "speed", Type: models.OptionTypeNumber, Scope: models.OptionScopeProvider, Description: "default speech speed"},
+		{Name: "volume", Type: models.OptionTypeNumber, Scope: models.OptionScopeProvider, Description: "default volume"},
+		voicecache.TTLOption,
+		models.CapabilityTimeoutOption(models.CapabilityTTS),
+		{Name: "bitrate", Type: models.OptionTypeInt, Scope: models.OptionScopeTTS, Description: "MP3 bitrate: 32000, 64000, 128000 (default) or 256000"},
+		{Name: "channels", Type: models.OptionTypeInt, Scope: models.OptionScopeTTS, Description: "output channels: 1 (default) or 2"},
+		{Name: "emotion", Type: models.OptionTypeString, Scope: models.OptionScopeTTS, Description: "delivery style, such as happy, sad or calm"},
+		{Name: "pronunciation_dict", Type: models.OptionTypeStringList, Scope: models.OptionScopeTTS, Description: "pronunciation overrides of the form term/replacement, such as \"处理/(chu3)(li3)\""},
+		{Name: "max_reconnect_attempts", Type: models.OptionTypeInt, Scope: models.OptionScopeTTS, Description: "times a dropped connection is re-dialed, 3 by default"},
+		wsutil.MaxMessageBytesOption(models.OptionScopeTTS),
+		wsutil.CompressionOption(models.OptionScopeTTS),
+		wsutil.SubprotocolsOption(models.OptionScopeTTS),
+	}
+}
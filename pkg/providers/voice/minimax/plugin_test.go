@@ -0,0 +1,100 @@
+package minimax
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// audioEvent is a task_continued message carrying audio
+func audioEvent(audio string) map[string]any {
+	return map[string]any{"event": "task_continued", "data": map[string]any{"audio": hex.EncodeToString([]byte(audio))}}
+}
+
+func TestStreamSynthesizeDeliversTrailingAudio(t *testing.T) {
+	// Echo each segment as audio, then send trailing audio and task_finished on task_finish
+	client := newTestTTSClient(t, func(conn *websocket.Conn) {
+		for {
+			var message map[string]any
+			if err := conn.ReadJSON(&message); err != nil {
+				return
+			}
+			switch message["event"] {
+			case "task_continue":
+				conn.WriteJSON(audioEvent(message["text"].(string)))
+			case "task_finish":
+				conn.WriteJSON(audioEvent("tail"))
+				conn.WriteJSON(map[string]any{"event": "task_finished"})
+				return
+			}
+		}
+	})
+
+	textStream := make(chan string, 2)
+	textStream <- "ab"
+	textStream <- "cd"
+	close(textStream)
+
+	audioChan := make(chan []byte)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- streamSynthesize(context.Background(), client, textStream, audioChan)
+		close(audioChan)
+	}()
+
+	var audio []string
+	for chunk := range audioChan {
+		audio = append(audio, string(chunk))
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("streamSynthesize: %v", err)
+	}
+
+	got, _ := json.Marshal(audio)
+	if string(got) != `["ab","cd","tail"]` {
+		t.Errorf("unexpected audio: %s", got)
+	}
+}
+
+func TestStreamSynthesizeStopsWhenConsumerCancels(t *testing.T) {
+	// Stream audio until the client goes away, never finishing the task
+	client := newTestTTSClient(t, func(conn *websocket.Conn) {
+		for conn.WriteJSON(audioEvent("chunk")) == nil {
+			time.Sleep(time.Millisecond)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	textStream := make(chan string)
+	close(textStream)
+
+	audioChan := make(chan []byte)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- streamSynthesize(ctx, client, textStream, audioChan)
+	}()
+
+	// Read one chunk, then stop reading and cancel
+	<-audioChan
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("streamSynthesize kept waiting for trailing audio after cancellation")
+	}
+
+	select {
+	case <-client.lc.Done():
+	default:
+		t.Error("expected the client to be closed")
+	}
+}
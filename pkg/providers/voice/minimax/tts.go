@@ -4,17 +4,51 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicecache"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
+	"github.com/creastat/common-go/pkg/sanitize"
 	"github.com/creastat/common-go/pkg/types"
 
 	"github.com/gorilla/websocket"
 )
 
+// taskFinishTimeout bounds how long Close waits for task_finished while trailing audio drains
+const taskFinishTimeout = 30 * time.Second
+
+const (
+	// defaultMaxReconnects is how many times a dropped connection is re-dialed when max_reconnect_attempts is not set
+	defaultMaxReconnects = 3
+
+	// reconnectBackoffMin and reconnectBackoffMax bound the delay between reconnect attempts
+	reconnectBackoffMin = 200 * time.Millisecond
+	reconnectBackoffMax = 5 * time.Second
+
+	// handshakeTimeout bounds the connected_success and task_started handshake
+	handshakeTimeout = 10 * time.Second
+
+	// defaultBitrate and defaultChannels are the audio settings used when the options leave them unset
+	defaultBitrate  = 128000
+	defaultChannels = 1
+)
+
+// supportedBitrates lists the MP3 bitrates MiniMax accepts
+var supportedBitrates = []int{32000, 64000, 128000, 256000}
+
+// supportedEmotions lists the delivery styles MiniMax accepts in voice_setting
+var supportedEmotions = []string{"happy", "sad", "angry", "fearful", "disgusted", "surprised", "calm", "neutral", "fluent", "whisper"}
+
 // MinimaxTTSService implements the TextToSpeechService interface for MiniMax
 type MinimaxTTSService struct {
 	provider *MinimaxProvider
@@ -34,6 +68,7 @@ func (s *MinimaxTTSService) NewTTSClient(ctx context.Context, config models.TTSC
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeTTS, config.Options, s.logger)
 
 	// Get provider config for defaults
 	providerConfig := s.provider.GetConfig()
@@ -56,7 +91,7 @@ func (s *MinimaxTTSService) NewTTSClient(ctx context.Context, config models.TTSC
 	}
 	if config.Voice == "" {
 		// Use default voice for the language
-		config.Voice = s.GetDefaultVoiceForLanguage(config.Language)
+		config.Voice = s.GetDefaultVoiceForLanguage(ctx, config.Language)
 	}
 	if config.SampleRate == 0 {
 		// Try to get from provider config
@@ -73,18 +108,22 @@ func (s *MinimaxTTSService) NewTTSClient(ctx context.Context, config models.TTSC
 			config.SampleRate = 32000
 		}
 	}
-	if config.Encoding == "" {
-		// Try to get from provider config
-		if providerConfig.Options != nil {
-			if format, ok := providerConfig.Options["format"].(string); ok && format != "" {
-				config.Encoding = format
-			} else {
-				config.Encoding = "mp3"
-			}
-		} else {
-			config.Encoding = "mp3"
-		}
+	// Bit depth and companding refine the requested encoding; the provider's format is the fallback
+	format := "mp3"
+	if f, ok := providerConfig.Options["format"].(string); ok && f != "" {
+		format = f
+	}
+	if err := config.ResolveEncoding(format); err != nil {
+		return nil, err
 	}
+
+	// Map the requested encoding to MiniMax's native format name
+	encoding, err := models.ResolveProviderEncoding(models.ProviderTypeMinimax, config.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	config.Encoding = encoding
+
 	if config.Speed == 0 {
 		// Try to get from provider config
 		if providerConfig.Options != nil {
@@ -114,46 +153,146 @@ func (s *MinimaxTTSService) NewTTSClient(ctx context.Context, config models.TTSC
 		}
 	}
 
-	// Connect to MiniMax TTS WebSocket
-	wsURL := "wss://api.minimax.io/ws/v1/t2a_v2"
-
-	dialer := websocket.DefaultDialer
-	header := make(map[string][]string)
-	header["Authorization"] = []string{fmt.Sprintf("Bearer %s", s.provider.GetAPIKey())}
-
-	conn, _, err := dialer.Dial(wsURL, header)
+	// Validate the audio settings before opening the WebSocket
+	bitrate, channels, err := resolveAudioSetting(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to MiniMax TTS: %w", err)
+		return nil, err
+	}
+	emotion, err := resolveEmotion(config)
+	if err != nil {
+		return nil, err
+	}
+	pronunciations, err := resolvePronunciationDict(config)
+	if err != nil {
+		return nil, err
 	}
 
-	client := &minimaxTTSClient{
-		conn:    conn,
-		config:  config,
-		audioCh: make(chan []byte, 10),
-		errCh:   make(chan error, 1),
-		doneCh:  make(chan struct{}),
-		closed:  false,
-		logger:  s.logger,
+	maxReconnects := defaultMaxReconnects
+	if mr, ok := config.Options["max_reconnect_attempts"].(int); ok && mr >= 0 {
+		maxReconnects = mr
+	} else if mr, ok := config.Options["max_reconnect_attempts"].(float64); ok && mr >= 0 {
+		maxReconnects = int(mr)
 	}
 
-	// Wait for connection success message
-	if err := client.waitForConnection(); err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("connection failed: %w", err)
+	client := &minimaxTTSClient{
+		config:        config,
+		audioCh:       make(chan []byte, 10),
+		errCh:         make(chan error, 1),
+		lc:            lifecycle.New(),
+		logger:        s.logger,
+		apiKey:        s.provider.GetAPIKey(),
+		maxReconnects: maxReconnects,
+		bitrate:       bitrate,
+		channels:      channels,
+		emotion:       emotion,
+
+		pronunciations: pronunciations,
+
+		maxMessageBytes: wsutil.MaxMessageBytes(config.Options),
+		dialer:          wsutil.Dialer(config.Options),
 	}
+	client.taskStart = client.buildTaskStart()
 
-	// Start task
-	if err := client.startTask(); err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("failed to start task: %w", err)
+	conn, err := client.connect(ctx)
+	if err != nil {
+		return nil, err
 	}
+	client.conn = conn
+
+	// Trailing audio for long text can take a while after task_finish
+	client.lc.GracePeriod = taskFinishTimeout
 
 	// Start reading messages in background
-	go client.readMessages()
+	client.lc.Go(client.readMessages)
 
 	return client, nil
 }
 
+// resolveAudioSetting reads the "bitrate" and "channels" options, rejecting values MiniMax does not accept.
+// The bitrate only applies to MP3 output.
+func resolveAudioSetting(config models.TTSConfig) (int, int, error) {
+	bitrate := defaultBitrate
+	if br, ok := config.Options["bitrate"].(int); ok {
+		bitrate = br
+	} else if br, ok := config.Options["bitrate"].(float64); ok {
+		bitrate = int(br)
+	}
+	if !slices.Contains(supportedBitrates, bitrate) {
+		return 0, 0, fmt.Errorf("unsupported MiniMax bitrate: %d (supported: %v)", bitrate, supportedBitrates)
+	}
+	if _, set := config.Options["bitrate"]; set && config.Encoding != string(models.AudioEncodingMP3) {
+		return 0, 0, fmt.Errorf("MiniMax bitrate only applies to mp3 output, got %s", config.Encoding)
+	}
+
+	channels := defaultChannels
+	if ch, ok := config.Options["channels"].(int); ok {
+		channels = ch
+	} else if ch, ok := config.Options["channels"].(float64); ok {
+		channels = int(ch)
+	}
+	if channels != 1 && channels != 2 {
+		return 0, 0, fmt.Errorf("unsupported MiniMax channel count: %d (supported: 1, 2)", channels)
+	}
+
+	return bitrate, channels, nil
+}
+
+// resolveEmotion reads the "emotion" option, rejecting styles MiniMax does not support
+func resolveEmotion(config models.TTSConfig) (string, error) {
+	value, set := config.Options["emotion"]
+	if !set {
+		return "", nil
+	}
+	emotion, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("MiniMax emotion must be a string, got %T", value)
+	}
+	emotion = strings.ToLower(strings.TrimSpace(emotion))
+	if emotion == "" {
+		return "", nil
+	}
+	if !slices.Contains(supportedEmotions, emotion) {
+		return "", fmt.Errorf("unsupported MiniMax emotion: %q (supported: %s)", emotion, strings.Join(supportedEmotions, ", "))
+	}
+	return emotion, nil
+}
+
+// resolvePronunciationDict reads the "pronunciation_dict" option, a list of replacements that
+// override how terms are spoken. Each entry has the form "term/(pinyin)(tones)" for Chinese,
+// such as "处理/(chu3)(li3)", or "term/replacement" for other languages, such as "SQL/sequel".
+func resolvePronunciationDict(config models.TTSConfig) ([]string, error) {
+	value, set := config.Options["pronunciation_dict"]
+	if !set || value == nil {
+		return nil, nil
+	}
+
+	var entries []string
+	switch v := value.(type) {
+	case []string:
+		entries = v
+	case []any:
+		for i, item := range v {
+			entry, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("MiniMax pronunciation_dict entry %d must be a string, got %T", i, item)
+			}
+			entries = append(entries, entry)
+		}
+	default:
+		return nil, fmt.Errorf("MiniMax pronunciation_dict must be a list of strings, got %T", value)
+	}
+
+	tones := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		term, replacement, found := strings.Cut(strings.TrimSpace(entry), "/")
+		if !found || strings.TrimSpace(term) == "" || strings.TrimSpace(replacement) == "" {
+			return nil, fmt.Errorf("MiniMax pronunciation_dict entry %d must have the form term/replacement, got %q", i, entry)
+		}
+		tones = append(tones, strings.TrimSpace(entry))
+	}
+	return tones, nil
+}
+
 // Synthesize synthesizes text to audio (non-streaming)
 func (s *MinimaxTTSService) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
 
@@ -186,60 +325,23 @@ func (s *MinimaxTTSService) Synthesize(ctx context.Context, text string, config
 	return audioData, nil
 }
 
-// GetVoices returns available voices
+// GetVoices returns the live MiniMax catalog, cached for the voice_cache_ttl_secs option (an hour
+// by default), with the voices from the provider config overlaid. A small built-in list stands in
+// for the catalog when it cannot be fetched.
 func (s *MinimaxTTSService) GetVoices(ctx context.Context) ([]models.Voice, error) {
-	// Try to get voices from config first
-	if voices := s.getVoicesFromConfig(); len(voices) > 0 {
-		return voices, nil
-	}
-
-	// Fallback to hardcoded voices
-	voices := []models.Voice{
-		{
-			ID:          "male-qn-qingse",
-			Name:        "Male Qingse",
-			Language:    "zh",
-			Gender:      "male",
-			Description: "Clear male voice with natural tone",
-		},
-		{
-			ID:          "female-shaonv",
-			Name:        "Female Shaonv",
-			Language:    "zh",
-			Gender:      "female",
-			Description: "Young female voice",
-		},
-		{
-			ID:          "female-yujie",
-			Name:        "Female Yujie",
-			Language:    "zh",
-			Gender:      "female",
-			Description: "Mature female voice",
-		},
-		{
-			ID:          "male-qn-jingying",
-			Name:        "Male Jingying",
-			Language:    "zh",
-			Gender:      "male",
-			Description: "Professional male voice",
-		},
-		{
-			ID:          "presenter_male",
-			Name:        "Presenter Male",
-			Language:    "en",
-			Gender:      "male",
-			Description: "Professional presenter voice",
-		},
-		{
-			ID:          "presenter_female",
-			Name:        "Presenter Female",
-			Language:    "en",
-			Gender:      "female",
-			Description: "Professional female presenter voice",
-		},
+	catalog, ok := s.provider.voiceCache.Get()
+	if !ok {
+		fetched, err := s.FetchVoices(ctx)
+		if err != nil {
+			s.logger.Warn("Failed to fetch MiniMax voice catalog, using built-in voices", "error", err)
+			fetched = slices.Clone(fallbackVoices)
+		} else {
+			s.provider.voiceCache.Set(fetched, voicecache.TTL(s.provider.GetConfig().Options))
+		}
+		catalog = fetched
 	}
 
-	return voices, nil
+	return mergeVoices(catalog, s.getVoicesFromConfig()), nil
 }
 
 // getVoicesFromConfig extracts voices from provider config
@@ -296,40 +398,42 @@ func (s *MinimaxTTSService) getVoicesFromConfig() []models.Voice {
 	return voices
 }
 
-// GetVoicesByLanguage returns voices filtered by language
+// GetVoicesByLanguage returns voices filtered by language; "en" matches "en-US"
 func (s *MinimaxTTSService) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
 	allVoices, err := s.GetVoices(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var filtered []models.Voice
-	for _, voice := range allVoices {
-		if voice.Language == language {
-			filtered = append(filtered, voice)
-		}
-	}
-
-	return filtered, nil
+	return models.FilterVoicesByLanguage(allVoices, language), nil
 }
 
-// GetDefaultVoiceForLanguage returns the default voice for a language
-func (s *MinimaxTTSService) GetDefaultVoiceForLanguage(language string) string {
+// defaultVoiceLookupTimeout bounds the catalog fetch GetDefaultVoiceForLanguage makes on a cold cache
+const defaultVoiceLookupTimeout = 10 * time.Second
+
+// GetDefaultVoiceForLanguage returns the default voice for a language. A configured default is
+// used as is; a built-in default missing from the catalog, fetched under ctx if not cached yet,
+// is replaced by the first catalog voice for the language.
+func (s *MinimaxTTSService) GetDefaultVoiceForLanguage(ctx context.Context, language string) string {
 	config := s.provider.GetConfig()
-	if config.Options == nil {
-		return s.getHardcodedDefaultVoice(language)
+	if defaultVoices, ok := config.Options["default_voices"].(map[string]any); ok {
+		if voiceID, ok := defaultVoices[language].(string); ok && voiceID != "" {
+			return voiceID
+		}
 	}
 
-	defaultVoices, ok := config.Options["default_voices"].(map[string]any)
-	if !ok {
-		return s.getHardcodedDefaultVoice(language)
-	}
+	ctx, cancel := context.WithTimeout(ctx, defaultVoiceLookupTimeout)
+	defer cancel()
 
-	if voiceID, ok := defaultVoices[language].(string); ok && voiceID != "" {
+	voiceID := s.getHardcodedDefaultVoice(language)
+	catalog, err := s.GetVoices(ctx)
+	if err != nil || slices.ContainsFunc(catalog, func(v models.Voice) bool { return v.ID == voiceID }) {
 		return voiceID
 	}
-
-	return s.getHardcodedDefaultVoice(language)
+	if voices := models.FilterVoicesByLanguage(catalog, language); len(voices) > 0 {
+		return voices[0].ID
+	}
+	return voiceID
 }
 
 // getHardcodedDefaultVoice returns hardcoded default voices
@@ -349,19 +453,148 @@ func (s *MinimaxTTSService) getHardcodedDefaultVoice(language string) string {
 
 // minimaxTTSClient implements the TTSClient interface
 type minimaxTTSClient struct {
-	conn    *websocket.Conn
-	config  models.TTSConfig
-	audioCh chan []byte
-	errCh   chan error
-	doneCh  chan struct{}
-	mu      sync.Mutex
-	closed  bool
-	logger  types.Logger
+	conn     *websocket.Conn
+	config   models.TTSConfig
+	audioCh  chan []byte
+	errCh    chan error
+	lc       *lifecycle.Lifecycle
+	mu       sync.Mutex // serializes writes to conn and guards reconnects
+	finished bool       // task_finish has been sent
+	logger   types.Logger
+
+	apiKey        string
+	taskStart     map[string]any // replayed on reconnect so the task resumes with the same settings
+	maxReconnects int
+	bitrate       int    // MP3 bitrate sent in audio_setting
+	channels      int    // output channel count sent in audio_setting
+	emotion       string // delivery style sent in voice_setting, empty for the model's default
+
+	pronunciations []string // pronunciation_dict tone entries, nil when not set
+
+	maxMessageBytes int64 // read limit applied to every connection
+
+	dialer *websocket.Dialer // dialer for every connection, with the configured compression and subprotocols
+}
+
+// minimaxTTSURL is the MiniMax streaming TTS WebSocket endpoint
+const minimaxTTSURL = "wss://api.minimax.io/ws/v1/t2a_v2"
+
+// connect dials the WebSocket and completes the connection and task_start handshake
+func (c *minimaxTTSClient) connect(ctx context.Context) (*websocket.Conn, error) {
+	header := make(map[string][]string)
+	header["Authorization"] = []string{fmt.Sprintf("Bearer %s", c.apiKey)}
+
+	conn, _, err := c.dialer.DialContext(ctx, minimaxTTSURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MiniMax TTS: %w", err)
+	}
+	conn.SetReadLimit(c.maxMessageBytes)
+
+	// Abort the handshake reads if ctx is cancelled or the server stalls
+	conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	// Wait for connection success message
+	if err := waitForConnection(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("connection failed: %w", err)
+	}
+
+	// Start task
+	if err := c.startTask(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start task: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	return conn, nil
+}
+
+// reconnect replaces a dropped connection, retrying with exponential backoff up to maxReconnects times.
+// Sends block until it finishes; text sent before the drop whose audio was not yet received is lost.
+// A task already finished is finished again on the new connection.
+func (c *minimaxTTSClient) reconnect(cause error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Abandon reconnecting as soon as the client starts closing
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.lc.Closing():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	c.conn.Close()
+
+	delay := reconnectBackoffMin
+	for attempt := 1; attempt <= c.maxReconnects; attempt++ {
+		c.logger.Warn("MiniMax TTS connection lost, reconnecting",
+			"attempt", attempt,
+			"max_attempts", c.maxReconnects,
+			"error", cause,
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("TTS client is closed")
+		}
+
+		conn, err := c.connect(ctx)
+		if err == nil && c.finished {
+			// The restarted task must be finished again, or the server keeps waiting for text
+			if err = sendTaskFinish(conn); err != nil {
+				conn.Close()
+			}
+		}
+		if err == nil {
+			c.conn = conn
+			return nil
+		}
+		cause = err
+
+		delay *= 2
+		if delay > reconnectBackoffMax {
+			delay = reconnectBackoffMax
+		}
+	}
+
+	return fmt.Errorf("TTS connection lost after %d reconnect attempts: %w", c.maxReconnects, cause)
+}
+
+// currentConn returns the active connection
+func (c *minimaxTTSClient) currentConn() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn
+}
+
+// closeConn closes the active connection
+func (c *minimaxTTSClient) closeConn() error {
+	return c.currentConn().Close()
 }
 
 // waitForConnection waits for the connection success message
-func (c *minimaxTTSClient) waitForConnection() error {
-	_, message, err := c.conn.ReadMessage()
+func waitForConnection(conn *websocket.Conn) error {
+	_, message, err := conn.ReadMessage()
 	if err != nil {
 		return fmt.Errorf("failed to read connection message: %w", err)
 	}
@@ -378,33 +611,45 @@ func (c *minimaxTTSClient) waitForConnection() error {
 	return nil
 }
 
-// startTask sends the task_start message
-func (c *minimaxTTSClient) startTask() error {
-	// Build task_start request
-	request := map[string]any{
-		"event": "task_start",
-		"model": c.config.Model,
-		"voice_setting": map[string]any{
-			"voice_id":              c.config.Voice,
-			"speed":                 c.config.Speed,
-			"vol":                   c.config.Volume,
-			"pitch":                 c.config.Pitch,
-			"english_normalization": false,
-		},
+// buildTaskStart builds the task_start request from the client config
+func (c *minimaxTTSClient) buildTaskStart() map[string]any {
+	voiceSetting := map[string]any{
+		"voice_id":              c.config.Voice,
+		"speed":                 c.config.Speed,
+		"vol":                   c.config.Volume,
+		"pitch":                 c.config.Pitch,
+		"english_normalization": false,
+	}
+	if c.emotion != "" {
+		voiceSetting["emotion"] = c.emotion
+	}
+
+	taskStart := map[string]any{
+		"event":         "task_start",
+		"model":         c.config.Model,
+		"voice_setting": voiceSetting,
 		"audio_setting": map[string]any{
 			"sample_rate": c.config.SampleRate,
-			"bitrate":     128000,
+			"bitrate":     c.bitrate,
 			"format":      c.config.Encoding,
-			"channel":     1,
+			"channel":     c.channels,
 		},
 	}
+	if len(c.pronunciations) > 0 {
+		taskStart["pronunciation_dict"] = map[string]any{"tone": c.pronunciations}
+	}
 
-	if err := c.conn.WriteJSON(request); err != nil {
+	return taskStart
+}
+
+// startTask sends the task_start message and waits for task_started
+func (c *minimaxTTSClient) startTask(conn *websocket.Conn) error {
+	if err := conn.WriteJSON(c.taskStart); err != nil {
 		return fmt.Errorf("failed to send task_start: %w", err)
 	}
 
 	// Wait for task_started response
-	_, message, err := c.conn.ReadMessage()
+	_, message, err := conn.ReadMessage()
 	if err != nil {
 		return fmt.Errorf("failed to read task_started message: %w", err)
 	}
@@ -426,10 +671,13 @@ func (c *minimaxTTSClient) Send(ctx context.Context, text string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() {
 		return fmt.Errorf("TTS client is closed")
 	}
 
+	// Control characters and invalid UTF-8 break the JSON payload
+	text = sanitize.Text(text)
+
 	// Build task_continue request
 	request := map[string]any{
 		"event": "task_continue",
@@ -451,75 +699,78 @@ func (c *minimaxTTSClient) Send(ctx context.Context, text string) error {
 
 // Receive receives synthesized audio data
 func (c *minimaxTTSClient) Receive(ctx context.Context) ([]byte, error) {
-	select {
-	case audio := <-c.audioCh:
-		return audio, nil
-	case err := <-c.errCh:
-		return nil, err
-	case <-c.doneCh:
-		return nil, io.EOF
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
+	return lifecycle.Receive(ctx, c.lc, c.audioCh, c.errCh)
 }
 
-// Close closes the TTS client and releases resources
+// Close finishes the task and releases resources.
+// Audio synthesized before task_finished is still delivered to Receive
+// during the grace period.
 func (c *minimaxTTSClient) Close() error {
+	return c.lc.Close(c.finishTask, c.closeConn)
+}
+
+// abort closes the client without waiting for trailing audio
+func (c *minimaxTTSClient) abort() error {
+	c.lc.Abort()
+	return c.Close()
+}
+
+// finishTask sends the task_finish message once
+func (c *minimaxTTSClient) finishTask() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.finished {
 		return nil
 	}
+	c.finished = true
+
+	return sendTaskFinish(c.conn)
+}
 
-	// Send task_finish message but DON'T set closed=true yet
-	// Let readMessages handle the final cleanup when it receives task_finished
+// sendTaskFinish writes the task_finish message to conn
+func sendTaskFinish(conn *websocket.Conn) error {
 	finishMsg := map[string]any{
 		"event": "task_finish",
 	}
-	if err := c.conn.WriteJSON(finishMsg); err != nil {
-		c.closed = true
-		close(c.doneCh)
-		return c.conn.Close()
+	if err := conn.WriteJSON(finishMsg); err != nil {
+		return fmt.Errorf("failed to send task_finish: %w", err)
 	}
 
-	// Don't close the connection or set closed=true
-	// The readMessages goroutine will handle cleanup when it receives task_finished
 	return nil
 }
 
 // readMessages reads messages from TTS WebSocket
 func (c *minimaxTTSClient) readMessages() {
-	defer func() {
-		c.mu.Lock()
-		if !c.closed {
-			close(c.doneCh)
-		}
-		c.mu.Unlock()
-	}()
-
+	conn := c.currentConn()
 	for {
-		c.mu.Lock()
-		if c.closed {
-			c.mu.Unlock()
-			return
-		}
-		c.mu.Unlock()
-
-		_, message, err := c.conn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
-			c.mu.Lock()
-			wasClosed := c.closed
-			c.mu.Unlock()
+			if c.lc.IsClosing() {
+				return
+			}
 
-			if !wasClosed {
+			// The same oversized message would be sent again after reconnecting
+			if errors.Is(err, websocket.ErrReadLimit) {
 				select {
-				case c.errCh <- fmt.Errorf("TTS read error: %w", err):
+				case c.errCh <- fmt.Errorf("TTS read error: %w", wsutil.ReadLimitError(err, c.maxMessageBytes)):
 				default:
 				}
-				c.Close()
+				return
 			}
-			return
+
+			// Re-dial and restart the task; give up only once reconnects are exhausted
+			if reconnectErr := c.reconnect(err); reconnectErr != nil {
+				if !c.lc.IsClosing() {
+					select {
+					case c.errCh <- fmt.Errorf("TTS read error: %w", reconnectErr):
+					default:
+					}
+				}
+				return
+			}
+			conn = c.currentConn()
+			continue
 		}
 
 		// Parse JSON message
@@ -549,35 +800,21 @@ func (c *minimaxTTSClient) readMessages() {
 						c.logger.Debug("Received audio chunk",
 							"size", len(audioData),
 						)
-					case <-c.doneCh:
+					case <-c.lc.Aborted():
 						return
 					}
 				}
 			}
 
 		case "task_finished":
-			c.mu.Lock()
-			if !c.closed {
-				c.closed = true
-				close(c.doneCh)
-			}
-			c.mu.Unlock()
-			c.conn.Close()
 			return
 
 		case "task_failed":
 			errMsg := c.extractErrorMessage(response)
-			c.mu.Lock()
-			if !c.closed {
-				c.closed = true
-				select {
-				case c.errCh <- fmt.Errorf("TTS task failed: %s", errMsg):
-				default:
-				}
-				close(c.doneCh)
+			select {
+			case c.errCh <- fmt.Errorf("TTS task failed: %s", errMsg):
+			default:
 			}
-			c.mu.Unlock()
-			c.conn.Close()
 			return
 		}
 	}
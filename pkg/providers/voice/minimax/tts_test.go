@@ -0,0 +1,114 @@
+package minimax
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/wsutil"
+	"github.com/creastat/common-go/pkg/types"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestTTSClient returns a connected client whose fixed MiniMax URL is redirected to a stub
+// server running handler after the connection and task_start handshake
+func newTestTTSClient(t *testing.T, handler func(*websocket.Conn)) *minimaxTTSClient {
+	t.Helper()
+
+	client := &minimaxTTSClient{
+		config:          models.TTSConfig{Voice: "male-qn-qingse", Model: "speech-02-turbo", SampleRate: 32000, Encoding: "pcm"},
+		audioCh:         make(chan []byte, 10),
+		errCh:           make(chan error, 1),
+		lc:              lifecycle.New(),
+		logger:          &types.NoOpLogger{},
+		apiKey:          "test-key",
+		bitrate:         defaultBitrate,
+		channels:        1,
+		maxMessageBytes: wsutil.MaxMessageBytes(nil),
+		dialer:          wsutil.Dialer(nil),
+	}
+	client.taskStart = client.buildTaskStart()
+
+	voicetest.Redirect(t, client.dialer, func(conn *websocket.Conn) {
+		conn.WriteJSON(map[string]any{"event": "connected_success"})
+		if !voicetest.ReadUntil(conn, "task_start") {
+			return
+		}
+		conn.WriteJSON(map[string]any{"event": "task_started"})
+		handler(conn)
+	})
+
+	conn, err := client.connect(context.Background())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	client.conn = conn
+	client.lc.Go(client.readMessages)
+	return client
+}
+
+func TestTTSClientClose(t *testing.T) {
+	// MiniMax answers task_finish with task_finished once the trailing audio is sent
+	client := newTestTTSClient(t, func(conn *websocket.Conn) {
+		if voicetest.ReadUntil(conn, "task_finish") {
+			conn.WriteJSON(map[string]any{"event": "task_finished"})
+		}
+	})
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestReconnectFinishesTaskAgain(t *testing.T) {
+	// The first connection drops on task_finish; the replacement only ends the stream once it
+	// receives task_finish itself
+	var connections atomic.Int32
+	client := newTestTTSClient(t, func(conn *websocket.Conn) {
+		if connections.Add(1) == 1 {
+			voicetest.ReadUntil(conn, "task_finish")
+			return
+		}
+		conn.WriteJSON(map[string]any{"event": "task_continued", "data": map[string]any{"audio": "0102"}})
+		if voicetest.ReadUntil(conn, "task_finish") {
+			conn.WriteJSON(map[string]any{"event": "task_finished"})
+		}
+	})
+	client.mu.Lock()
+	client.maxReconnects = 2
+	client.mu.Unlock()
+	defer client.abort()
+
+	if err := client.finishTask(); err != nil {
+		t.Fatalf("finishTask: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var audio []byte
+	for {
+		chunk, err := client.Receive(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("expected the stream to end after reconnecting, got %v", err)
+		}
+		audio = append(audio, chunk...)
+	}
+
+	if connections.Load() != 2 {
+		t.Errorf("expected one reconnect, got %d connections", connections.Load())
+	}
+	if !bytes.Equal(audio, []byte{1, 2}) {
+		t.Errorf("expected the audio from the new connection, got %v", audio)
+	}
+}
@@ -0,0 +1,244 @@
+package minimax
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+// minimaxVoicesURL lists the system, cloned and generated voices available to the API key
+const minimaxVoicesURL = "https://api.minimax.io/v1/get_voice"
+
+// voiceLanguagePrefixes maps the language prefix of MiniMax system voice IDs, such as
+// "Russian_ReliableMan", to a language code
+var voiceLanguagePrefixes = map[string]string{
+	"english":            "en",
+	"chinese (mandarin)": "zh",
+	"cantonese":          "yue",
+	"russian":            "ru",
+	"japanese":           "ja",
+	"korean":             "ko",
+	"spanish":            "es",
+	"portuguese":         "pt",
+	"french":             "fr",
+	"german":             "de",
+	"italian":            "it",
+	"dutch":              "nl",
+	"indonesian":         "id",
+	"vietnamese":         "vi",
+	"arabic":             "ar",
+	"turkish":            "tr",
+	"ukrainian":          "uk",
+	"thai":               "th",
+	"polish":             "pl",
+	"romanian":           "ro",
+	"greek":              "el",
+	"czech":              "cs",
+	"finnish":            "fi",
+	"hindi":              "hi",
+}
+
+// legacyVoiceLanguages labels the system voices whose IDs predate the language prefix
+var legacyVoiceLanguages = map[string]string{
+	"male-qn-qingse":     "zh",
+	"male-qn-jingying":   "zh",
+	"male-qn-badao":      "zh",
+	"male-qn-daxuesheng": "zh",
+	"female-shaonv":      "zh",
+	"female-yujie":       "zh",
+	"female-chengshu":    "zh",
+	"female-tianmei":     "zh",
+	"presenter_male":     "en",
+	"presenter_female":   "en",
+}
+
+// fallbackVoices are returned by GetVoices when the live catalog cannot be fetched
+var fallbackVoices = []models.Voice{
+	{
+		ID:          "male-qn-qingse",
+		Name:        "Male Qingse",
+		Language:    "zh",
+		Gender:      "male",
+		Description: "Clear male voice with natural tone",
+	},
+	{
+		ID:          "female-shaonv",
+		Name:        "Female Shaonv",
+		Language:    "zh",
+		Gender:      "female",
+		Description: "Young female voice",
+	},
+	{
+		ID:          "female-yujie",
+		Name:        "Female Yujie",
+		Language:    "zh",
+		Gender:      "female",
+		Description: "Mature female voice",
+	},
+	{
+		ID:          "male-qn-jingying",
+		Name:        "Male Jingying",
+		Language:    "zh",
+		Gender:      "male",
+		Description: "Professional male voice",
+	},
+	{
+		ID:          "presenter_male",
+		Name:        "Presenter Male",
+		Language:    "en",
+		Gender:      "male",
+		Description: "Professional presenter voice",
+	},
+	{
+		ID:          "presenter_female",
+		Name:        "Presenter Female",
+		Language:    "en",
+		Gender:      "female",
+		Description: "Professional female presenter voice",
+	},
+	{
+		ID:          "Russian_ReliableMan",
+		Name:        "Reliable Man",
+		Language:    "ru",
+		Gender:      "male",
+		Description: "Steady Russian male voice",
+	},
+}
+
+// minimaxVoice is a voice as listed by the MiniMax API
+type minimaxVoice struct {
+	VoiceID     string          `json:"voice_id"`
+	VoiceName   string          `json:"voice_name"`
+	Description json.RawMessage `json:"description"` // A list of strings for system voices, a string otherwise
+}
+
+// minimaxVoiceList is the voice listing response
+type minimaxVoiceList struct {
+	SystemVoices    []minimaxVoice `json:"system_voice"`
+	ClonedVoices    []minimaxVoice `json:"voice_cloning"`
+	GeneratedVoices []minimaxVoice `json:"voice_generation"`
+	BaseResp        struct {
+		StatusCode int    `json:"status_code"`
+		StatusMsg  string `json:"status_msg"`
+	} `json:"base_resp"`
+}
+
+// FetchVoices lists the voices in the live MiniMax catalog, bypassing the cache
+func (s *MinimaxTTSService) FetchVoices(ctx context.Context) ([]models.Voice, error) {
+	if !s.provider.IsInitialized() {
+		return nil, fmt.Errorf("provider not initialized")
+	}
+
+	body, err := json.Marshal(map[string]string{"voice_type": "all"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", minimaxVoicesURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.provider.GetAPIKey()))
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := &http.Client{Timeout: s.provider.GetConfig().Timeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MiniMax voices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("MiniMax voice listing failed (status: %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var list minimaxVoiceList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if list.BaseResp.StatusCode != 0 {
+		return nil, fmt.Errorf("MiniMax voice listing failed (code: %d): %s", list.BaseResp.StatusCode, list.BaseResp.StatusMsg)
+	}
+
+	var voices []models.Voice
+	for _, group := range [][]minimaxVoice{list.SystemVoices, list.ClonedVoices, list.GeneratedVoices} {
+		for _, voice := range group {
+			if voice.VoiceID != "" {
+				voices = append(voices, voice.toModel())
+			}
+		}
+	}
+
+	return voices, nil
+}
+
+// toModel converts an API voice; the language comes from the voice ID prefix
+func (v minimaxVoice) toModel() models.Voice {
+	name := v.VoiceName
+	if name == "" {
+		name = v.VoiceID
+	}
+
+	return models.Voice{
+		ID:          v.VoiceID,
+		Name:        name,
+		Language:    voiceLanguage(v.VoiceID),
+		Description: v.description(),
+	}
+}
+
+// description flattens the description, which system voices list as several strings
+func (v minimaxVoice) description() string {
+	var parts []string
+	if err := json.Unmarshal(v.Description, &parts); err == nil {
+		return strings.Join(parts, " ")
+	}
+
+	var text string
+	if err := json.Unmarshal(v.Description, &text); err == nil {
+		return text
+	}
+	return ""
+}
+
+// voiceLanguage derives the language of a voice from its ID. Prefixed IDs such as
+// "Russian_ReliableMan" name their language and legacy system IDs are looked up; cloned and
+// generated voices are named by their owners and stay unlabeled.
+func voiceLanguage(voiceID string) string {
+	prefix, _, found := strings.Cut(voiceID, "_")
+	if found {
+		if language, ok := voiceLanguagePrefixes[strings.ToLower(prefix)]; ok {
+			return language
+		}
+	}
+	return legacyVoiceLanguages[voiceID]
+}
+
+// mergeVoices overlays the configured voices on the catalog; a configured voice replaces
+// the catalog entry with the same ID
+func mergeVoices(catalog, configured []models.Voice) []models.Voice {
+	merged := make([]models.Voice, 0, len(catalog)+len(configured))
+	index := make(map[string]int, len(catalog))
+	for _, voice := range catalog {
+		index[voice.ID] = len(merged)
+		merged = append(merged, voice)
+	}
+
+	for _, voice := range configured {
+		if i, ok := index[voice.ID]; ok {
+			merged[i] = voice
+			continue
+		}
+		index[voice.ID] = len(merged)
+		merged = append(merged, voice)
+	}
+
+	return merged
+}
@@ -0,0 +1,131 @@
+package minimax
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/models"
+)
+
+func TestVoiceLanguage(t *testing.T) {
+	tests := []struct {
+		voiceID string
+		want    string
+	}{
+		{voiceID: "Russian_ReliableMan", want: "ru"},
+		{voiceID: "Chinese (Mandarin)_Gentleman", want: "zh"},
+		{voiceID: "English_Graceful_Lady", want: "en"},
+		{voiceID: "male-qn-qingse", want: "zh"},
+		{voiceID: "female-shaonv", want: "zh"},
+		{voiceID: "presenter_male", want: "en"},
+		{voiceID: "presenter_female", want: "en"},
+		{voiceID: "my_cloned_voice", want: ""},
+		{voiceID: "narrator", want: ""},
+		{voiceID: "MyVoice", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := voiceLanguage(tt.voiceID); got != tt.want {
+			t.Errorf("voiceLanguage(%q) = %q, want %q", tt.voiceID, got, tt.want)
+		}
+	}
+}
+
+// roundTripFunc serves HTTP requests without a network
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// serveVoiceList answers the voice listing endpoint with body until the test ends
+func serveVoiceList(t *testing.T, body string) {
+	t.Helper()
+
+	original := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = original })
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != minimaxVoicesURL {
+			t.Errorf("unexpected request to %s", req.URL)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestDefaultVoiceOnColdCacheIsInCatalog(t *testing.T) {
+	// The live catalog no longer lists the built-in Russian default
+	serveVoiceList(t, `{
+		"system_voice": [
+			{"voice_id": "Russian_BrightHeroine", "voice_name": "Bright Heroine"},
+			{"voice_id": "presenter_male", "voice_name": "Presenter"}
+		],
+		"voice_cloning": [{"voice_id": "my_voice"}],
+		"base_resp": {"status_code": 0}
+	}`)
+
+	provider := NewMinimaxProvider(nil)
+	if err := provider.Initialize(context.Background(), models.ProviderConfig{APIKey: "test-key"}); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	if got := provider.GetDefaultVoiceForLanguage(context.Background(), "ru"); got != "Russian_BrightHeroine" {
+		t.Errorf("got %q for ru, want the catalog's Russian voice", got)
+	}
+
+	english, err := provider.GetVoicesByLanguage(context.Background(), "en")
+	if err != nil {
+		t.Fatalf("GetVoicesByLanguage: %v", err)
+	}
+	if len(english) != 1 || english[0].ID != "presenter_male" {
+		t.Errorf("expected only presenter_male for en, got %+v", english)
+	}
+}
+
+func TestDefaultVoiceLookupUsesCallerContext(t *testing.T) {
+	serveVoiceList(t, `{
+		"system_voice": [{"voice_id": "Russian_BrightHeroine", "voice_name": "Bright Heroine"}],
+		"base_resp": {"status_code": 0}
+	}`)
+
+	type callerKey struct{}
+	var seen []any
+	catalog := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		seen = append(seen, req.Context().Value(callerKey{}))
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		return catalog.RoundTrip(req)
+	})
+	t.Cleanup(func() { http.DefaultTransport = catalog })
+
+	provider := NewMinimaxProvider(nil)
+	if err := provider.Initialize(context.Background(), models.ProviderConfig{APIKey: "test-key"}); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	seen = nil
+
+	// A caller that has already given up gets the built-in default without a catalog fetch being cached
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), callerKey{}, "cancelled"))
+	cancel()
+	if got := provider.GetDefaultVoiceForLanguage(ctx, "ru"); got != "Russian_ReliableMan" {
+		t.Errorf("got %q for a cancelled lookup, want the built-in default", got)
+	}
+
+	ctx = context.WithValue(context.Background(), callerKey{}, "live")
+	if got := provider.GetDefaultVoiceForLanguage(ctx, "ru"); got != "Russian_BrightHeroine" {
+		t.Errorf("got %q for ru, want the catalog's Russian voice", got)
+	}
+
+	if len(seen) != 2 || seen[0] != "cancelled" || seen[1] != "live" {
+		t.Errorf("expected each catalog fetch to run under the caller's context, got %v", seen)
+	}
+}
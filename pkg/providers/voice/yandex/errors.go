@@ -0,0 +1,42 @@
+package yandex
+
+import (
+	"github.com/creastat/common-go/pkg/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// classifyGRPCCode maps a gRPC status code to the error class used by retry and fallback
+func classifyGRPCCode(code codes.Code) models.ErrorClass {
+	switch code {
+	case codes.Unavailable, codes.Aborted, codes.Internal, codes.DeadlineExceeded:
+		return models.ErrorClassTransient
+	case codes.ResourceExhausted:
+		return models.ErrorClassRateLimited
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return models.ErrorClassAuth
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange, codes.Unimplemented:
+		return models.ErrorClassInvalidRequest
+	case codes.NotFound:
+		return models.ErrorClassNotFound
+	case codes.Canceled:
+		return models.ErrorClassCanceled
+	default:
+		return models.ErrorClassUnknown
+	}
+}
+
+// classifyError tags a gRPC status error with its class. Other errors are returned unchanged.
+func classifyError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.OK {
+		return err
+	}
+	return models.NewClassifiedError(classifyGRPCCode(st.Code()), err)
+}
+
+// isRetryable reports whether err's class may succeed when the call is repeated
+func isRetryable(err error) bool {
+	return models.ClassifyError(err).Retryable()
+}
@@ -0,0 +1,103 @@
+package yandex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	tts "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/tts"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want models.ErrorClass
+	}{
+		{code: codes.Unavailable, want: models.ErrorClassTransient},
+		{code: codes.Aborted, want: models.ErrorClassTransient},
+		{code: codes.Internal, want: models.ErrorClassTransient},
+		{code: codes.DeadlineExceeded, want: models.ErrorClassTransient},
+		{code: codes.ResourceExhausted, want: models.ErrorClassRateLimited},
+		{code: codes.Unauthenticated, want: models.ErrorClassAuth},
+		{code: codes.PermissionDenied, want: models.ErrorClassAuth},
+		{code: codes.InvalidArgument, want: models.ErrorClassInvalidRequest},
+		{code: codes.FailedPrecondition, want: models.ErrorClassInvalidRequest},
+		{code: codes.NotFound, want: models.ErrorClassNotFound},
+		{code: codes.Canceled, want: models.ErrorClassCanceled},
+		{code: codes.DataLoss, want: models.ErrorClassUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			// Wrap as the clients do, so the class must survive the chain
+			err := fmt.Errorf("failed to send audio: %w", classifyError(status.Error(tt.code, "boom")))
+
+			if got := models.ClassifyError(err); got != tt.want {
+				t.Errorf("got class %q, want %q", got, tt.want)
+			}
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("the gRPC status was lost: got %s", code)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorLeavesOtherErrors(t *testing.T) {
+	plain := errors.New("plain")
+	if err := classifyError(plain); err != plain {
+		t.Errorf("expected a non-status error unchanged, got %v", err)
+	}
+	if err := classifyError(nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestSynthesizeRetriesRetryableStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		code  codes.Code
+		calls int
+		ok    bool
+	}{
+		{name: "transient", code: codes.Unavailable, calls: 2, ok: true},
+		{name: "rate limited", code: codes.ResourceExhausted, calls: 2, ok: true},
+		{name: "invalid request", code: codes.InvalidArgument, calls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			provider := newStubProvider(t, &stubSynthesizer{
+				utterance: func(req *tts.UtteranceSynthesisRequest, stream grpc.ServerStreamingServer[tts.UtteranceSynthesisResponse]) error {
+					calls++
+					if calls == 1 {
+						return status.Error(tt.code, "first attempt fails")
+					}
+					return stream.Send(&tts.UtteranceSynthesisResponse{AudioChunk: &tts.AudioChunk{Data: []byte("audio")}})
+				},
+			}, nil)
+			provider.config.RetryPolicy = &models.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}
+
+			audio, err := NewYandexTTSService(provider).Synthesize(context.Background(), "hello", models.TTSConfig{})
+			if calls != tt.calls {
+				t.Errorf("expected %d calls, got %d", tt.calls, calls)
+			}
+			if tt.ok {
+				if err != nil || string(audio) != "audio" {
+					t.Errorf("expected the audio from the retry, got %q and %v", audio, err)
+				}
+				return
+			}
+			if status.Code(err) != tt.code {
+				t.Errorf("expected the %s status, got %v", tt.code, err)
+			}
+		})
+	}
+}
@@ -1,15 +1,34 @@
 package yandex
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"time"
 
+	"github.com/creastat/common-go/pkg/audio"
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
 	"github.com/creastat/common-go/pkg/types"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
+// sttLanguages lists the languages recognized by Yandex SpeechKit STT models
+var sttLanguages = []string{"ru-RU", "en-US", "tr-TR", "kk-KZ", "uz-UZ"}
+
+// ttsLanguages lists the languages covered by the Yandex SpeechKit TTS voices
+var ttsLanguages = []string{"ru-RU", "en-US", "kk-KZ", "uz-UZ"}
+
+// healthCheckText is the utterance synthesized by the deep health check
+const healthCheckText = "ok"
+
+// healthCheckSilence is 100 ms of 8 kHz 16-bit silence transcribed by the deep health check
+var healthCheckSilence = make([]byte, 1600)
+
 // YandexProvider implements the Provider interface for Yandex SpeechKit
 type YandexProvider struct {
 	name         string
@@ -72,6 +91,7 @@ func (p *YandexProvider) Initialize(ctx context.Context, config models.ProviderC
 
 	// Store configuration
 	p.config = config
+	providers.WarnUnknownOptions(p, models.OptionScopeProvider, config.Options, p.logger)
 	p.apiKey = config.APIKey
 	p.folderId = folderId
 
@@ -81,23 +101,50 @@ func (p *YandexProvider) Initialize(ctx context.Context, config models.ProviderC
 	return nil
 }
 
-// HealthCheck performs a health check on the provider
+// HealthCheck performs a health check on the provider.
+// By default only the configuration is validated; set Options["deep_health_check"] to true
+// to exercise STT and TTS so that a revoked key, wrong folder or single-service outage is detected.
 func (p *YandexProvider) HealthCheck(ctx context.Context) error {
+	for _, capability := range p.capabilities {
+		if err := p.CheckCapabilityHealth(ctx, capability); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckCapabilityHealth checks a single capability. With Options["deep_health_check"] set, STT
+// transcribes a short silence and TTS synthesizes a short utterance.
+func (p *YandexProvider) CheckCapabilityHealth(ctx context.Context, capability types.Capability) error {
 	if !p.initialized {
 		return fmt.Errorf("provider not initialized")
 	}
 
+	if p.apiKey == "" || p.folderId == "" {
+		return fmt.Errorf("health check failed: invalid configuration")
+	}
+
+	if capability != types.CapabilitySTT && capability != types.CapabilityTTS {
+		return fmt.Errorf("capability %s is not supported by %s", capability, p.name)
+	}
+
+	if deep, ok := p.config.Options["deep_health_check"].(bool); !ok || !deep {
+		return nil
+	}
+
 	// Create a context with timeout for health check
 	healthCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	// For Yandex, we'll just verify the configuration is valid
-	// A full health check would require making an actual API call
-	if p.apiKey == "" || p.folderId == "" {
-		return fmt.Errorf("health check failed: invalid configuration")
+	var err error
+	if capability == types.CapabilitySTT {
+		_, err = NewYandexSTTService(p).Transcribe(healthCtx, bytes.NewReader(healthCheckSilence), models.STTConfig{})
+	} else {
+		_, err = NewYandexTTSService(p).Synthesize(healthCtx, healthCheckText, models.TTSConfig{})
+	}
+	if err != nil {
+		return fmt.Errorf("%s health check failed: %w", capability, err)
 	}
-
-	_ = healthCtx // Use the context to avoid unused variable warning
 
 	return nil
 }
@@ -118,6 +165,32 @@ func (p *YandexProvider) GetFolderId() string {
 	return p.folderId
 }
 
+// endpoint returns the gRPC endpoint for the given option key. A per-request option
+// takes precedence over the provider option, which falls back to the public default.
+func (p *YandexProvider) endpoint(options map[string]any, key, fallback string) string {
+	if e, ok := options[key].(string); ok && e != "" {
+		return e
+	}
+	if e, ok := p.config.Options[key].(string); ok && e != "" {
+		return e
+	}
+	return fallback
+}
+
+// transportCredentials returns TLS credentials, or plaintext ones when insecure_endpoint is set
+// for a local stub or an on-prem endpoint without TLS. A per-request option takes precedence
+// over the provider option.
+func (p *YandexProvider) transportCredentials(options map[string]any) credentials.TransportCredentials {
+	plaintext, ok := options["insecure_endpoint"].(bool)
+	if !ok {
+		plaintext, _ = p.config.Options["insecure_endpoint"].(bool)
+	}
+	if plaintext {
+		return insecure.NewCredentials()
+	}
+	return credentials.NewTLS(&tls.Config{})
+}
+
 // GetConfig returns the provider configuration
 func (p *YandexProvider) GetConfig() models.ProviderConfig {
 	return p.config
@@ -159,7 +232,7 @@ func (p *YandexProvider) GetProviderInfo() *models.ProviderInfo {
 			Metadata: map[string]any{
 				"sample_rate": 8000,
 				"encoding":    "linear16",
-				"languages":   []string{"ru-RU", "en-US", "tr-TR", "kk-KZ", "uz-UZ"},
+				"languages":   sttLanguages,
 			},
 		},
 		{
@@ -171,7 +244,7 @@ func (p *YandexProvider) GetProviderInfo() *models.ProviderInfo {
 			Metadata: map[string]any{
 				"sample_rate": 8000,
 				"encoding":    "linear16",
-				"languages":   []string{"ru-RU", "en-US", "tr-TR", "kk-KZ", "uz-UZ"},
+				"languages":   sttLanguages,
 			},
 		},
 		{
@@ -183,7 +256,7 @@ func (p *YandexProvider) GetProviderInfo() *models.ProviderInfo {
 			Metadata: map[string]any{
 				"sample_rate": 8000,
 				"encoding":    "linear16",
-				"languages":   []string{"ru-RU", "en-US", "tr-TR", "kk-KZ", "uz-UZ"},
+				"languages":   sttLanguages,
 			},
 		},
 	}
@@ -263,3 +336,35 @@ func (p *YandexProvider) GetProviderInfo() *models.ProviderInfo {
 
 	return info
 }
+
+// DescribeCapabilities reports the provider's STT and TTS capabilities; both stream over gRPC
+func (p *YandexProvider) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{
+		STT: &models.STTCapability{Streaming: true, Languages: models.NormalizeLanguages(sttLanguages)},
+		TTS: &models.TTSCapability{Streaming: true, Languages: models.NormalizeLanguages(ttsLanguages)},
+	}
+}
+
+// SupportedOptions describes the Options keys the provider honors
+func (p *YandexProvider) SupportedOptions() []models.OptionDescriptor {
+	return []models.OptionDescriptor{
+		{Name: "folder_id", Type: models.OptionTypeString, Scope: models.OptionScopeProvider, Description: "Yandex Cloud folder billed for requests"},
+		{Name: "deep_health_check", Type: models.OptionTypeBool, Scope: models.OptionScopeProvider, Description: "make health checks call the API instead of only validating the configuration"},
+		{Name: "stt_endpoint", Type: models.OptionTypeString, Scope: models.OptionScopeProvider, Description: "STT gRPC endpoint for every request"},
+		{Name: "tts_endpoint", Type: models.OptionTypeString, Scope: models.OptionScopeProvider, Description: "TTS gRPC endpoint for every request"},
+		{Name: "insecure_endpoint", Type: models.OptionTypeBool, Scope: models.OptionScopeProvider, Description: "connect to the gRPC endpoints without TLS"},
+		models.CapabilityTimeoutOption(models.CapabilitySTT),
+		models.CapabilityTimeoutOption(models.CapabilityTTS),
+		{Name: "stt_endpoint", Type: models.OptionTypeString, Scope: models.OptionScopeSTT, Description: "STT gRPC endpoint for this stream"},
+		{Name: "insecure_endpoint", Type: models.OptionTypeBool, Scope: models.OptionScopeSTT, Description: "connect to the STT endpoint without TLS"},
+		audio.NormalizeGainOption,
+		audio.AutoResampleOption,
+		audio.TrackSequenceOption,
+		{Name: "tts_endpoint", Type: models.OptionTypeString, Scope: models.OptionScopeTTS, Description: "TTS gRPC endpoint for this request"},
+		{Name: "insecure_endpoint", Type: models.OptionTypeBool, Scope: models.OptionScopeTTS, Description: "connect to the TTS endpoint without TLS"},
+		{Name: "role", Type: models.OptionTypeString, Scope: models.OptionScopeTTS, Description: "voice role, such as neutral or good"},
+		{Name: "volume", Type: models.OptionTypeNumber, Scope: models.OptionScopeTTS, Description: "loudness used instead of the config volume"},
+		{Name: "explicit_volume", Type: models.OptionTypeBool, Scope: models.OptionScopeTTS, Description: "pass the volume through without adjusting it to the normalization range"},
+		{Name: "loudness_normalization", Type: models.OptionTypeString, Scope: models.OptionScopeTTS, Description: "max_peak to normalize by peak level instead of LUFS"},
+	}
+}
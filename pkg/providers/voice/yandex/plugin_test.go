@@ -0,0 +1,118 @@
+package yandex
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/models"
+	stt "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/stt"
+	tts "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/tts"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// stubSynthesizer serves the TTS RPCs with the given handlers
+type stubSynthesizer struct {
+	tts.UnimplementedSynthesizerServer
+	utterance func(*tts.UtteranceSynthesisRequest, grpc.ServerStreamingServer[tts.UtteranceSynthesisResponse]) error
+	stream    func(grpc.BidiStreamingServer[tts.StreamSynthesisRequest, tts.StreamSynthesisResponse]) error
+}
+
+func (s *stubSynthesizer) UtteranceSynthesis(req *tts.UtteranceSynthesisRequest, stream grpc.ServerStreamingServer[tts.UtteranceSynthesisResponse]) error {
+	if s.utterance == nil {
+		return s.UnimplementedSynthesizerServer.UtteranceSynthesis(req, stream)
+	}
+	return s.utterance(req, stream)
+}
+
+func (s *stubSynthesizer) StreamSynthesis(stream grpc.BidiStreamingServer[tts.StreamSynthesisRequest, tts.StreamSynthesisResponse]) error {
+	if s.stream == nil {
+		return s.UnimplementedSynthesizerServer.StreamSynthesis(stream)
+	}
+	return s.stream(stream)
+}
+
+// stubRecognizer serves the STT RPC with the given handler
+type stubRecognizer struct {
+	stt.UnimplementedRecognizerServer
+	stream func(grpc.BidiStreamingServer[stt.StreamingRequest, stt.StreamingResponse]) error
+}
+
+func (s *stubRecognizer) RecognizeStreaming(stream grpc.BidiStreamingServer[stt.StreamingRequest, stt.StreamingResponse]) error {
+	if s.stream == nil {
+		return s.UnimplementedRecognizerServer.RecognizeStreaming(stream)
+	}
+	return s.stream(stream)
+}
+
+// newStubProvider starts a plaintext gRPC server with the given stubs and returns an initialized
+// provider pointed at it
+func newStubProvider(t *testing.T, synthesizer *stubSynthesizer, recognizer *stubRecognizer) *YandexProvider {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	server := grpc.NewServer()
+	if synthesizer != nil {
+		tts.RegisterSynthesizerServer(server, synthesizer)
+	}
+	if recognizer != nil {
+		stt.RegisterRecognizerServer(server, recognizer)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	provider := NewYandexProvider(nil)
+	err = provider.Initialize(context.Background(), models.ProviderConfig{
+		APIKey: "test-key",
+		Options: map[string]any{
+			"folder_id":         "test-folder",
+			"stt_endpoint":      listener.Addr().String(),
+			"tts_endpoint":      listener.Addr().String(),
+			"insecure_endpoint": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	return provider
+}
+
+func TestSynthesizeUsesInsecureEndpoint(t *testing.T) {
+	var authorization []string
+	provider := newStubProvider(t, &stubSynthesizer{
+		utterance: func(req *tts.UtteranceSynthesisRequest, stream grpc.ServerStreamingServer[tts.UtteranceSynthesisResponse]) error {
+			md, _ := metadata.FromIncomingContext(stream.Context())
+			authorization = md.Get("authorization")
+			return stream.Send(&tts.UtteranceSynthesisResponse{AudioChunk: &tts.AudioChunk{Data: []byte("audio")}})
+		},
+	}, nil)
+
+	audio, err := NewYandexTTSService(provider).Synthesize(context.Background(), "hello", models.TTSConfig{})
+	if err != nil {
+		t.Fatalf("Synthesize: %v", err)
+	}
+	if string(audio) != "audio" {
+		t.Errorf("expected the stub audio, got %q", audio)
+	}
+	if len(authorization) != 1 || authorization[0] != "Api-Key test-key" {
+		t.Errorf("unexpected authorization metadata: %v", authorization)
+	}
+}
+
+func TestTransportCredentials(t *testing.T) {
+	provider := NewYandexProvider(nil)
+	provider.config.Options = map[string]any{"insecure_endpoint": true}
+
+	if got := provider.transportCredentials(nil).Info().SecurityProtocol; got != "insecure" {
+		t.Errorf("provider option: expected insecure credentials, got %q", got)
+	}
+	if got := provider.transportCredentials(map[string]any{"insecure_endpoint": false}).Info().SecurityProtocol; got != "tls" {
+		t.Errorf("request override: expected TLS credentials, got %q", got)
+	}
+}
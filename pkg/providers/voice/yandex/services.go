@@ -28,7 +28,7 @@ func (w *YandexSTTServiceWrapper) Initialize(ctx context.Context, config models.
 	return nil
 }
 func (w *YandexSTTServiceWrapper) HealthCheck(ctx context.Context) error {
-	return w.provider.HealthCheck(ctx)
+	return w.provider.CheckCapabilityHealth(ctx, types.CapabilitySTT)
 }
 func (w *YandexSTTServiceWrapper) Close() error { return nil }
 func (w *YandexSTTServiceWrapper) GetProviderInfo() *models.ProviderInfo {
@@ -37,6 +37,9 @@ func (w *YandexSTTServiceWrapper) GetProviderInfo() *models.ProviderInfo {
 
 // STTService interface methods (new interface with different signatures)
 func (w *YandexSTTServiceWrapper) Transcribe(ctx context.Context, audioData []byte, options map[string]any) (string, error) {
+	ctx, cancel := models.WithCapabilityTimeout(ctx, w.provider.config, models.CapabilitySTT)
+	defer cancel()
+
 	// Convert []byte to io.Reader for the old implementation
 	sttService := NewYandexSTTService(w.provider)
 	return sttService.Transcribe(ctx, io.NopCloser(bytes.NewReader(audioData)), models.STTConfig{
@@ -82,7 +85,7 @@ func (w *YandexTTSServiceWrapper) Initialize(ctx context.Context, config models.
 	return nil
 }
 func (w *YandexTTSServiceWrapper) HealthCheck(ctx context.Context) error {
-	return w.provider.HealthCheck(ctx)
+	return w.provider.CheckCapabilityHealth(ctx, types.CapabilityTTS)
 }
 func (w *YandexTTSServiceWrapper) Close() error { return nil }
 func (w *YandexTTSServiceWrapper) GetProviderInfo() *models.ProviderInfo {
@@ -91,6 +94,9 @@ func (w *YandexTTSServiceWrapper) GetProviderInfo() *models.ProviderInfo {
 
 // TTSService interface methods
 func (w *YandexTTSServiceWrapper) Synthesize(ctx context.Context, text string, config models.TTSConfig) ([]byte, error) {
+	ctx, cancel := models.WithCapabilityTimeout(ctx, w.provider.config, models.CapabilityTTS)
+	defer cancel()
+
 	ttsService := NewYandexTTSService(w.provider)
 	return ttsService.Synthesize(ctx, text, config)
 }
@@ -120,3 +126,28 @@ func (w *YandexTTSServiceWrapper) GetVoices(ctx context.Context) ([]models.Voice
 	ttsService := NewYandexTTSService(w.provider)
 	return ttsService.GetVoices(ctx)
 }
+
+func (w *YandexTTSServiceWrapper) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
+	ttsService := NewYandexTTSService(w.provider)
+	return ttsService.GetVoicesByLanguage(ctx, language)
+}
+
+// SupportedLanguages returns the normalized language codes Yandex can transcribe
+func (w *YandexSTTServiceWrapper) SupportedLanguages() []string {
+	return models.NormalizeLanguages(sttLanguages)
+}
+
+// SupportedLanguages returns the normalized language codes Yandex can synthesize
+func (w *YandexTTSServiceWrapper) SupportedLanguages() []string {
+	return models.NormalizeLanguages(ttsLanguages)
+}
+
+// DescribeCapabilities reports the STT capability of the wrapped provider
+func (w *YandexSTTServiceWrapper) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{STT: w.provider.DescribeCapabilities().STT}
+}
+
+// DescribeCapabilities reports the TTS capability of the wrapped provider
+func (w *YandexTTSServiceWrapper) DescribeCapabilities() *models.ProviderCapabilities {
+	return &models.ProviderCapabilities{TTS: w.provider.DescribeCapabilities().TTS}
+}
@@ -0,0 +1,74 @@
+package yandex
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	stt "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/stt"
+	tts "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/tts"
+
+	"google.golang.org/grpc"
+)
+
+// remaining reports how long the RPC had left when it reached the stub, or 0 without a deadline
+func remaining(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	return time.Until(deadline)
+}
+
+func TestServicesUseCapabilityTimeout(t *testing.T) {
+	var sttLeft, ttsLeft time.Duration
+	provider := newStubProvider(t, &stubSynthesizer{
+		utterance: func(req *tts.UtteranceSynthesisRequest, stream grpc.ServerStreamingServer[tts.UtteranceSynthesisResponse]) error {
+			ttsLeft = remaining(stream.Context())
+			return stream.Send(&tts.UtteranceSynthesisResponse{AudioChunk: &tts.AudioChunk{Data: []byte("audio")}})
+		},
+	}, &stubRecognizer{
+		stream: func(stream grpc.BidiStreamingServer[stt.StreamingRequest, stt.StreamingResponse]) error {
+			sttLeft = remaining(stream.Context())
+			return drainRecognizer(stream)
+		},
+	})
+	provider.config.Options["stt_timeout"] = "1m"
+	provider.config.Options["tts_timeout"] = "1h"
+
+	if _, err := (&YandexSTTServiceWrapper{provider: provider}).Transcribe(context.Background(), []byte{0, 0}, nil); err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	if _, err := (&YandexTTSServiceWrapper{provider: provider}).Synthesize(context.Background(), "hello", models.TTSConfig{}); err != nil {
+		t.Fatalf("Synthesize: %v", err)
+	}
+
+	if sttLeft <= 50*time.Second || sttLeft > time.Minute {
+		t.Errorf("expected the 1m STT timeout, the RPC had %v left", sttLeft)
+	}
+	if ttsLeft <= 59*time.Minute || ttsLeft > time.Hour {
+		t.Errorf("expected the 1h TTS timeout, the RPC had %v left", ttsLeft)
+	}
+}
+
+func TestSupportedLanguages(t *testing.T) {
+	provider := NewYandexProvider(nil)
+
+	// Region subtags are dropped, and Turkish is recognized but has no voices
+	sttWant := []string{"ru", "en", "tr", "kk", "uz"}
+	ttsWant := []string{"ru", "en", "kk", "uz"}
+
+	if got := (&YandexSTTServiceWrapper{provider: provider}).SupportedLanguages(); !reflect.DeepEqual(got, sttWant) {
+		t.Errorf("STT: got %v, want %v", got, sttWant)
+	}
+	if got := (&YandexTTSServiceWrapper{provider: provider}).SupportedLanguages(); !reflect.DeepEqual(got, ttsWant) {
+		t.Errorf("TTS: got %v, want %v", got, ttsWant)
+	}
+
+	capabilities := provider.DescribeCapabilities()
+	if !reflect.DeepEqual(capabilities.STT.Languages, sttWant) || !reflect.DeepEqual(capabilities.TTS.Languages, ttsWant) {
+		t.Errorf("capability languages differ: STT %v, TTS %v", capabilities.STT.Languages, capabilities.TTS.Languages)
+	}
+}
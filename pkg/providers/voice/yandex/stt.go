@@ -2,22 +2,25 @@ package yandex
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
+	"github.com/creastat/common-go/pkg/audio"
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
 	stt "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/stt"
 	"github.com/creastat/common-go/pkg/types"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 )
 
 const (
+	// yandexSTTEndpoint is the public endpoint, overridable with Options["stt_endpoint"]
 	yandexSTTEndpoint = "stt.api.cloud.yandex.net:443"
 )
 
@@ -37,9 +40,19 @@ func NewYandexSTTService(provider *YandexProvider) *YandexSTTService {
 
 // NewSTTClient creates a new STT client for streaming audio
 func (s *YandexSTTService) NewSTTClient(ctx context.Context, config models.STTConfig) (interfaces.STTClient, error) {
+	client, err := s.newSTTClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// newSTTClient creates a new STT client and returns its concrete type
+func (s *YandexSTTService) newSTTClient(ctx context.Context, config models.STTConfig) (*yandexSTTClient, error) {
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeSTT, config.Options, s.logger)
 
 	// Set defaults if not provided
 	if config.Model == "" {
@@ -51,17 +64,31 @@ func (s *YandexSTTService) NewSTTClient(ctx context.Context, config models.STTCo
 	if config.SampleRate == 0 {
 		config.SampleRate = 8000
 	}
-	if config.Encoding == "" {
-		config.Encoding = "linear16"
+	// Bit depth and companding refine the requested encoding
+	if err := config.ResolveEncoding(string(models.AudioEncodingPCM16)); err != nil {
+		return nil, err
+	}
+
+	// Yandex has no G.711 input, so μ-law and A-law audio is decoded to PCM before sending
+	decode := audio.G711Decoder(config.Encoding)
+	if decode != nil {
+		config.Encoding = string(models.AudioEncodingPCM16)
 	}
+
+	// Map the requested encoding to Yandex's native name
+	encoding, err := models.ResolveProviderEncoding(models.ProviderTypeYandex, config.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	config.Encoding = encoding
 	if config.Channels == 0 {
 		config.Channels = 1
 	}
 
 	// Create gRPC connection
-	creds := credentials.NewTLS(&tls.Config{})
+	creds := s.provider.transportCredentials(config.Options)
 	conn, err := grpc.NewClient(
-		yandexSTTEndpoint,
+		s.provider.endpoint(config.Options, "stt_endpoint", yandexSTTEndpoint),
 		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(10*1024*1024)), // 10MB max receive size
 	)
@@ -71,16 +98,23 @@ func (s *YandexSTTService) NewSTTClient(ctx context.Context, config models.STTCo
 
 	// Create streaming client
 	client := &yandexSTTClient{
-		conn:     conn,
-		config:   config,
-		provider: s.provider,
-		resultCh: make(chan *models.STTResult, 10),
-		errCh:    make(chan error, 1),
-		doneCh:   make(chan struct{}),
-		closed:   false,
-		logger:   s.logger,
+		conn:        conn,
+		config:      config,
+		provider:    s.provider,
+		resultCh:    make(chan *models.STTResult, 10),
+		errCh:       make(chan error, 1),
+		lc:          lifecycle.New(),
+		logger:      s.logger,
+		streamStart: time.Now(),
+		decode:      decode,
 	}
 
+	if audio.IsPCM16(config.Encoding) {
+		client.gain = audio.GainNormalizerFromOption(config.Options["normalize_gain"])
+		client.resampler = audio.ResamplerFromOption(config.Options["auto_resample"], config.SampleRate, config.Channels)
+	}
+	client.sequence = audio.SequenceTrackerFromOption(config.Options["track_sequence"], config.Encoding, config.SampleRate, config.Channels)
+
 	// Initialize the stream
 	if err := client.initStream(ctx); err != nil {
 		conn.Close()
@@ -93,11 +127,25 @@ func (s *YandexSTTService) NewSTTClient(ctx context.Context, config models.STTCo
 // Transcribe transcribes audio data to text (non-streaming)
 func (s *YandexSTTService) Transcribe(ctx context.Context, audio io.Reader, config models.STTConfig) (string, error) {
 	// Create a streaming client
-	client, err := s.NewSTTClient(ctx, config)
+	client, err := s.newSTTClient(ctx, config)
 	if err != nil {
 		return "", fmt.Errorf("failed to create STT client: %w", err)
 	}
-	defer client.Close()
+
+	return client.transcribe(ctx, audio)
+}
+
+// transcribe streams audio through the client and returns the final transcript, closing the
+// client when done. A cancelled transcription aborts rather than waiting out the grace period
+// for trailing results.
+func (c *yandexSTTClient) transcribe(ctx context.Context, audio io.Reader) (string, error) {
+	defer func() {
+		if ctx.Err() != nil {
+			c.abort()
+			return
+		}
+		c.Close()
+	}()
 
 	// Channel to collect results
 	resultCh := make(chan string, 1)
@@ -107,7 +155,7 @@ func (s *YandexSTTService) Transcribe(ctx context.Context, audio io.Reader, conf
 	go func() {
 		var fullText string
 		for {
-			result, err := client.Receive(ctx)
+			result, err := c.Receive(ctx)
 			if err != nil {
 				if err == io.EOF {
 					resultCh <- fullText
@@ -126,6 +174,13 @@ func (s *YandexSTTService) Transcribe(ctx context.Context, audio io.Reader, conf
 	// Read audio data and send to client
 	buffer := make([]byte, 4096)
 	for {
+		// Stop sending promptly on cancel; the deferred abort tears the stream down
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		default:
+		}
+
 		n, err := audio.Read(buffer)
 		if err != nil {
 			if err == io.EOF {
@@ -135,14 +190,14 @@ func (s *YandexSTTService) Transcribe(ctx context.Context, audio io.Reader, conf
 		}
 
 		if n > 0 {
-			if err := client.Send(ctx, buffer[:n]); err != nil {
+			if err := c.Send(ctx, buffer[:n]); err != nil {
 				return "", fmt.Errorf("failed to send audio: %w", err)
 			}
 		}
 	}
 
 	// Close the client to signal end of audio
-	client.Close()
+	c.Close()
 
 	// Wait for results
 	select {
@@ -185,16 +240,22 @@ func (s *YandexSTTService) GetModels(ctx context.Context) ([]models.Model, error
 
 // yandexSTTClient implements the STTClient interface
 type yandexSTTClient struct {
-	conn     *grpc.ClientConn
-	stream   stt.Recognizer_RecognizeStreamingClient
-	config   models.STTConfig
-	provider *YandexProvider
-	resultCh chan *models.STTResult
-	errCh    chan error
-	doneCh   chan struct{}
-	mu       sync.Mutex
-	closed   bool
-	logger   types.Logger
+	conn      *grpc.ClientConn
+	stream    stt.Recognizer_RecognizeStreamingClient
+	config    models.STTConfig
+	gain      *audio.GainNormalizer  // optional pre-Send normalization
+	resampler *audio.Resampler       // optional pre-Send sample rate conversion
+	sequence  *audio.SequenceTracker // optional sent-audio gap detection
+	decode    func([]byte) []byte    // decodes μ-law/A-law input to PCM; nil for PCM input
+	provider  *YandexProvider
+	resultCh  chan *models.STTResult
+	errCh     chan error
+	lc        *lifecycle.Lifecycle
+	mu        sync.Mutex // serializes writes to stream
+	sendDone  bool
+	logger    types.Logger
+
+	streamStart time.Time // when the stream was opened; result offsets are relative to it
 }
 
 // initStream initializes the bidirectional streaming connection
@@ -216,7 +277,7 @@ func (c *yandexSTTClient) initStream(ctx context.Context) error {
 	stream, err := recognizerClient.RecognizeStreaming(ctx)
 	if err != nil {
 		fmt.Printf("[YANDEX STT] Failed to start streaming: %v\n", err)
-		return fmt.Errorf("failed to start streaming: %w", err)
+		return fmt.Errorf("failed to start streaming: %w", classifyError(err))
 	}
 
 	c.stream = stream
@@ -248,33 +309,39 @@ func (c *yandexSTTClient) initStream(ctx context.Context) error {
 	)
 
 	if err := stream.Send(req); err != nil {
-		return fmt.Errorf("failed to send session options: %w", err)
+		return fmt.Errorf("failed to send session options: %w", classifyError(err))
 	}
 
 	fmt.Println("[YANDEX STT] Session options sent, starting message reader goroutine")
 	// Start reading responses in background
-	go c.readMessages()
+	c.lc.Go(c.readMessages)
 
 	return nil
 }
 
 // buildSessionOptions creates the session options from config
 func (c *yandexSTTClient) buildSessionOptions() *stt.StreamingOptions {
-	// Map encoding
-	audioEncoding := stt.RawAudio_LINEAR16_PCM
-	if c.config.Encoding == "opus" {
-		// For OPUS, we'd use ContainerAudio instead
-	}
-
 	// Build audio format options with proper union type
-	audioFormatOptions := &stt.AudioFormatOptions{
-		AudioFormat: &stt.AudioFormatOptions_RawAudio{
-			RawAudio: &stt.RawAudio{
-				AudioEncoding:     audioEncoding,
-				SampleRateHertz:   int64(c.config.SampleRate),
-				AudioChannelCount: int64(c.config.Channels),
+	var audioFormatOptions *stt.AudioFormatOptions
+	if c.config.Encoding == string(models.AudioEncodingOpus) {
+		// OPUS arrives in an OGG container; sample rate and channels come from the container header
+		audioFormatOptions = &stt.AudioFormatOptions{
+			AudioFormat: &stt.AudioFormatOptions_ContainerAudio{
+				ContainerAudio: &stt.ContainerAudio{
+					ContainerAudioType: stt.ContainerAudio_OGG_OPUS,
+				},
 			},
-		},
+		}
+	} else {
+		audioFormatOptions = &stt.AudioFormatOptions{
+			AudioFormat: &stt.AudioFormatOptions_RawAudio{
+				RawAudio: &stt.RawAudio{
+					AudioEncoding:     stt.RawAudio_LINEAR16_PCM,
+					SampleRateHertz:   int64(c.config.SampleRate),
+					AudioChannelCount: int64(c.config.Channels),
+				},
+			},
+		}
 	}
 
 	// Build recognition model options
@@ -326,11 +393,26 @@ func (c *yandexSTTClient) Send(ctx context.Context, audio []byte) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
+	if c.lc.IsClosing() || c.sendDone {
 		fmt.Println("[YANDEX STT] Attempted to send audio on closed client")
 		return fmt.Errorf("STT client is closed")
 	}
 
+	// Decode G.711 input to PCM
+	if c.decode != nil {
+		audio = c.decode(audio)
+	}
+
+	// Convert input at the auto_resample rate to the configured sample rate; a chunk too short to
+	// yield a frame is held until the next one
+	audio = c.resampler.Apply(audio)
+	if c.resampler != nil && len(audio) == 0 {
+		return nil
+	}
+
+	// Amplify quiet input when normalize_gain is set
+	audio = c.gain.Apply(audio)
+
 	// Send audio chunk
 	req := &stt.StreamingRequest{
 		Event: &stt.StreamingRequest_Chunk{
@@ -341,137 +423,112 @@ func (c *yandexSTTClient) Send(ctx context.Context, audio []byte) error {
 	}
 
 	if err := c.stream.Send(req); err != nil {
-		return fmt.Errorf("failed to send audio: %w", err)
+		return fmt.Errorf("failed to send audio: %w", classifyError(err))
 	}
+	c.sequence.RecordSend(len(audio))
 
 	return nil
 }
 
 // Receive receives transcription results from the STT service
 func (c *yandexSTTClient) Receive(ctx context.Context) (*models.STTResult, error) {
-	select {
-	case result := <-c.resultCh:
-		return result, nil
-	case err := <-c.errCh:
-		return nil, err
-	case <-c.doneCh:
-		return nil, io.EOF
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
+	return lifecycle.Receive(ctx, c.lc, c.resultCh, c.errCh)
 }
 
 // Finalize finalizes the STT stream by sending end-of-stream marker
 func (c *yandexSTTClient) Finalize() error {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.closed {
-		fmt.Println("[YANDEX STT] Finalize called on already closed client")
+	if c.lc.IsClosing() {
 		return fmt.Errorf("STT client is closed")
 	}
 
-	fmt.Println("[YANDEX STT] Finalizing STT stream")
+	return c.closeSend()
+}
 
-	if c.stream != nil {
-		fmt.Println("[YANDEX STT] Sending CloseSend to signal end of audio")
-		if err := c.stream.CloseSend(); err != nil {
-			fmt.Printf("[YANDEX STT] Error during CloseSend: %v\n", err)
-			return fmt.Errorf("failed to finalize stream: %w", err)
-		}
-		fmt.Println("[YANDEX STT] CloseSend completed successfully")
-	}
+// StreamTo invokes handler for each transcription result until the stream ends
+func (c *yandexSTTClient) StreamTo(ctx context.Context, handler func(*models.STTResult) error) error {
+	return lifecycle.StreamTo(ctx, c.Receive, handler, c.closeSend)
+}
 
-	return nil
+// StreamStartTime returns when the stream was opened
+func (c *yandexSTTClient) StreamStartTime() time.Time {
+	return c.streamStart
 }
 
 // Close closes the STT client and releases resources
 func (c *yandexSTTClient) Close() error {
+	return c.lc.Close(c.closeSend, c.conn.Close)
+}
+
+// abort closes the client without waiting for trailing results
+func (c *yandexSTTClient) abort() error {
+	c.lc.Abort()
+	return c.Close()
+}
+
+// closeSend signals end of audio to the server once
+func (c *yandexSTTClient) closeSend() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.closed {
-		fmt.Println("[YANDEX STT] Close called on already closed client")
+	if c.sendDone || c.stream == nil {
 		return nil
 	}
+	c.sendDone = true
 
-	fmt.Println("[YANDEX STT] Closing STT client")
-	c.closed = true
-	close(c.doneCh)
-
-	if c.stream != nil {
-		fmt.Println("[YANDEX STT] Closing stream send")
-		c.stream.CloseSend()
-	}
-
-	if c.conn != nil {
-		fmt.Println("[YANDEX STT] Closing gRPC connection")
-		return c.conn.Close()
+	c.logger.Debug("Sending CloseSend to signal end of audio")
+	if err := c.stream.CloseSend(); err != nil {
+		return fmt.Errorf("failed to finalize stream: %w", classifyError(err))
 	}
 
-	fmt.Println("[YANDEX STT] Client closed successfully")
 	return nil
 }
 
 // readMessages reads messages from the STT stream
 func (c *yandexSTTClient) readMessages() {
-	defer func() {
-		c.mu.Lock()
-		if !c.closed {
-			close(c.doneCh)
-		}
-		c.mu.Unlock()
-	}()
-
 	messageCount := 0
 	for {
-		c.mu.Lock()
-		if c.closed {
-			c.mu.Unlock()
-			return
-		}
-		stream := c.stream
-		c.mu.Unlock()
-
 		messageCount++
-		resp, err := stream.Recv()
+		resp, err := c.stream.Recv()
 		if err != nil {
-			c.mu.Lock()
-			wasClosed := c.closed
-			c.mu.Unlock()
-
-			if !wasClosed {
-				if err == io.EOF {
-					// EOF might indicate server closed due to error
-					// Try to get the trailer metadata which might contain error info
-					fmt.Printf("[YANDEX STT] EOF received after %d messages\n", messageCount)
-
-					// Try to get trailer metadata for error details
-					if trailer := stream.Trailer(); len(trailer) > 0 {
-						fmt.Printf("[YANDEX STT] Trailer metadata: %v\n", trailer)
-					}
-
-					c.Close()
-					return
+			if err == io.EOF {
+				c.logger.Debug("Yandex STT stream ended", "messages", messageCount)
+				if gap := c.sequence.Finish(); gap != nil {
+					c.logger.Warn("Yandex STT received less audio than was sent",
+						"received_secs", gap.Start,
+						"sent_secs", gap.End,
+					)
 				}
+				return
+			}
 
-				// Log the actual error for debugging - include full error details
-				errMsg := fmt.Sprintf("STT read error after %d messages: %v (type: %T)", messageCount, err, err)
-				fmt.Printf("[YANDEX STT] %s\n", errMsg)
-
+			if !c.lc.IsClosing() {
 				select {
-				case c.errCh <- fmt.Errorf(errMsg):
+				case c.errCh <- fmt.Errorf("STT read error after %d messages: %w", messageCount, classifyError(err)):
 				default:
 				}
-				c.Close()
 			}
 			return
 		}
 
 		if resp != nil {
+			// Non-OK status codes end the stream with a clear error
+			if err := c.statusError(resp); err != nil {
+				select {
+				case c.errCh <- err:
+				default:
+				}
+				return
+			}
+
+			if cursors := resp.GetAudioCursors(); cursors != nil {
+				c.sequence.ObserveReceived(float64(cursors.GetReceivedDataMs()) / 1000.0)
+			}
+
 			// Process the response
 			result := c.parseResponse(resp)
 			if result != nil {
+				c.sequence.Annotate(result.Metadata)
+
 				// Log transcript at trace level
 				if result.Text != "" {
 					c.logger.Debug("Yandex STT result",
@@ -483,7 +540,7 @@ func (c *yandexSTTClient) readMessages() {
 
 				select {
 				case c.resultCh <- result:
-				case <-c.doneCh:
+				case <-c.lc.Aborted():
 					return
 				}
 			}
@@ -491,6 +548,30 @@ func (c *yandexSTTClient) readMessages() {
 	}
 }
 
+// statusError classifies a status-code event. WORKING is informational and WARNING is logged;
+// CLOSED before the client finished sending means the server ended the session (e.g. quota exceeded).
+func (c *yandexSTTClient) statusError(resp *stt.StreamingResponse) error {
+	status := resp.GetStatusCode()
+	if status == nil {
+		return nil
+	}
+
+	switch status.CodeType {
+	case stt.CodeType_WARNING:
+		c.logger.Warn("Yandex STT warning", "message", status.Message)
+	case stt.CodeType_CLOSED:
+		c.mu.Lock()
+		expected := c.sendDone || c.lc.IsClosing()
+		c.mu.Unlock()
+
+		if !expected {
+			return fmt.Errorf("Yandex STT session closed by server: %s", status.Message)
+		}
+	}
+
+	return nil
+}
+
 // parseResponse converts Yandex response to STTResult
 func (c *yandexSTTClient) parseResponse(resp *stt.StreamingResponse) *models.STTResult {
 	result := &models.STTResult{
@@ -508,7 +589,8 @@ func (c *yandexSTTClient) parseResponse(resp *stt.StreamingResponse) *models.STT
 			result.Confidence = alt.Confidence
 			result.StartTime = float64(alt.StartTimeMs) / 1000.0
 			result.EndTime = float64(alt.EndTimeMs) / 1000.0
-			result.Words = c.parseWords(alt.Words)
+			result.Words = c.parseWords(alt.Words, alt.Confidence)
+			result.DetectedLanguage = detectedLanguage(alt.GetLanguages())
 		}
 
 	case *stt.StreamingResponse_Final:
@@ -520,7 +602,8 @@ func (c *yandexSTTClient) parseResponse(resp *stt.StreamingResponse) *models.STT
 			result.Confidence = alt.Confidence
 			result.StartTime = float64(alt.StartTimeMs) / 1000.0
 			result.EndTime = float64(alt.EndTimeMs) / 1000.0
-			result.Words = c.parseWords(alt.Words)
+			result.Words = c.parseWords(alt.Words, alt.Confidence)
+			result.DetectedLanguage = detectedLanguage(alt.GetLanguages())
 		}
 
 	case *stt.StreamingResponse_EouUpdate:
@@ -540,7 +623,8 @@ func (c *yandexSTTClient) parseResponse(resp *stt.StreamingResponse) *models.STT
 				result.Confidence = alt.Confidence
 				result.StartTime = float64(alt.StartTimeMs) / 1000.0
 				result.EndTime = float64(alt.EndTimeMs) / 1000.0
-				result.Words = c.parseWords(alt.Words)
+				result.Words = c.parseWords(alt.Words, alt.Confidence)
+				result.DetectedLanguage = detectedLanguage(alt.GetLanguages())
 				result.Metadata["normalized"] = true
 			}
 		}
@@ -557,6 +641,18 @@ func (c *yandexSTTClient) parseResponse(resp *stt.StreamingResponse) *models.STT
 	return result
 }
 
+// detectedLanguage returns the most probable language among the estimates Yandex attaches to an
+// alternative when recognizing with automatic language detection
+func detectedLanguage(estimates []*stt.LanguageEstimation) string {
+	var best *stt.LanguageEstimation
+	for _, estimate := range estimates {
+		if best == nil || estimate.GetProbability() > best.GetProbability() {
+			best = estimate
+		}
+	}
+	return best.GetLanguageCode()
+}
+
 // normalizeLanguageCode converts language codes to Yandex-supported format
 // Yandex supports: de-DE, en-US, es-ES, fi-FI, fr-FR, he-IL, it-IT, kk-KZ, nl-NL, pl-PL, pt-PT, pt-BR, ru-RU, sv-SE, tr-TR, uz-UZ
 func (c *yandexSTTClient) normalizeLanguageCode(lang string) string {
@@ -631,18 +727,25 @@ func (c *yandexSTTClient) normalizeLanguageCode(lang string) string {
 }
 
 // parseWords converts Yandex words to WordInfo
-func (c *yandexSTTClient) parseWords(words []*stt.Word) []models.WordInfo {
+func (c *yandexSTTClient) parseWords(words []*stt.Word, altConfidence float64) []models.WordInfo {
 	if len(words) == 0 {
 		return nil
 	}
 
+	// Yandex STT v3 words carry no confidence of their own, so each word reports the confidence
+	// of its alternative, or 1.0 when the alternative has none either
+	confidence := altConfidence
+	if confidence <= 0 {
+		confidence = 1.0
+	}
+
 	result := make([]models.WordInfo, len(words))
 	for i, word := range words {
 		result[i] = models.WordInfo{
 			Word:       word.Text,
 			StartTime:  float64(word.StartTimeMs) / 1000.0,
 			EndTime:    float64(word.EndTimeMs) / 1000.0,
-			Confidence: 1.0, // Yandex doesn't provide per-word confidence
+			Confidence: confidence,
 		}
 	}
 
@@ -0,0 +1,203 @@
+package yandex
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+	stt "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/stt"
+	"github.com/creastat/common-go/pkg/types"
+
+	"google.golang.org/grpc"
+)
+
+// drainRecognizer reads the stream until the client half-closes it, then ends the RPC
+func drainRecognizer(stream grpc.BidiStreamingServer[stt.StreamingRequest, stt.StreamingResponse]) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func TestSTTClientClose(t *testing.T) {
+	provider := newStubProvider(t, nil, &stubRecognizer{stream: drainRecognizer})
+
+	client, err := NewYandexSTTService(provider).NewSTTClient(context.Background(), models.STTConfig{})
+	if err != nil {
+		t.Fatalf("NewSTTClient: %v", err)
+	}
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestParseWordsUsesAlternativeConfidence(t *testing.T) {
+	client := &yandexSTTClient{}
+	words := []*stt.Word{{Text: "hello", StartTimeMs: 100, EndTimeMs: 400}, {Text: "world", StartTimeMs: 500, EndTimeMs: 900}}
+
+	for _, tt := range []struct {
+		altConfidence float64
+		want          float64
+	}{
+		{altConfidence: 0.82, want: 0.82},
+		{altConfidence: 0, want: 1.0},
+	} {
+		parsed := client.parseWords(words, tt.altConfidence)
+		if len(parsed) != 2 || parsed[1].Word != "world" || parsed[1].StartTime != 0.5 || parsed[1].EndTime != 0.9 {
+			t.Fatalf("unexpected words: %+v", parsed)
+		}
+		for _, word := range parsed {
+			if word.Confidence != tt.want {
+				t.Errorf("alternative confidence %v: expected word confidence %v, got %v", tt.altConfidence, tt.want, word.Confidence)
+			}
+		}
+	}
+}
+
+func TestSTTResultsConvertToAbsoluteTimestamps(t *testing.T) {
+	provider := newStubProvider(t, nil, &stubRecognizer{
+		stream: func(stream grpc.BidiStreamingServer[stt.StreamingRequest, stt.StreamingResponse]) error {
+			err := stream.Send(&stt.StreamingResponse{Event: &stt.StreamingResponse_Final{Final: &stt.AlternativeUpdate{
+				Alternatives: []*stt.Alternative{{
+					Text:        "hello world",
+					StartTimeMs: 1500,
+					EndTimeMs:   2750,
+					Words: []*stt.Word{
+						{Text: "hello", StartTimeMs: 1500, EndTimeMs: 2000},
+						{Text: "world", StartTimeMs: 2100, EndTimeMs: 2750},
+					},
+				}},
+			}}})
+			if err != nil {
+				return err
+			}
+			return drainRecognizer(stream)
+		},
+	})
+
+	before := time.Now()
+	client, err := NewYandexSTTService(provider).NewSTTClient(context.Background(), models.STTConfig{})
+	if err != nil {
+		t.Fatalf("NewSTTClient: %v", err)
+	}
+	defer client.Close()
+
+	streamStart := client.StreamStartTime()
+	if streamStart.Before(before) || streamStart.After(time.Now()) {
+		t.Fatalf("stream start %v is not when the client was created", streamStart)
+	}
+
+	result, err := client.Receive(context.Background())
+	if err != nil {
+		t.Fatalf("Receive: %v", err)
+	}
+	if got, want := result.AbsoluteStart(streamStart), streamStart.Add(1500*time.Millisecond); !got.Equal(want) {
+		t.Errorf("result start: got %v, want %v", got, want)
+	}
+	if got, want := result.Words[1].AbsoluteStart(streamStart), streamStart.Add(2100*time.Millisecond); !got.Equal(want) {
+		t.Errorf("word start: got %v, want %v", got, want)
+	}
+	if got, want := result.Words[1].AbsoluteEnd(streamStart), streamStart.Add(2750*time.Millisecond); !got.Equal(want) {
+		t.Errorf("word end: got %v, want %v", got, want)
+	}
+}
+
+func TestTranscribeReturnsPromptlyOnCancel(t *testing.T) {
+	// The stub never answers, even once the audio ends
+	provider := newStubProvider(t, nil, &stubRecognizer{
+		stream: func(stream grpc.BidiStreamingServer[stt.StreamingRequest, stt.StreamingResponse]) error {
+			drainRecognizer(stream)
+			<-stream.Context().Done()
+			return nil
+		},
+	})
+
+	client, err := NewYandexSTTService(provider).newSTTClient(context.Background(), models.STTConfig{})
+	if err != nil {
+		t.Fatalf("newSTTClient: %v", err)
+	}
+	client.lc.GracePeriod = time.Minute
+
+	voicetest.CheckPromptCancel(t, client.transcribe)
+}
+
+// statusResponse is a status-code event of the given type
+func statusResponse(code stt.CodeType, message string) *stt.StreamingResponse {
+	return &stt.StreamingResponse{Event: &stt.StreamingResponse_StatusCode{StatusCode: &stt.StatusCode{CodeType: code, Message: message}}}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     *stt.StreamingResponse
+		sendDone bool
+		wantErr  bool
+	}{
+		{name: "no status", resp: &stt.StreamingResponse{}},
+		{name: "working", resp: statusResponse(stt.CodeType_WORKING, "")},
+		{name: "warning", resp: statusResponse(stt.CodeType_WARNING, "context unknown")},
+		{name: "closed mid-stream", resp: statusResponse(stt.CodeType_CLOSED, "quota exceeded"), wantErr: true},
+		{name: "closed after audio ended", resp: statusResponse(stt.CodeType_CLOSED, "done"), sendDone: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &yandexSTTClient{lc: lifecycle.New(), logger: &types.NoOpLogger{}, sendDone: tt.sendDone}
+			err := client.statusError(tt.resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "quota exceeded") {
+				t.Errorf("expected the server message in %v", err)
+			}
+		})
+	}
+}
+
+func TestServerClosedSessionEndsStream(t *testing.T) {
+	provider := newStubProvider(t, nil, &stubRecognizer{
+		stream: func(stream grpc.BidiStreamingServer[stt.StreamingRequest, stt.StreamingResponse]) error {
+			if _, err := stream.Recv(); err != nil {
+				return err
+			}
+			if err := stream.Send(statusResponse(stt.CodeType_CLOSED, "quota exceeded")); err != nil {
+				return err
+			}
+			return drainRecognizer(stream)
+		},
+	})
+
+	client, err := NewYandexSTTService(provider).NewSTTClient(context.Background(), models.STTConfig{})
+	if err != nil {
+		t.Fatalf("NewSTTClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Send(ctx, make([]byte, 320)); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	for {
+		_, err := client.Receive(ctx)
+		if err == nil {
+			continue
+		}
+		if err == io.EOF || !strings.Contains(err.Error(), "closed by server: quota exceeded") {
+			t.Errorf("expected the server closure to surface as an error, got %v", err)
+		}
+		break
+	}
+}
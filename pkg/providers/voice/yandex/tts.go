@@ -2,23 +2,24 @@ package yandex
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"sync"
-	"time"
 
 	"github.com/creastat/common-go/pkg/interfaces"
 	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/lifecycle"
 	tts "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/tts"
+	"github.com/creastat/common-go/pkg/sanitize"
 	"github.com/creastat/common-go/pkg/types"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 )
 
 const (
+	// yandexTTSEndpoint is the public endpoint, overridable with Options["tts_endpoint"]
 	yandexTTSEndpoint = "tts.api.cloud.yandex.net:443"
 )
 
@@ -43,6 +44,7 @@ func (s *YandexTTSService) NewTTSClient(ctx context.Context, config models.TTSCo
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeTTS, config.Options, s.logger)
 
 	// Set defaults if not provided
 	if config.Voice == "" {
@@ -54,20 +56,28 @@ func (s *YandexTTSService) NewTTSClient(ctx context.Context, config models.TTSCo
 	if config.SampleRate == 0 {
 		config.SampleRate = 22050
 	}
-	if config.Encoding == "" {
-		config.Encoding = "linear16"
+	// Bit depth and companding refine the requested encoding
+	if err := config.ResolveEncoding(string(models.AudioEncodingPCM16)); err != nil {
+		return nil, err
+	}
+
+	// Map the requested encoding to Yandex's native name
+	encoding, err := models.ResolveProviderEncoding(models.ProviderTypeYandex, config.Encoding)
+	if err != nil {
+		return nil, err
 	}
+	config.Encoding = encoding
 	if config.Speed == 0 {
 		config.Speed = 1.0
 	}
-	if config.Volume == 0 {
-		// Default volume for LUFS normalization (range: -145 to 0)
-		config.Volume = -19.0
+	// The volume default depends on the normalization type and is applied by resolveLoudness
+	if _, err := resolveLoudness(config); err != nil {
+		return nil, err
 	}
 
 	// Create gRPC connection
-	creds := credentials.NewTLS(&tls.Config{})
-	conn, err := grpc.NewClient(yandexTTSEndpoint, grpc.WithTransportCredentials(creds))
+	creds := s.provider.transportCredentials(config.Options)
+	conn, err := grpc.NewClient(s.provider.endpoint(config.Options, "tts_endpoint", yandexTTSEndpoint), grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Yandex TTS: %w", err)
 	}
@@ -79,8 +89,7 @@ func (s *YandexTTSService) NewTTSClient(ctx context.Context, config models.TTSCo
 		provider: s.provider,
 		audioCh:  make(chan []byte, 100),
 		errCh:    make(chan error, 1),
-		doneCh:   make(chan struct{}),
-		closed:   false,
+		lc:       lifecycle.New(),
 		ctx:      ctx,
 		logger:   s.logger,
 	}
@@ -93,6 +102,7 @@ func (s *YandexTTSService) Synthesize(ctx context.Context, text string, config m
 	if !s.provider.IsInitialized() {
 		return nil, fmt.Errorf("provider not initialized")
 	}
+	providers.WarnUnknownOptions(s.provider, models.OptionScopeTTS, config.Options, s.logger)
 
 	// Set defaults
 	if config.Voice == "" {
@@ -101,15 +111,23 @@ func (s *YandexTTSService) Synthesize(ctx context.Context, text string, config m
 	if config.SampleRate == 0 {
 		config.SampleRate = 22050
 	}
-	if config.Encoding == "" {
-		config.Encoding = "linear16"
+	// Bit depth and companding refine the requested encoding
+	if err := config.ResolveEncoding(string(models.AudioEncodingPCM16)); err != nil {
+		return nil, err
+	}
+
+	// Map the requested encoding to Yandex's native name
+	encoding, err := models.ResolveProviderEncoding(models.ProviderTypeYandex, config.Encoding)
+	if err != nil {
+		return nil, err
 	}
+	config.Encoding = encoding
 	if config.Speed == 0 {
 		config.Speed = 1.0
 	}
-	if config.Volume == 0 {
-		// Default volume for LUFS normalization (range: -145 to 0)
-		config.Volume = -19.0
+	// The volume default depends on the normalization type and is applied by resolveLoudness
+	if _, err := resolveLoudness(config); err != nil {
+		return nil, err
 	}
 
 	s.logger.Debug("Starting TTS synthesis",
@@ -117,8 +135,8 @@ func (s *YandexTTSService) Synthesize(ctx context.Context, text string, config m
 	)
 
 	// Create gRPC connection
-	creds := credentials.NewTLS(&tls.Config{})
-	conn, err := grpc.NewClient(yandexTTSEndpoint, grpc.WithTransportCredentials(creds))
+	creds := s.provider.transportCredentials(config.Options)
+	conn, err := grpc.NewClient(s.provider.endpoint(config.Options, "tts_endpoint", yandexTTSEndpoint), grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Yandex TTS: %w", err)
 	}
@@ -137,13 +155,28 @@ func (s *YandexTTSService) Synthesize(ctx context.Context, text string, config m
 	// Build request
 	req := s.buildUtteranceRequest(text, config)
 
-	// Call synthesis
+	// Nothing has been returned to the caller yet, so transient and rate-limited failures are retried
+	var audioData []byte
+	err = providers.Retry(ctx, s.provider.GetConfig().RetryPolicy, isRetryable, func(ctx context.Context) error {
+		var err error
+		audioData, err = s.synthesizeUtterance(ctx, synthesizerClient, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return audioData, nil
+}
+
+// synthesizeUtterance runs one synthesis call and collects its audio
+func (s *YandexTTSService) synthesizeUtterance(ctx context.Context, synthesizerClient tts.SynthesizerClient, req *tts.UtteranceSynthesisRequest) ([]byte, error) {
 	stream, err := synthesizerClient.UtteranceSynthesis(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start synthesis: %w", err)
+		return nil, fmt.Errorf("failed to start synthesis: %w", classifyError(err))
 	}
 
-	// Collect audio data
+	// Collect audio data. Cancelling ctx resets the gRPC stream, which stops generation server-side.
 	var audioData []byte
 	chunkCount := 0
 	for {
@@ -153,10 +186,10 @@ func (s *YandexTTSService) Synthesize(ctx context.Context, text string, config m
 				"chunks", chunkCount,
 				"total_bytes", len(audioData),
 			)
-			break
+			return audioData, nil
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to receive audio: %w", err)
+			return nil, fmt.Errorf("failed to receive audio: %w", classifyError(err))
 		}
 
 		if resp.AudioChunk != nil && len(resp.AudioChunk.Data) > 0 {
@@ -170,8 +203,15 @@ func (s *YandexTTSService) Synthesize(ctx context.Context, text string, config m
 			}
 		}
 	}
+}
 
-	return audioData, nil
+// GetVoicesByLanguage returns the available voices for a language; "ru" matches "ru-RU"
+func (s *YandexTTSService) GetVoicesByLanguage(ctx context.Context, language string) ([]models.Voice, error) {
+	voices, err := s.GetVoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.FilterVoicesByLanguage(voices, language), nil
 }
 
 // GetVoices returns available voices
@@ -279,11 +319,6 @@ func (s *YandexTTSService) buildUtteranceRequest(text string, config models.TTSC
 				Speed: config.Speed,
 			},
 		},
-		{
-			Hint: &tts.Hints_Volume{
-				Volume: config.Volume,
-			},
-		},
 	}
 
 	// Add pitch if specified
@@ -306,22 +341,74 @@ func (s *YandexTTSService) buildUtteranceRequest(text string, config models.TTSC
 		}
 	}
 
-	// Determine loudness normalization type
+	// Determine loudness normalization type and volume; validated when the client was created
+	loud, _ := resolveLoudness(config)
 	loudnessType := tts.UtteranceSynthesisRequest_LUFS
-	if config.Options != nil {
-		if normType, ok := config.Options["loudness_normalization"].(string); ok {
-			if normType == "max_peak" {
-				loudnessType = tts.UtteranceSynthesisRequest_MAX_PEAK
-			}
-		}
+	if loud.maxPeak {
+		loudnessType = tts.UtteranceSynthesisRequest_MAX_PEAK
+	}
+	if loud.set {
+		hints = append(hints, &tts.Hints{
+			Hint: &tts.Hints_Volume{
+				Volume: loud.volume,
+			},
+		})
+	}
+
+	return &tts.UtteranceSynthesisRequest{
+		Model: config.Model,
+		Utterance: &tts.UtteranceSynthesisRequest_Text{
+			Text: text,
+		},
+		Hints:                     hints,
+		OutputAudioSpec:           audioSpec,
+		LoudnessNormalizationType: loudnessType,
+		UnsafeMode:                false,
+	}
+}
+
+// loudness is the normalization type and target volume sent to Yandex
+type loudness struct {
+	maxPeak bool
+	volume  float64
+	set     bool // false leaves the volume to Yandex's default
+}
+
+// resolveLoudness returns the normalization type and volume to send.
+//
+// The volume is taken from Options["volume"] when set, otherwise from config.Volume.
+// By default it is coerced into the valid range of the normalization type:
+// LUFS accepts [-145, 0) with default -19, MAX_PEAK accepts (0, 1] with default 0.7.
+// When Options["explicit_volume"] is true the value is passed through unchanged, an out-of-range
+// value is an error, and an unset volume is not sent at all.
+func resolveLoudness(config models.TTSConfig) (loudness, error) {
+	maxPeak := false
+	if normType, ok := config.Options["loudness_normalization"].(string); ok && normType == "max_peak" {
+		maxPeak = true
 	}
 
-	// Adjust volume based on normalization type
 	volume := config.Volume
-	if loudnessType == tts.UtteranceSynthesisRequest_LUFS {
+	if v, ok := config.Options["volume"].(float64); ok {
+		volume = v
+	}
+
+	if explicit, ok := config.Options["explicit_volume"].(bool); ok && explicit {
+		if volume == 0 {
+			return loudness{maxPeak: maxPeak}, nil
+		}
+		if !maxPeak && (volume < -145 || volume >= 0) {
+			return loudness{}, fmt.Errorf("explicit LUFS volume %v is out of range [-145, 0)", volume)
+		}
+		if maxPeak && (volume <= 0 || volume > 1) {
+			return loudness{}, fmt.Errorf("explicit MAX_PEAK volume %v is out of range (0, 1]", volume)
+		}
+		return loudness{maxPeak: maxPeak, volume: volume, set: true}, nil
+	}
+
+	if !maxPeak {
 		// LUFS: range [-145, 0), default -19
-		if volume > 0 {
-			// User provided MAX_PEAK style volume (0-1), convert to LUFS
+		if volume >= 0 {
+			// Unset, or a MAX_PEAK style volume (0-1)
 			volume = -19.0
 		}
 		if volume < -145 {
@@ -330,7 +417,7 @@ func (s *YandexTTSService) buildUtteranceRequest(text string, config models.TTSC
 	} else {
 		// MAX_PEAK: range (0, 1], default 0.7
 		if volume <= 0 {
-			// User provided LUFS style volume, convert to MAX_PEAK
+			// Unset, or a LUFS style volume
 			volume = 0.7
 		}
 		if volume > 1 {
@@ -338,64 +425,42 @@ func (s *YandexTTSService) buildUtteranceRequest(text string, config models.TTSC
 		}
 	}
 
-	// Update volume hint with adjusted value
-	for i, hint := range hints {
-		if _, ok := hint.Hint.(*tts.Hints_Volume); ok {
-			hints[i] = &tts.Hints{
-				Hint: &tts.Hints_Volume{
-					Volume: volume,
-				},
-			}
-			break
-		}
-	}
-
-	return &tts.UtteranceSynthesisRequest{
-		Model: config.Model,
-		Utterance: &tts.UtteranceSynthesisRequest_Text{
-			Text: text,
-		},
-		Hints:                     hints,
-		OutputAudioSpec:           audioSpec,
-		LoudnessNormalizationType: loudnessType,
-		UnsafeMode:                false,
-	}
+	return loudness{maxPeak: maxPeak, volume: volume, set: true}, nil
 }
 
 // yandexTTSClient implements the TTSClient interface using StreamSynthesis
 type yandexTTSClient struct {
-	conn      *grpc.ClientConn
-	config    models.TTSConfig
-	provider  *YandexProvider
-	stream    tts.Synthesizer_StreamSynthesisClient
-	audioCh   chan []byte
-	errCh     chan error
-	doneCh    chan struct{}
-	mu        sync.Mutex
-	closed    bool
-	ctx       context.Context
-	wg        sync.WaitGroup // Track receiver goroutine
-	closeOnce sync.Once      // Ensure audioCh is closed only once
-	logger    types.Logger
+	conn     *grpc.ClientConn
+	config   models.TTSConfig
+	provider *YandexProvider
+	stream   tts.Synthesizer_StreamSynthesisClient
+	audioCh  chan []byte
+	errCh    chan error
+	lc       *lifecycle.Lifecycle
+	mu       sync.Mutex // serializes writes to stream
+	sendDone bool
+	ctx      context.Context
+	logger   types.Logger
 }
 
 // Send sends text to be synthesized using StreamSynthesis API for low latency
 func (c *yandexTTSClient) Send(ctx context.Context, text string) error {
 	c.mu.Lock()
-	if c.closed {
-		c.mu.Unlock()
+	defer c.mu.Unlock()
+
+	if c.lc.IsClosing() || c.sendDone {
 		return fmt.Errorf("TTS client is closed - cannot start new synthesis")
 	}
 
 	// Initialize stream on first Send
 	if c.stream == nil {
 		if err := c.initStream(); err != nil {
-			c.mu.Unlock()
 			return fmt.Errorf("failed to initialize stream: %w", err)
 		}
 	}
-	c.mu.Unlock()
 
+	// Protobuf strings must be valid UTF-8
+	text = sanitize.Text(text)
 	if text == "" {
 		return nil
 	}
@@ -415,7 +480,7 @@ func (c *yandexTTSClient) Send(ctx context.Context, text string) error {
 	}
 
 	if err := c.stream.Send(req); err != nil {
-		return fmt.Errorf("failed to send text: %w", err)
+		return fmt.Errorf("failed to send text: %w", classifyError(err))
 	}
 
 	return nil
@@ -436,7 +501,7 @@ func (c *yandexTTSClient) initStream() error {
 	// Start bidirectional stream
 	stream, err := synthesizerClient.StreamSynthesis(streamCtx)
 	if err != nil {
-		return fmt.Errorf("failed to create stream: %w", err)
+		return fmt.Errorf("failed to create stream: %w", classifyError(err))
 	}
 	c.stream = stream
 
@@ -449,26 +514,23 @@ func (c *yandexTTSClient) initStream() error {
 	}
 
 	if err := stream.Send(req); err != nil {
-		return fmt.Errorf("failed to send options: %w", err)
+		return fmt.Errorf("failed to send options: %w", classifyError(err))
 	}
 
 	c.logger.Debug("TTS stream initialized",
 		"voice", c.config.Voice,
 		"speed", c.config.Speed,
-		"volume", c.config.Volume,
+		"volume", opts.Volume,
 	)
 
 	// Start receiver goroutine
-	c.wg.Add(1)
-	go c.receiveAudio()
+	c.lc.Go(c.receiveAudio)
 
 	return nil
 }
 
 // receiveAudio receives audio chunks from the stream
 func (c *yandexTTSClient) receiveAudio() {
-	defer c.wg.Done()
-
 	chunkCount := 0
 	totalBytes := 0
 
@@ -482,9 +544,11 @@ func (c *yandexTTSClient) receiveAudio() {
 			return
 		}
 		if err != nil {
-			select {
-			case c.errCh <- fmt.Errorf("failed to receive audio: %w", err):
-			default:
+			if !c.lc.IsClosing() {
+				select {
+				case c.errCh <- fmt.Errorf("failed to receive audio: %w", classifyError(err)):
+				default:
+				}
 			}
 			return
 		}
@@ -500,10 +564,7 @@ func (c *yandexTTSClient) receiveAudio() {
 			// Send audio chunk
 			select {
 			case c.audioCh <- resp.AudioChunk.Data:
-			case <-time.After(5 * time.Second):
-				c.logger.Warn("Timeout sending TTS chunk to channel",
-					"chunk_number", chunkCount,
-				)
+			case <-c.lc.Aborted():
 				return
 			}
 		}
@@ -525,32 +586,12 @@ func (c *yandexTTSClient) buildSynthesisOptions() *tts.SynthesisOptions {
 		},
 	}
 
-	// Determine loudness normalization type
+	// Determine loudness normalization type and volume; validated when the client was created.
+	// An unset volume is left at zero, which is not sent.
+	loud, _ := resolveLoudness(c.config)
 	loudnessType := tts.LoudnessNormalizationType_LUFS
-	if c.config.Options != nil {
-		if normType, ok := c.config.Options["loudness_normalization"].(string); ok {
-			if normType == "max_peak" {
-				loudnessType = tts.LoudnessNormalizationType_MAX_PEAK
-			}
-		}
-	}
-
-	// Adjust volume based on normalization type
-	volume := c.config.Volume
-	if loudnessType == tts.LoudnessNormalizationType_LUFS {
-		if volume > 0 {
-			volume = -19.0
-		}
-		if volume < -145 {
-			volume = -145
-		}
-	} else {
-		if volume <= 0 {
-			volume = 0.7
-		}
-		if volume > 1 {
-			volume = 1.0
-		}
+	if loud.maxPeak {
+		loudnessType = tts.LoudnessNormalizationType_MAX_PEAK
 	}
 
 	// Get role if specified
@@ -566,7 +607,7 @@ func (c *yandexTTSClient) buildSynthesisOptions() *tts.SynthesisOptions {
 		Voice:                     c.config.Voice,
 		Role:                      role,
 		Speed:                     c.config.Speed,
-		Volume:                    volume,
+		Volume:                    loud.volume,
 		PitchShift:                c.config.Pitch,
 		OutputAudioSpec:           audioSpec,
 		LoudnessNormalizationType: loudnessType,
@@ -600,11 +641,6 @@ func (c *yandexTTSClient) buildUtteranceRequest(text string) *tts.UtteranceSynth
 				Speed: c.config.Speed,
 			},
 		},
-		{
-			Hint: &tts.Hints_Volume{
-				Volume: c.config.Volume,
-			},
-		},
 	}
 
 	// Add pitch if specified
@@ -627,48 +663,18 @@ func (c *yandexTTSClient) buildUtteranceRequest(text string) *tts.UtteranceSynth
 		}
 	}
 
-	// Determine loudness normalization type
+	// Determine loudness normalization type and volume; validated when the client was created
+	loud, _ := resolveLoudness(c.config)
 	loudnessType := tts.UtteranceSynthesisRequest_LUFS
-	if c.config.Options != nil {
-		if normType, ok := c.config.Options["loudness_normalization"].(string); ok {
-			if normType == "max_peak" {
-				loudnessType = tts.UtteranceSynthesisRequest_MAX_PEAK
-			}
-		}
-	}
-
-	// Adjust volume based on normalization type
-	volume := c.config.Volume
-	if loudnessType == tts.UtteranceSynthesisRequest_LUFS {
-		// LUFS: range [-145, 0), default -19
-		if volume > 0 {
-			// User provided MAX_PEAK style volume (0-1), convert to LUFS
-			volume = -19.0
-		}
-		if volume < -145 {
-			volume = -145
-		}
-	} else {
-		// MAX_PEAK: range (0, 1], default 0.7
-		if volume <= 0 {
-			// User provided LUFS style volume, convert to MAX_PEAK
-			volume = 0.7
-		}
-		if volume > 1 {
-			volume = 1.0
-		}
+	if loud.maxPeak {
+		loudnessType = tts.UtteranceSynthesisRequest_MAX_PEAK
 	}
-
-	// Update volume hint with adjusted value
-	for i, hint := range hints {
-		if _, ok := hint.Hint.(*tts.Hints_Volume); ok {
-			hints[i] = &tts.Hints{
-				Hint: &tts.Hints_Volume{
-					Volume: volume,
-				},
-			}
-			break
-		}
+	if loud.set {
+		hints = append(hints, &tts.Hints{
+			Hint: &tts.Hints_Volume{
+				Volume: loud.volume,
+			},
+		})
 	}
 
 	// Create the request with proper protobuf types
@@ -688,53 +694,29 @@ func (c *yandexTTSClient) buildUtteranceRequest(text string) *tts.UtteranceSynth
 
 // Receive receives synthesized audio data
 func (c *yandexTTSClient) Receive(ctx context.Context) ([]byte, error) {
-	select {
-	case audio, ok := <-c.audioCh:
-		if !ok {
-			// Channel closed, EOF
-			return nil, io.EOF
-		}
-		return audio, nil
-	case err := <-c.errCh:
-		return nil, err
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	}
+	return lifecycle.Receive(ctx, c.lc, c.audioCh, c.errCh)
 }
 
 // Close closes the TTS client and releases resources
 func (c *yandexTTSClient) Close() error {
+	return c.lc.Close(c.closeSend, c.conn.Close)
+}
+
+// closeSend signals end of text input to the server once
+func (c *yandexTTSClient) closeSend() error {
 	c.mu.Lock()
-	if c.closed {
-		c.mu.Unlock()
-		return nil
-	}
-	c.closed = true
-	c.mu.Unlock()
+	defer c.mu.Unlock()
 
-	// Close the send side of the stream if it exists
-	if c.stream != nil {
-		if err := c.stream.CloseSend(); err != nil {
-			c.logger.Warn("Error closing TTS stream send",
-				"error", err,
-			)
-		}
+	if c.sendDone || c.stream == nil {
+		return nil
 	}
+	c.sendDone = true
 
-	// Wait for receiver goroutine to finish
-	c.wg.Wait()
-
-	// Close audioCh only once after receiver is done
-	c.closeOnce.Do(func() {
-		close(c.audioCh)
-	})
-
-	// Signal done
-	close(c.doneCh)
-
-	// Close the connection
-	if c.conn != nil {
-		return c.conn.Close()
+	if err := c.stream.CloseSend(); err != nil {
+		c.logger.Warn("Error closing TTS stream send",
+			"error", err,
+		)
+		return err
 	}
 
 	return nil
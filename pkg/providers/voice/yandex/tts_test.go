@@ -0,0 +1,182 @@
+package yandex
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/models"
+	"github.com/creastat/common-go/pkg/providers/voice/internal/voicetest"
+	tts "github.com/creastat/common-go/pkg/providers/voice/yandex/proto/generated/tts"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// drainSynthesizer reads the stream until the client half-closes it, then ends the RPC
+func drainSynthesizer(stream grpc.BidiStreamingServer[tts.StreamSynthesisRequest, tts.StreamSynthesisResponse]) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func TestTTSClientClose(t *testing.T) {
+	provider := newStubProvider(t, &stubSynthesizer{stream: drainSynthesizer}, nil)
+
+	client, err := NewYandexTTSService(provider).NewTTSClient(context.Background(), models.TTSConfig{})
+	if err != nil {
+		t.Fatalf("NewTTSClient: %v", err)
+	}
+	if err := client.Send(context.Background(), "hello"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestTTSClientCloseBeforeSend(t *testing.T) {
+	provider := newStubProvider(t, &stubSynthesizer{stream: drainSynthesizer}, nil)
+
+	client, err := NewYandexTTSService(provider).NewTTSClient(context.Background(), models.TTSConfig{})
+	if err != nil {
+		t.Fatalf("NewTTSClient: %v", err)
+	}
+
+	voicetest.CheckClose(t, client.Close, func(ctx context.Context) error {
+		_, err := client.Receive(ctx)
+		return err
+	})
+}
+
+func TestResolveLoudness(t *testing.T) {
+	tests := []struct {
+		name    string
+		volume  float64
+		options map[string]any
+		want    loudness
+		wantErr bool
+	}{
+		{name: "LUFS default", want: loudness{volume: -19, set: true}},
+		{name: "LUFS clamped", volume: -200, want: loudness{volume: -145, set: true}},
+		{name: "MAX_PEAK default", options: map[string]any{"loudness_normalization": "max_peak"}, want: loudness{maxPeak: true, volume: 0.7, set: true}},
+		{name: "MAX_PEAK clamped", volume: 3, options: map[string]any{"loudness_normalization": "max_peak"}, want: loudness{maxPeak: true, volume: 1, set: true}},
+		{name: "explicit LUFS", options: map[string]any{"explicit_volume": true, "volume": -145.0}, want: loudness{volume: -145, set: true}},
+		{name: "explicit MAX_PEAK", volume: 0.3, options: map[string]any{"explicit_volume": true, "loudness_normalization": "max_peak"}, want: loudness{maxPeak: true, volume: 0.3, set: true}},
+		{name: "explicit unset", options: map[string]any{"explicit_volume": true}, want: loudness{}},
+		{name: "explicit LUFS too loud", volume: 0.5, options: map[string]any{"explicit_volume": true}, wantErr: true},
+		{name: "explicit LUFS too quiet", volume: -146, options: map[string]any{"explicit_volume": true}, wantErr: true},
+		{name: "explicit MAX_PEAK above 1", volume: 1.5, options: map[string]any{"explicit_volume": true, "loudness_normalization": "max_peak"}, wantErr: true},
+		{name: "explicit MAX_PEAK negative", volume: -19, options: map[string]any{"explicit_volume": true, "loudness_normalization": "max_peak"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveLoudness(models.TTSConfig{Volume: tt.volume, Options: tt.options})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveLoudness: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUtteranceRequestOmitsUnsetExplicitVolume(t *testing.T) {
+	service := NewYandexTTSService(NewYandexProvider(nil))
+
+	req := service.buildUtteranceRequest("hello", models.TTSConfig{Options: map[string]any{"explicit_volume": true}})
+	for _, hint := range req.Hints {
+		if _, ok := hint.Hint.(*tts.Hints_Volume); ok {
+			t.Errorf("expected no volume hint, got %v", hint)
+		}
+	}
+
+	req = service.buildUtteranceRequest("hello", models.TTSConfig{})
+	var volume float64
+	for _, hint := range req.Hints {
+		if v, ok := hint.Hint.(*tts.Hints_Volume); ok {
+			volume = v.Volume
+		}
+	}
+	if volume != -19 {
+		t.Errorf("expected the default -19 LUFS volume hint, got %v", volume)
+	}
+}
+
+func TestSynthesizeRejectsOutOfRangeExplicitVolume(t *testing.T) {
+	provider := newStubProvider(t, &stubSynthesizer{}, nil)
+
+	_, err := NewYandexTTSService(provider).Synthesize(context.Background(), "hello", models.TTSConfig{
+		Volume:  5,
+		Options: map[string]any{"explicit_volume": true},
+	})
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("expected an out of range error, got %v", err)
+	}
+}
+
+func TestSynthesizeCancelStopsServerStream(t *testing.T) {
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	calls := 0
+	provider := newStubProvider(t, &stubSynthesizer{
+		utterance: func(req *tts.UtteranceSynthesisRequest, stream grpc.ServerStreamingServer[tts.UtteranceSynthesisResponse]) error {
+			calls++
+			if err := stream.Send(&tts.UtteranceSynthesisResponse{AudioChunk: &tts.AudioChunk{Data: []byte("audio")}}); err != nil {
+				return err
+			}
+			close(started)
+
+			// Synthesis is still running when the caller gives up
+			<-stream.Context().Done()
+			close(stopped)
+			return stream.Context().Err()
+		},
+	}, nil)
+	provider.config.RetryPolicy = &models.RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		_, err := NewYandexTTSService(provider).Synthesize(ctx, "hello", models.TTSConfig{})
+		result <- err
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-result:
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("expected a canceled status, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Synthesize did not return after cancellation")
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server stream was not cancelled")
+	}
+	if calls != 1 {
+		t.Errorf("expected a cancelled synthesis not to be retried, got %d calls", calls)
+	}
+}
@@ -0,0 +1,27 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Text prepares text for providers that require clean UTF-8 (JSON WebSocket payloads, protobuf strings).
+// Invalid UTF-8 sequences are dropped, CR and CRLF line endings become LF, and control characters
+// other than newline and tab are removed.
+func Text(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\r':
+			return '\n'
+		case r == '\n' || r == '\t':
+			return r
+		case unicode.IsControl(r):
+			return -1
+		default:
+			return r
+		}
+	}, s)
+}
@@ -0,0 +1,38 @@
+package sanitize
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "clean", in: "Привет, world! 👋", want: "Привет, world! 👋"},
+		{name: "newline and tab kept", in: "a\tb\nc", want: "a\tb\nc"},
+		{name: "CRLF", in: "one\r\ntwo\r\n", want: "one\ntwo\n"},
+		{name: "lone CR", in: "one\rtwo", want: "one\ntwo"},
+		{name: "C0 controls", in: "a\x00b\x07c\x1bd\x7f", want: "abcd"},
+		{name: "C1 controls", in: "a\u0085b\u009fc", want: "abc"},
+		{name: "invalid byte", in: "ab\xffcd", want: "abcd"},
+		{name: "truncated sequence", in: "caf\xc3", want: "caf"},
+		{name: "surrogate half", in: "x\xed\xa0\x80y", want: "xy"},
+		{name: "invalid and control together", in: "\xfe\x01ok\r\n\xc0\xaf", want: "ok\n"},
+		{name: "empty", in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Text(tt.in)
+			if got != tt.want {
+				t.Errorf("Text(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("Text(%q) returned invalid UTF-8 %q", tt.in, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package supabase
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// adaptiveBackoff tracks a shared retry delay that widens on consecutive failures
+// and narrows on success, so concurrent callers back off together
+type adaptiveBackoff struct {
+	mu         sync.Mutex
+	min        time.Duration
+	max        time.Duration
+	multiplier float64
+	current    time.Duration
+}
+
+// newAdaptiveBackoff creates a new adaptive backoff starting with no delay
+func newAdaptiveBackoff(min, max time.Duration, multiplier float64) *adaptiveBackoff {
+	return &adaptiveBackoff{
+		min:        min,
+		max:        max,
+		multiplier: multiplier,
+	}
+}
+
+// Delay returns the current backoff delay
+func (b *adaptiveBackoff) Delay() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.current
+}
+
+// Failure widens the delay after a transient failure
+func (b *adaptiveBackoff) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.current < b.min {
+		b.current = b.min
+		return
+	}
+
+	b.current = time.Duration(float64(b.current) * b.multiplier)
+	if b.current > b.max {
+		b.current = b.max
+	}
+}
+
+// Success narrows the delay after a successful request
+func (b *adaptiveBackoff) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current = time.Duration(float64(b.current) / b.multiplier)
+	if b.current < b.min {
+		b.current = 0
+	}
+}
+
+// Wait sleeps for the current delay with jitter, returning early if the context is done
+func (b *adaptiveBackoff) Wait(ctx context.Context) error {
+	delay := b.Delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	// Full jitter in [delay/2, delay) spreads out callers that failed together
+	half := delay / 2
+	delay = half + time.Duration(rand.Int63n(int64(half)+1))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,44 @@
+package supabase
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveBackoff(t *testing.T) {
+	b := newAdaptiveBackoff(100*time.Millisecond, time.Second, 2)
+	if b.Delay() != 0 {
+		t.Fatalf("expected no initial delay, got %v", b.Delay())
+	}
+
+	// Failures start at min and double until capped at max
+	for _, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		b.Failure()
+		if got := b.Delay(); got != want*time.Millisecond {
+			t.Errorf("after failure: got %v, want %v", got, want*time.Millisecond)
+		}
+	}
+
+	// Successes halve the delay and drop it to zero below min
+	for _, want := range []time.Duration{500, 250, 125, 0, 0} {
+		b.Success()
+		if got := b.Delay(); got != want*time.Millisecond {
+			t.Errorf("after success: got %v, want %v", got, want*time.Millisecond)
+		}
+	}
+}
+
+func TestAdaptiveBackoffWait(t *testing.T) {
+	b := newAdaptiveBackoff(time.Hour, time.Hour, 2)
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("expected no wait without a delay, got %v", err)
+	}
+
+	b.Failure()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected the context to end the wait, got %v", err)
+	}
+}
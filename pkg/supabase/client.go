@@ -22,6 +22,15 @@ type Client struct {
 	cache      *sourceCache
 	cacheTTL   time.Duration
 	logger     types.Logger
+
+	embeddingDimensions int
+	documentBatchSize   int
+
+	searchBackoff    *adaptiveBackoff
+	searchMaxRetries int
+
+	realtimeCancel context.CancelFunc
+	realtimeDone   chan struct{}
 }
 
 // ClientConfig holds configuration for the Supabase client
@@ -31,6 +40,22 @@ type ClientConfig struct {
 	CacheTTL time.Duration // Default: 5 minutes
 	Timeout  time.Duration // HTTP client timeout
 	Logger   types.Logger
+
+	// EmbeddingDimensions is the expected embedding vector length (0 = infer from the first vector in a batch)
+	EmbeddingDimensions int
+
+	// DocumentBatchSize caps the documents sent per request by BatchUpsertDocuments (Default: 500)
+	DocumentBatchSize int
+
+	// Search backoff widens on consecutive transient search failures and narrows on success
+	SearchBackoffMin        time.Duration // Default: 100ms
+	SearchBackoffMax        time.Duration // Default: 5s
+	SearchBackoffMultiplier float64       // Default: 2
+	SearchMaxRetries        *int          // Default: 3; 0 disables retries
+
+	// RealtimeSourceInvalidation subscribes to changes on the sources table via Supabase Realtime
+	// and evicts cached sources immediately instead of waiting for CacheTTL
+	RealtimeSourceInvalidation bool
 }
 
 // sourceCache provides thread-safe caching for source configurations
@@ -61,13 +86,29 @@ func NewClient(config ClientConfig) (*Client, error) {
 	if config.Timeout == 0 {
 		config.Timeout = 10 * time.Second
 	}
+	if config.SearchBackoffMin == 0 {
+		config.SearchBackoffMin = 100 * time.Millisecond
+	}
+	if config.SearchBackoffMax == 0 {
+		config.SearchBackoffMax = 5 * time.Second
+	}
+	if config.SearchBackoffMultiplier <= 1 {
+		config.SearchBackoffMultiplier = 2
+	}
+	searchMaxRetries := 3
+	if config.SearchMaxRetries != nil {
+		searchMaxRetries = max(0, *config.SearchMaxRetries)
+	}
+	if config.DocumentBatchSize <= 0 {
+		config.DocumentBatchSize = 500
+	}
 
 	logger := config.Logger
 	if logger == nil {
 		logger = &types.NoOpLogger{}
 	}
 
-	return &Client{
+	client := &Client{
 		url:    strings.TrimSuffix(config.URL, "/"),
 		apiKey: config.APIKey,
 		httpClient: &http.Client{
@@ -79,7 +120,31 @@ func NewClient(config ClientConfig) (*Client, error) {
 		},
 		cacheTTL: config.CacheTTL,
 		logger:   logger,
-	}, nil
+
+		embeddingDimensions: config.EmbeddingDimensions,
+		documentBatchSize:   config.DocumentBatchSize,
+
+		searchBackoff:    newAdaptiveBackoff(config.SearchBackoffMin, config.SearchBackoffMax, config.SearchBackoffMultiplier),
+		searchMaxRetries: searchMaxRetries,
+	}
+
+	if config.RealtimeSourceInvalidation {
+		ctx, cancel := context.WithCancel(context.Background())
+		client.realtimeCancel = cancel
+		client.realtimeDone = make(chan struct{})
+		go client.watchSourceChanges(ctx)
+	}
+
+	return client, nil
+}
+
+// Close stops the realtime source subscription if it is running
+func (c *Client) Close() error {
+	if c.realtimeCancel != nil {
+		c.realtimeCancel()
+		<-c.realtimeDone
+	}
+	return nil
 }
 
 // ValidateToken validates a site token and returns the associated source configuration
@@ -193,6 +258,16 @@ func (c *Client) GetSourceByID(ctx context.Context, sourceID string) (*types.Sou
 
 // SearchDocuments performs vector similarity search against documents for a source
 func (c *Client) SearchDocuments(ctx context.Context, req types.SearchRequest) ([]types.SearchResult, error) {
+	page, err := c.SearchDocumentsPage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return page.Results, nil
+}
+
+// SearchDocumentsPage performs a paged vector similarity search. HasMore is set when the
+// page holds MaxResults matches; fetch the next page by advancing Offset by MaxResults.
+func (c *Client) SearchDocumentsPage(ctx context.Context, req types.SearchRequest) (*types.SearchPage, error) {
 	// Prepare RPC parameters
 	params := map[string]any{
 		"p_source_id":     req.SourceID,
@@ -201,18 +276,59 @@ func (c *Client) SearchDocuments(ctx context.Context, req types.SearchRequest) (
 		"match_count":     req.MaxResults,
 	}
 
-	rpcURL := fmt.Sprintf("%s/rest/v1/rpc/search_documents_by_source", c.url)
-	rpcReq, err := http.NewRequestWithContext(ctx, "POST", rpcURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	// Only send p_offset when paging so the RPC stays compatible with signatures that lack it
+	if req.Offset > 0 {
+		params["p_offset"] = req.Offset
 	}
 
-	// Set body
 	jsonBody, err := json.Marshal(params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal params: %w", err)
 	}
-	rpcReq.Body = io.NopCloser(bytes.NewReader(jsonBody))
+
+	var lastErr error
+	for attempt := 0; attempt <= c.searchMaxRetries; attempt++ {
+		// Back off while the search RPC is under contention
+		if err := c.searchBackoff.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		results, retryable, err := c.searchOnce(ctx, jsonBody)
+		if err == nil {
+			c.searchBackoff.Success()
+			return &types.SearchPage{
+				Results: results,
+				HasMore: req.MaxResults > 0 && len(results) == req.MaxResults,
+			}, nil
+		}
+
+		lastErr = err
+		if !retryable {
+			return nil, err
+		}
+
+		c.searchBackoff.Failure()
+		c.logger.Warn("Supabase search failed, backing off",
+			"attempt", attempt+1,
+			"delay", c.searchBackoff.Delay(),
+			"error", err,
+		)
+	}
+
+	if lastErr == nil {
+		return nil, fmt.Errorf("search was not attempted")
+	}
+	return nil, lastErr
+}
+
+// searchOnce executes a single search RPC and reports whether a failure is transient
+func (c *Client) searchOnce(ctx context.Context, jsonBody []byte) ([]types.SearchResult, bool, error) {
+	rpcURL := fmt.Sprintf("%s/rest/v1/rpc/search_documents_by_source", c.url)
+	// Passing the body here sets ContentLength and GetBody, so redirects and retries can replay it
+	rpcReq, err := http.NewRequestWithContext(ctx, "POST", rpcURL, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
 
 	// Set headers
 	rpcReq.Header.Set("apikey", c.apiKey)
@@ -223,7 +339,10 @@ func (c *Client) SearchDocuments(ctx context.Context, req types.SearchRequest) (
 	// Execute request
 	resp, err := c.httpClient.Do(rpcReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute RPC: %w", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, false, ctxErr
+		}
+		return nil, true, fmt.Errorf("failed to execute RPC: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -231,7 +350,8 @@ func (c *Client) SearchDocuments(ctx context.Context, req types.SearchRequest) (
 		// Read body for error details
 		body, _ := io.ReadAll(resp.Body)
 		c.logger.Error("Supabase RPC failed", "status", resp.StatusCode, "body", string(body))
-		return nil, fmt.Errorf("RPC failed: status %d", resp.StatusCode)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, retryable, fmt.Errorf("RPC failed: status %d", resp.StatusCode)
 	}
 
 	// Parse response
@@ -247,7 +367,7 @@ func (c *Client) SearchDocuments(ctx context.Context, req types.SearchRequest) (
 
 	var results []rpcResult
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, false, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	// Convert to domain model
@@ -260,7 +380,7 @@ func (c *Client) SearchDocuments(ctx context.Context, req types.SearchRequest) (
 		}
 	}
 
-	return searchResults, nil
+	return searchResults, false, nil
 }
 
 // getFromCache retrieves a source from cache by token or ID
@@ -0,0 +1,128 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// newTestClient creates a client against a test server with a long HTTP timeout,
+// so only the caller's context can end a request early
+func newTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+
+	client, err := NewClient(ClientConfig{URL: url, APIKey: "test-key", Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestSearchDocumentsAbortsOnContextCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.SearchDocuments(ctx, types.SearchRequest{SourceID: "source", MaxResults: 5})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > 2*time.Second {
+		t.Fatalf("search returned after %v, want it to stop at the context deadline", elapsed)
+	}
+}
+
+func TestSearchDocumentsSendsBodyIntact(t *testing.T) {
+	var (
+		body          map[string]any
+		contentLength int64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	req := types.SearchRequest{SourceID: "source-1", QueryEmbedding: []float32{0.5, -0.25}, Threshold: 0.7, MaxResults: 3}
+	if _, err := client.SearchDocuments(context.Background(), req); err != nil {
+		t.Fatalf("SearchDocuments: %v", err)
+	}
+
+	if contentLength <= 0 {
+		t.Errorf("expected a Content-Length, got %d", contentLength)
+	}
+	if body["p_source_id"] != "source-1" || body["match_threshold"] != 0.7 || body["match_count"] != float64(3) {
+		t.Errorf("unexpected body: %v", body)
+	}
+	if embedding, ok := body["query_embedding"].([]any); !ok || len(embedding) != 2 || embedding[0] != 0.5 || embedding[1] != -0.25 {
+		t.Errorf("unexpected query_embedding: %v", body["query_embedding"])
+	}
+}
+
+func TestSearchDocumentsRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries *int
+		wantCalls  int32
+	}{
+		{name: "default", maxRetries: nil, wantCalls: 4},
+		{name: "disabled", maxRetries: new(int), wantCalls: 1},
+		{name: "negative", maxRetries: func() *int { n := -2; return &n }(), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientConfig{
+				URL:              server.URL,
+				APIKey:           "test-key",
+				SearchBackoffMin: time.Millisecond,
+				SearchBackoffMax: time.Millisecond,
+				SearchMaxRetries: tt.maxRetries,
+			})
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+
+			page, err := client.SearchDocumentsPage(context.Background(), types.SearchRequest{SourceID: "source", MaxResults: 5})
+			if err == nil || page != nil {
+				t.Fatalf("expected an error and no page, got %v, %v", page, err)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("expected %d attempts, got %d", tt.wantCalls, got)
+			}
+		})
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -168,6 +172,51 @@ func (c *Client) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
 	return &results[0], nil
 }
 
+// ListJobsBySource lists jobs for a source, newest first. An empty status matches every status;
+// a limit of 0 leaves the page size to the server.
+func (c *Client) ListJobsBySource(ctx context.Context, sourceID uuid.UUID, status string, limit, offset int) ([]Job, error) {
+	query := url.Values{}
+	query.Set("source_id", "eq."+sourceID.String())
+	if status != "" {
+		query.Set("status", "eq."+status)
+	}
+	query.Set("order", "created_at.desc")
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+
+	listURL := fmt.Sprintf("%s/rest/v1/ingestion_jobs?%s", c.url, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", listURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("apikey", c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list jobs failed: status %d", resp.StatusCode)
+	}
+
+	var results []Job
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return results, nil
+}
+
 // UpsertDocument creates or updates a document
 func (c *Client) UpsertDocument(ctx context.Context, doc *Document) (uuid.UUID, error) {
 	url := fmt.Sprintf("%s/rest/v1/documents", c.url)
@@ -209,12 +258,219 @@ func (c *Client) UpsertDocument(ctx context.Context, doc *Document) (uuid.UUID,
 	return results[0].ID, nil
 }
 
+// BatchUpsertDocuments upserts documents in chunks of the configured batch size and
+// returns their IDs in input order. Once ctx is done no further chunk is sent, and the IDs
+// of the chunks already upserted are returned with the context error.
+func (c *Client) BatchUpsertDocuments(ctx context.Context, docs []Document) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(docs))
+	for start := 0; start < len(docs); start += c.documentBatchSize {
+		end := min(start+c.documentBatchSize, len(docs))
+
+		if err := ctx.Err(); err != nil {
+			return ids, fmt.Errorf("upsert stopped before documents %d-%d: %w", start, len(docs)-1, err)
+		}
+
+		batchIDs, err := c.upsertDocumentBatch(ctx, docs[start:end])
+		if err != nil {
+			return ids, fmt.Errorf("failed to upsert documents %d-%d: %w", start, end-1, err)
+		}
+		ids = append(ids, batchIDs...)
+	}
+
+	return ids, nil
+}
+
+// upsertDocumentBatch upserts a single batch of documents in one request
+func (c *Client) upsertDocumentBatch(ctx context.Context, docs []Document) ([]uuid.UUID, error) {
+	url := fmt.Sprintf("%s/rest/v1/documents", c.url)
+
+	payload, err := json.Marshal(docs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal documents: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("apikey", c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "return=representation,resolution=merge-duplicates")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert documents: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("upsert documents failed: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var results []Document
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Rows come back in insertion order, so a count mismatch means the IDs cannot be matched up
+	if len(results) != len(docs) {
+		return nil, fmt.Errorf("upsert documents returned %d rows for %d documents", len(results), len(docs))
+	}
+
+	ids := make([]uuid.UUID, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+
+	return ids, nil
+}
+
+// NotFoundError is returned when an operation matched no rows
+type NotFoundError struct {
+	Table string
+	ID    string
+}
+
+// Error implements the error interface
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %s", e.Table, e.ID)
+}
+
+// DeleteDocument deletes a document by ID
+func (c *Client) DeleteDocument(ctx context.Context, documentID uuid.UUID) error {
+	return c.deleteRows(ctx, "documents", "id", documentID)
+}
+
+// DeleteEmbeddingsByDocument deletes every embedding of a document
+func (c *Client) DeleteEmbeddingsByDocument(ctx context.Context, documentID uuid.UUID) error {
+	return c.deleteRows(ctx, "embeddings", "document_id", documentID)
+}
+
+// deleteRows deletes the rows of a table whose column equals id, returning a NotFoundError when none matched
+func (c *Client) deleteRows(ctx context.Context, table, column string, id uuid.UUID) error {
+	// Only the ids of the deleted rows are returned; embeddings would otherwise come back with their vectors
+	url := fmt.Sprintf("%s/rest/v1/%s?%s=eq.%s&select=id", c.url, table, column, id.String())
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("apikey", c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Prefer", "return=representation")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", table, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("delete from %s failed: status %d", table, resp.StatusCode)
+	}
+
+	// The deleted rows are returned, so an empty array means nothing matched
+	var deleted []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&deleted); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(deleted) == 0 {
+		return &NotFoundError{Table: table, ID: id.String()}
+	}
+
+	return nil
+}
+
 // BatchInsertEmbeddings inserts multiple embeddings
 func (c *Client) BatchInsertEmbeddings(ctx context.Context, embeddings []Embedding) error {
 	if len(embeddings) == 0 {
 		return nil
 	}
 
+	if err := c.validateEmbeddingDimensions(embeddings); err != nil {
+		return err
+	}
+
+	return c.insertEmbeddings(ctx, embeddings)
+}
+
+// DefaultEmbeddingSubBatchSize is the number of embeddings posted per request by
+// BatchInsertEmbeddingsPartial when no sub-batch size is given
+const DefaultEmbeddingSubBatchSize = 500
+
+// SubBatchError reports a sub-batch of embeddings, embeddings[Start:End], that failed to insert
+type SubBatchError struct {
+	Start int
+	End   int
+	Err   error
+}
+
+// Error implements the error interface
+func (e *SubBatchError) Error() string {
+	return fmt.Sprintf("embeddings %d-%d: %v", e.Start, e.End-1, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *SubBatchError) Unwrap() error {
+	return e.Err
+}
+
+// BatchInsertReport summarizes a partial batch insert
+type BatchInsertReport struct {
+	Inserted int
+	Failed   []SubBatchError
+}
+
+// Err returns the sub-batch failures joined into one error, or nil when every sub-batch was inserted
+func (r *BatchInsertReport) Err() error {
+	errs := make([]error, len(r.Failed))
+	for i := range r.Failed {
+		errs[i] = &r.Failed[i]
+	}
+	return errors.Join(errs...)
+}
+
+// BatchInsertEmbeddingsPartial inserts embeddings in sub-batches of subBatchSize, continuing past
+// failed sub-batches instead of discarding the whole batch. The report lists every sub-batch that
+// failed; once ctx is done the remaining sub-batches are reported as failed with its error.
+func (c *Client) BatchInsertEmbeddingsPartial(ctx context.Context, embeddings []Embedding, subBatchSize int) *BatchInsertReport {
+	if subBatchSize <= 0 {
+		subBatchSize = DefaultEmbeddingSubBatchSize
+	}
+
+	report := &BatchInsertReport{}
+	for start := 0; start < len(embeddings); start += subBatchSize {
+		end := min(start+subBatchSize, len(embeddings))
+
+		if err := ctx.Err(); err != nil {
+			report.Failed = append(report.Failed, SubBatchError{Start: start, End: len(embeddings), Err: err})
+			break
+		}
+
+		batch := embeddings[start:end]
+		err := c.validateEmbeddingDimensions(batch)
+		if err == nil {
+			err = c.insertEmbeddings(ctx, batch)
+		}
+		if err != nil {
+			report.Failed = append(report.Failed, SubBatchError{Start: start, End: end, Err: err})
+			continue
+		}
+
+		report.Inserted += len(batch)
+	}
+
+	return report
+}
+
+// insertEmbeddings posts embeddings in a single request
+func (c *Client) insertEmbeddings(ctx context.Context, embeddings []Embedding) error {
 	url := fmt.Sprintf("%s/rest/v1/embeddings", c.url)
 
 	payload, err := json.Marshal(embeddings)
@@ -243,3 +499,24 @@ func (c *Client) BatchInsertEmbeddings(ctx context.Context, embeddings []Embeddi
 
 	return nil
 }
+
+// validateEmbeddingDimensions checks that all vectors in a batch share the expected dimension
+func (c *Client) validateEmbeddingDimensions(embeddings []Embedding) error {
+	expected := c.embeddingDimensions
+	if expected == 0 {
+		// Infer from the first vector in the batch
+		expected = len(embeddings[0].Vector)
+	}
+
+	if expected == 0 {
+		return fmt.Errorf("embedding 0 has an empty vector")
+	}
+
+	for i, embedding := range embeddings {
+		if len(embedding.Vector) != expected {
+			return fmt.Errorf("embedding dimension mismatch at index %d: expected %d, got %d", i, expected, len(embedding.Vector))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDeleteDocument(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name     string
+		body     string
+		notFound bool
+	}{
+		{name: "deleted", body: `[{"id":"` + id.String() + `"}]`},
+		{name: "nothing matched", body: `[]`, notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/rest/v1/documents" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				query = r.URL.RawQuery
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := newTestClient(t, server.URL).DeleteDocument(context.Background(), id)
+
+			var notFound *NotFoundError
+			if tt.notFound != errors.As(err, &notFound) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.notFound && err != nil {
+				t.Fatalf("DeleteDocument: %v", err)
+			}
+			if want := "id=eq." + id.String() + "&select=id"; query != want {
+				t.Errorf("got query %q, want %q", query, want)
+			}
+		})
+	}
+}
+
+func TestDeleteEmbeddingsByDocumentReturnsOnlyIDs(t *testing.T) {
+	id := uuid.New()
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/v1/embeddings" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`[{"id":"` + uuid.NewString() + `"},{"id":"` + uuid.NewString() + `"}]`))
+	}))
+	defer server.Close()
+
+	if err := newTestClient(t, server.URL).DeleteEmbeddingsByDocument(context.Background(), id); err != nil {
+		t.Fatalf("DeleteEmbeddingsByDocument: %v", err)
+	}
+	if want := "document_id=eq." + id.String() + "&select=id"; query != want {
+		t.Errorf("got query %q, want %q", query, want)
+	}
+}
+
+func TestBatchInsertEmbeddingsRejectsDimensionMismatch(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	configured, err := NewClient(ClientConfig{URL: server.URL, APIKey: "test-key", EmbeddingDimensions: 3})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		client     *Client
+		embeddings []Embedding
+	}{
+		{name: "configured dimension", client: configured, embeddings: []Embedding{{Vector: []float32{1, 2, 3}}, {Vector: []float32{1, 2}}}},
+		{name: "inferred from the first vector", client: newTestClient(t, server.URL), embeddings: []Embedding{{Vector: []float32{1, 2}}, {Vector: []float32{1, 2, 3}}}},
+		{name: "empty vector", client: newTestClient(t, server.URL), embeddings: []Embedding{{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.client.BatchInsertEmbeddings(context.Background(), tt.embeddings); err == nil {
+				t.Error("expected the batch to be rejected")
+			}
+		})
+	}
+	if requests != 0 {
+		t.Errorf("expected no request for rejected batches, got %d", requests)
+	}
+
+	if err := configured.BatchInsertEmbeddings(context.Background(), []Embedding{{Vector: []float32{1, 2, 3}}}); err != nil || requests != 1 {
+		t.Errorf("expected a matching batch to be inserted, got %v after %d requests", err, requests)
+	}
+}
+
+func TestBatchInsertEmbeddingsPartialContinuesPastFailedSubBatch(t *testing.T) {
+	var inserted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Embedding
+		json.NewDecoder(r.Body).Decode(&batch)
+		if batch[0].Chunk == "c2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, embedding := range batch {
+			inserted = append(inserted, embedding.Chunk)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	embeddings := make([]Embedding, 5)
+	for i := range embeddings {
+		embeddings[i] = Embedding{Vector: []float32{1, 2, 3}, Chunk: fmt.Sprintf("c%d", i)}
+	}
+
+	report := newTestClient(t, server.URL).BatchInsertEmbeddingsPartial(context.Background(), embeddings, 2)
+
+	if want := []string{"c0", "c1", "c4"}; !reflect.DeepEqual(inserted, want) {
+		t.Errorf("expected the other sub-batches to be inserted, got %v", inserted)
+	}
+	if report.Inserted != 3 {
+		t.Errorf("expected 3 inserted, got %d", report.Inserted)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Start != 2 || report.Failed[0].End != 4 {
+		t.Fatalf("expected only embeddings 2-3 to fail, got %+v", report.Failed)
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "embeddings 2-3: insert embeddings failed: status 400") {
+		t.Errorf("expected the failed sub-batch to be named, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report = newTestClient(t, server.URL).BatchInsertEmbeddingsPartial(ctx, embeddings, 2)
+	if report.Inserted != 0 || len(report.Failed) != 1 || report.Failed[0].Start != 0 || report.Failed[0].End != 5 {
+		t.Errorf("expected every embedding to be reported after cancellation, got %+v", report)
+	}
+	if !errors.Is(report.Err(), context.Canceled) {
+		t.Errorf("expected the context error, got %v", report.Err())
+	}
+}
@@ -0,0 +1,233 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// realtimeTopic is the channel topic used for the sources subscription
+	realtimeTopic = "realtime:public:sources"
+
+	// realtimeHeartbeatInterval keeps the Phoenix socket alive (the server drops it after 60s)
+	realtimeHeartbeatInterval = 25 * time.Second
+)
+
+// realtimeMessage is a Phoenix channel message exchanged with Supabase Realtime
+type realtimeMessage struct {
+	Topic   string          `json:"topic"`
+	Event   string          `json:"event"`
+	Payload json.RawMessage `json:"payload"`
+	Ref     string          `json:"ref,omitempty"`
+}
+
+// realtimeReply is the payload of a phx_reply message
+type realtimeReply struct {
+	Status   string         `json:"status"`
+	Response map[string]any `json:"response"`
+}
+
+// sourceChange is the payload of a postgres_changes message for the sources table
+type sourceChange struct {
+	Data struct {
+		Type      string         `json:"type"`
+		Record    map[string]any `json:"record"`
+		OldRecord map[string]any `json:"old_record"`
+	} `json:"data"`
+}
+
+// watchSourceChanges keeps a Realtime subscription to the sources table open until ctx is done,
+// reconnecting with backoff, and invalidates cached sources as soon as they change
+func (c *Client) watchSourceChanges(ctx context.Context) {
+	defer close(c.realtimeDone)
+
+	backoff := newAdaptiveBackoff(time.Second, 30*time.Second, 2)
+	for {
+		err := c.runRealtime(ctx, backoff)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.logger.Warn("Supabase realtime subscription lost", "error", err)
+		backoff.Failure()
+		if err := backoff.Wait(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// runRealtime runs a single Realtime connection until it fails or ctx is done
+func (c *Client) runRealtime(ctx context.Context, backoff *adaptiveBackoff) error {
+	wsURL, err := realtimeURL(c.url, c.apiKey)
+	if err != nil {
+		return err
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to supabase realtime: %w", err)
+	}
+	defer conn.Close()
+
+	// Unblock the read loop when the context is cancelled
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	join := map[string]any{
+		"topic": realtimeTopic,
+		"event": "phx_join",
+		"payload": map[string]any{
+			"config": map[string]any{
+				"postgres_changes": []map[string]any{
+					{"event": "*", "schema": "public", "table": "sources"},
+				},
+			},
+			"access_token": c.apiKey,
+		},
+		"ref": "1",
+	}
+	if err := conn.WriteJSON(join); err != nil {
+		return fmt.Errorf("failed to join supabase realtime channel: %w", err)
+	}
+
+	// The join is the only other write, so the heartbeat loop is the sole writer from here on
+	go func() {
+		ticker := time.NewTicker(realtimeHeartbeatInterval)
+		defer ticker.Stop()
+
+		for ref := 2; ; ref++ {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				heartbeat := map[string]any{
+					"topic":   "phoenix",
+					"event":   "heartbeat",
+					"payload": map[string]any{},
+					"ref":     strconv.Itoa(ref),
+				}
+				if err := conn.WriteJSON(heartbeat); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		var msg realtimeMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return fmt.Errorf("failed to read supabase realtime message: %w", err)
+		}
+
+		if err := c.handleRealtimeMessage(msg, backoff); err != nil {
+			return err
+		}
+	}
+}
+
+// handleRealtimeMessage applies a single Realtime message to the source cache
+func (c *Client) handleRealtimeMessage(msg realtimeMessage, backoff *adaptiveBackoff) error {
+	if msg.Topic != realtimeTopic {
+		return nil
+	}
+
+	switch msg.Event {
+	case "phx_reply":
+		if msg.Ref != "1" {
+			return nil
+		}
+
+		var reply realtimeReply
+		if err := json.Unmarshal(msg.Payload, &reply); err != nil {
+			return fmt.Errorf("failed to decode supabase realtime reply: %w", err)
+		}
+		if reply.Status != "ok" {
+			return fmt.Errorf("supabase realtime join failed: %v", reply.Response)
+		}
+
+		// Changes may have been missed while disconnected
+		c.ClearCache()
+		backoff.Success()
+		c.logger.Info("Subscribed to supabase source changes")
+
+	case "postgres_changes":
+		var change sourceChange
+		if err := json.Unmarshal(msg.Payload, &change); err != nil {
+			c.logger.Warn("Failed to decode supabase source change", "error", err)
+			return nil
+		}
+
+		for _, record := range []map[string]any{change.Data.Record, change.Data.OldRecord} {
+			id, _ := record["id"].(string)
+			token, _ := record["public_token"].(string)
+			c.invalidateSource(id, token)
+		}
+
+	case "phx_error", "phx_close":
+		return fmt.Errorf("supabase realtime channel closed: %s", msg.Event)
+	}
+
+	return nil
+}
+
+// invalidateSource removes a source from the cache by ID and token
+func (c *Client) invalidateSource(id, token string) {
+	if id == "" && token == "" {
+		return
+	}
+
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	if token != "" {
+		delete(c.cache.byToken, token)
+	}
+	if id == "" {
+		return
+	}
+
+	delete(c.cache.byID, id)
+
+	// The old token is not always included in the change, so drop any token entry for this source
+	for key, entry := range c.cache.byToken {
+		if entry.source.ID == id {
+			delete(c.cache.byToken, key)
+		}
+	}
+}
+
+// realtimeURL builds the Realtime WebSocket URL from the project URL
+func realtimeURL(baseURL, apiKey string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid supabase URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	}
+	u.Path = "/realtime/v1/websocket"
+
+	query := url.Values{}
+	query.Set("apikey", apiKey)
+	query.Set("vsn", "1.0.0")
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,89 @@
+package supabase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// changeMessage builds a postgres_changes message for the sources table
+func changeMessage(t *testing.T, changeType string, record, oldRecord map[string]any) realtimeMessage {
+	t.Helper()
+
+	payload, err := json.Marshal(map[string]any{
+		"data": map[string]any{"type": changeType, "record": record, "old_record": oldRecord},
+	})
+	if err != nil {
+		t.Fatalf("encode change: %v", err)
+	}
+	return realtimeMessage{Topic: realtimeTopic, Event: "postgres_changes", Payload: payload}
+}
+
+func TestRealtimeChangeInvalidatesCachedSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		message func(t *testing.T) realtimeMessage
+	}{
+		{
+			name: "update with token",
+			message: func(t *testing.T) realtimeMessage {
+				return changeMessage(t, "UPDATE", map[string]any{"id": "src-1", "public_token": "tok-1"}, nil)
+			},
+		},
+		{
+			name: "update with rotated token",
+			message: func(t *testing.T) realtimeMessage {
+				return changeMessage(t, "UPDATE", map[string]any{"id": "src-1", "public_token": "tok-new"}, nil)
+			},
+		},
+		{
+			name: "delete with old record only",
+			message: func(t *testing.T) realtimeMessage {
+				return changeMessage(t, "DELETE", nil, map[string]any{"id": "src-1"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, "http://localhost")
+			client.cacheTTL = time.Hour
+			client.addToCache(&types.SourceConfig{ID: "src-1", PublicToken: "tok-1"})
+			client.addToCache(&types.SourceConfig{ID: "src-2", PublicToken: "tok-2"})
+
+			if err := client.handleRealtimeMessage(tt.message(t), newAdaptiveBackoff(time.Second, time.Minute, 2)); err != nil {
+				t.Fatalf("handleRealtimeMessage: %v", err)
+			}
+
+			if client.getFromCache("id", "src-1") != nil || client.getFromCache("token", "tok-1") != nil {
+				t.Error("the changed source is still cached")
+			}
+			if client.getFromCache("id", "src-2") == nil || client.getFromCache("token", "tok-2") == nil {
+				t.Error("an unrelated source was evicted")
+			}
+		})
+	}
+}
+
+func TestRealtimeMessagesForOtherTopicsAreIgnored(t *testing.T) {
+	client := newTestClient(t, "http://localhost")
+	client.cacheTTL = time.Hour
+	client.addToCache(&types.SourceConfig{ID: "src-1", PublicToken: "tok-1"})
+	backoff := newAdaptiveBackoff(time.Second, time.Minute, 2)
+
+	msg := changeMessage(t, "UPDATE", map[string]any{"id": "src-1"}, nil)
+	msg.Topic = "realtime:public:documents"
+	if err := client.handleRealtimeMessage(msg, backoff); err != nil {
+		t.Fatalf("handleRealtimeMessage: %v", err)
+	}
+	if client.getFromCache("id", "src-1") == nil {
+		t.Error("a message for another topic invalidated the source")
+	}
+
+	closed := realtimeMessage{Topic: realtimeTopic, Event: "phx_close"}
+	if err := client.handleRealtimeMessage(closed, backoff); err == nil {
+		t.Error("expected an error when the channel closes")
+	}
+}
@@ -0,0 +1,173 @@
+package supabase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/tokenizer"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+const (
+	// defaultContextSeparator joins the chunks of an assembled context
+	defaultContextSeparator = "\n\n"
+
+	// minTruncatedChunkTokens is the smallest remaining budget worth filling with a truncated chunk
+	minTruncatedChunkTokens = 32
+
+	// summaryPrompt instructs the summarizer how to condense chunks that did not fit
+	summaryPrompt = "Summarize the following passages, keeping facts, names and numbers. Answer in at most %d tokens."
+)
+
+// ContextOptions controls how search results are fitted into a model's context
+type ContextOptions struct {
+	// MaxTokens is the token budget for the assembled context
+	MaxTokens int
+	// Tokenizer counts tokens; nil uses an ApproximateTokenizer
+	Tokenizer tokenizer.Tokenizer
+	// Separator joins chunks; defaults to a blank line
+	Separator string
+	// Summarizer, when set, condenses the chunks that did not fit, typically with a cheap model
+	Summarizer interfaces.ChatService
+	// SummaryOptions are passed to the summarizer, e.g. {"model": "gpt-4o-mini"}
+	SummaryOptions map[string]any
+	// SummaryTokens is the part of MaxTokens reserved for the summary; defaults to a quarter
+	SummaryTokens int
+}
+
+// AssembledContext is retrieved context fitted to a token budget
+type AssembledContext struct {
+	// Text is the included chunks, highest similarity first, followed by the summary if any
+	Text string
+	// Included holds the chunks in Text; the last one may have been truncated
+	Included []types.SearchResult
+	// Dropped holds the chunks that did not fit, highest similarity first
+	Dropped []types.SearchResult
+	// Summary condenses Dropped when a summarizer was configured
+	Summary string
+	// Tokens is the token count of Text
+	Tokens int
+}
+
+// AssembleContext selects the most similar search results that fit within opts.MaxTokens. A chunk
+// that only partly fits is truncated; the rest are dropped, or summarized by opts.Summarizer into a
+// reserved part of the budget.
+func AssembleContext(ctx context.Context, results []types.SearchResult, opts ContextOptions) (*AssembledContext, error) {
+	if opts.MaxTokens <= 0 {
+		return nil, fmt.Errorf("context token budget must be positive, got %d", opts.MaxTokens)
+	}
+	if opts.Tokenizer == nil {
+		opts.Tokenizer = &tokenizer.ApproximateTokenizer{}
+	}
+	if opts.Separator == "" {
+		opts.Separator = defaultContextSeparator
+	}
+
+	ranked := slices.Clone(results)
+	slices.SortStableFunc(ranked, func(a, b types.SearchResult) int {
+		return cmp.Compare(b.Similarity, a.Similarity)
+	})
+
+	assembled := fitChunks(ranked, opts.MaxTokens, opts)
+	if len(assembled.Dropped) == 0 || opts.Summarizer == nil {
+		return assembled, nil
+	}
+
+	// Refit with room for the summary, then condense everything that no longer fits
+	summaryTokens := opts.SummaryTokens
+	if summaryTokens <= 0 || summaryTokens >= opts.MaxTokens {
+		summaryTokens = opts.MaxTokens / 4
+	}
+	assembled = fitChunks(ranked, opts.MaxTokens-summaryTokens, opts)
+
+	summary, err := summarizeChunks(ctx, assembled.Dropped, summaryTokens, opts)
+	if err != nil {
+		return nil, err
+	}
+	if summary == "" {
+		return assembled, nil
+	}
+
+	text := summary
+	if assembled.Text != "" {
+		text = assembled.Text + opts.Separator + summary
+	}
+	assembled.Summary = summary
+	assembled.Text = tokenizer.TruncateToTokens(text, opts.MaxTokens, opts.Tokenizer)
+	assembled.Tokens = opts.Tokenizer.CountTokens(assembled.Text)
+	return assembled, nil
+}
+
+// fitChunks greedily includes ranked chunks within budget, truncating the first chunk that does not
+// fit when enough budget remains for it to be useful
+func fitChunks(ranked []types.SearchResult, budget int, opts ContextOptions) *AssembledContext {
+	assembled := &AssembledContext{}
+	var text strings.Builder
+
+	for _, result := range ranked {
+		separator := ""
+		if text.Len() > 0 {
+			separator = opts.Separator
+		}
+
+		remaining := budget - opts.Tokenizer.CountTokens(text.String()+separator)
+		if remaining <= 0 {
+			assembled.Dropped = append(assembled.Dropped, result)
+			continue
+		}
+
+		content := result.Content
+		if opts.Tokenizer.CountTokens(content) > remaining {
+			if remaining < minTruncatedChunkTokens {
+				assembled.Dropped = append(assembled.Dropped, result)
+				continue
+			}
+			content = tokenizer.TruncateToTokens(content, remaining, opts.Tokenizer)
+			result.Content = content
+		}
+
+		text.WriteString(separator)
+		text.WriteString(content)
+		assembled.Included = append(assembled.Included, result)
+	}
+
+	assembled.Text = tokenizer.TruncateToTokens(text.String(), budget, opts.Tokenizer)
+	assembled.Tokens = opts.Tokenizer.CountTokens(assembled.Text)
+	return assembled
+}
+
+// summarizeChunks asks the summarizer to condense chunks into at most maxTokens
+func summarizeChunks(ctx context.Context, chunks []types.SearchResult, maxTokens int, opts ContextOptions) (string, error) {
+	if maxTokens <= 0 {
+		return "", nil
+	}
+
+	passages := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		passages[i] = chunk.Content
+	}
+
+	messages := []types.ChatMessage{
+		{Role: "system", Content: fmt.Sprintf(summaryPrompt, maxTokens)},
+		{Role: "user", Content: strings.Join(passages, opts.Separator)},
+	}
+
+	options := make(map[string]any, len(opts.SummaryOptions)+1)
+	for key, value := range opts.SummaryOptions {
+		options[key] = value
+	}
+	if _, ok := options["max_tokens"]; !ok {
+		options["max_tokens"] = maxTokens
+	}
+
+	summary, err := opts.Summarizer.ChatCompletion(ctx, messages, options)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize retrieved context: %w", err)
+	}
+
+	return tokenizer.TruncateToTokens(strings.TrimSpace(summary), maxTokens, opts.Tokenizer), nil
+}
@@ -0,0 +1,152 @@
+package supabase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/providers/mock"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// wordTokenizer counts one token per word so budgets are easy to follow
+type wordTokenizer struct{}
+
+func (wordTokenizer) CountTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+// searchResult is a search result whose content repeats its ID words times
+func searchResult(id string, similarity float64, words int) types.SearchResult {
+	return types.SearchResult{ID: id, Similarity: similarity, Content: strings.TrimSpace(strings.Repeat(id+" ", words))}
+}
+
+// resultIDs returns the IDs of results in order
+func resultIDs(results []types.SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	return ids
+}
+
+func TestAssembleContext(t *testing.T) {
+	results := []types.SearchResult{
+		searchResult("a", 0.5, 40),
+		searchResult("b", 0.9, 40),
+		searchResult("c", 0.7, 80),
+		searchResult("d", 0.1, 10),
+	}
+
+	tests := []struct {
+		name      string
+		maxTokens int
+		included  string
+		dropped   string
+		truncated string // ID of the included chunk that was cut short
+	}{
+		{name: "everything fits", maxTokens: 200, included: "b c a d"},
+		{name: "one chunk truncated", maxTokens: 100, included: "b c", dropped: "a d", truncated: "c"},
+		{name: "small remainders are not truncated", maxTokens: 50, included: "b d", dropped: "c a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assembled, err := AssembleContext(context.Background(), results, ContextOptions{MaxTokens: tt.maxTokens, Tokenizer: wordTokenizer{}})
+			if err != nil {
+				t.Fatalf("AssembleContext: %v", err)
+			}
+
+			if got := strings.Join(resultIDs(assembled.Included), " "); got != tt.included {
+				t.Errorf("included %q, want %q", got, tt.included)
+			}
+			if got := strings.Join(resultIDs(assembled.Dropped), " "); got != tt.dropped {
+				t.Errorf("dropped %q, want %q", got, tt.dropped)
+			}
+			if assembled.Tokens > tt.maxTokens || assembled.Tokens != (wordTokenizer{}).CountTokens(assembled.Text) {
+				t.Errorf("got %d tokens for a budget of %d", assembled.Tokens, tt.maxTokens)
+			}
+			if !strings.HasPrefix(assembled.Text, results[1].Content) {
+				t.Errorf("expected the most similar chunk first, got %q", assembled.Text)
+			}
+
+			for _, included := range assembled.Included {
+				var original types.SearchResult
+				for _, result := range results {
+					if result.ID == included.ID {
+						original = result
+					}
+				}
+				if cut := included.Content != original.Content; cut != (included.ID == tt.truncated) {
+					t.Errorf("%s: truncated %v, want %v", included.ID, cut, included.ID == tt.truncated)
+				}
+				if !strings.HasPrefix(original.Content, included.Content) {
+					t.Errorf("%s: the included content is not a prefix of the chunk", included.ID)
+				}
+			}
+		})
+	}
+
+	if results[0].ID != "a" || len(strings.Fields(results[2].Content)) != 80 {
+		t.Error("the input results were modified")
+	}
+	if _, err := AssembleContext(context.Background(), results, ContextOptions{}); err == nil {
+		t.Error("expected an error for a missing budget")
+	}
+}
+
+func TestAssembleContextSummarizesDroppedChunks(t *testing.T) {
+	results := []types.SearchResult{
+		searchResult("a", 0.9, 40),
+		searchResult("b", 0.7, 80),
+		searchResult("c", 0.5, 30),
+	}
+	opts := ContextOptions{MaxTokens: 100, SummaryTokens: 20, Tokenizer: wordTokenizer{}}
+
+	// The summarizer ignores the requested length; its answer is cut to the reserved budget
+	summarizer := mock.NewMockProvider(mock.Config{ChatResponse: strings.Repeat("summary ", 50)})
+	opts.Summarizer = summarizer
+
+	assembled, err := AssembleContext(context.Background(), results, opts)
+	if err != nil {
+		t.Fatalf("AssembleContext: %v", err)
+	}
+
+	if summarizer.Calls("ChatCompletion") != 1 {
+		t.Errorf("expected one summary, got %d", summarizer.Calls("ChatCompletion"))
+	}
+	if got := strings.Join(resultIDs(assembled.Included), " "); got != "a b" {
+		t.Errorf("included %q, want the most similar chunks", got)
+	}
+	if got := strings.Join(resultIDs(assembled.Dropped), " "); got != "c" {
+		t.Errorf("dropped %q, want c", got)
+	}
+	if tokens := len(strings.Fields(assembled.Summary)); tokens == 0 || tokens > opts.SummaryTokens {
+		t.Errorf("got a %d token summary for a budget of %d", tokens, opts.SummaryTokens)
+	}
+	if assembled.Tokens > opts.MaxTokens || !strings.HasSuffix(assembled.Text, assembled.Summary) {
+		t.Errorf("expected the chunks and summary within %d tokens, got %d: %q", opts.MaxTokens, assembled.Tokens, assembled.Text)
+	}
+
+	// Nothing is summarized when every chunk fits
+	opts.MaxTokens = 200
+	assembled, err = AssembleContext(context.Background(), results, opts)
+	if err != nil {
+		t.Fatalf("AssembleContext: %v", err)
+	}
+	if assembled.Summary != "" {
+		t.Errorf("expected no summary, got %q", assembled.Summary)
+	}
+	if summarizer.Calls("ChatCompletion") != 1 {
+		t.Error("the summarizer was called although every chunk fit")
+	}
+
+	// A failing summarizer fails the assembly
+	summaryErr := errors.New("summarizer down")
+	opts.MaxTokens = 100
+	opts.Summarizer = mock.NewMockProvider(mock.Config{Errors: map[types.Capability]error{types.CapabilityChat: summaryErr}})
+	if _, err := AssembleContext(context.Background(), results, opts); !errors.Is(err, summaryErr) {
+		t.Errorf("expected the summarizer error, got %v", err)
+	}
+}
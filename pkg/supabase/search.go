@@ -0,0 +1,49 @@
+package supabase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/creastat/common-go/pkg/interfaces"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// Embedders maps an embedding model id to the service that produces embeddings with it.
+// The entry under the empty key is used for sources that do not record their embedding model.
+type Embedders map[string]interfaces.EmbeddingService
+
+// QueryEmbedder returns the embedder matching the model the source was ingested with.
+// Embedding a query with a different model than its documents yields meaningless similarities,
+// so an unknown model is an error rather than a fallback to the default embedder.
+func (e Embedders) QueryEmbedder(source *types.SourceConfig) (interfaces.EmbeddingService, error) {
+	model := source.GetEmbeddingModel()
+
+	embedder, ok := e[model]
+	if !ok || embedder == nil {
+		if model == "" {
+			return nil, fmt.Errorf("no default embedder configured for source %s", source.ID)
+		}
+		return nil, fmt.Errorf("no embedder configured for model %s used by source %s", model, source.ID)
+	}
+
+	return embedder, nil
+}
+
+// SearchSource embeds the query with the source's embedding model and searches its documents.
+// req.SourceID and req.QueryEmbedding are filled in from the source and query.
+func (c *Client) SearchSource(ctx context.Context, source *types.SourceConfig, query string, embedders Embedders, req types.SearchRequest) ([]types.SearchResult, error) {
+	embedder, err := embedders.QueryEmbedder(source)
+	if err != nil {
+		return nil, err
+	}
+
+	embedding, err := embedder.GenerateEmbedding(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	req.SourceID = source.ID
+	req.QueryEmbedding = embedding
+
+	return c.SearchDocuments(ctx, req)
+}
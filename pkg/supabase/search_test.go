@@ -0,0 +1,122 @@
+package supabase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/providers/mock"
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// sourceWithModel returns a source whose documents were ingested with model, or no recorded model when empty
+func sourceWithModel(id, model string) *types.SourceConfig {
+	source := &types.SourceConfig{ID: id}
+	if model != "" {
+		source.SetEmbeddingModel(model)
+	}
+	return source
+}
+
+func TestQueryEmbedder(t *testing.T) {
+	small := mock.NewMockProvider(mock.Config{Name: "small"})
+	large := mock.NewMockProvider(mock.Config{Name: "large"})
+	fallback := mock.NewMockProvider(mock.Config{Name: "default"})
+
+	tests := []struct {
+		name      string
+		embedders Embedders
+		model     string
+		want      *mock.MockProvider
+		wantErr   string
+	}{
+		{
+			name:      "source model",
+			embedders: Embedders{"text-embedding-3-small": small, "text-embedding-3-large": large, "": fallback},
+			model:     "text-embedding-3-large",
+			want:      large,
+		},
+		{
+			name:      "unrecorded model uses default",
+			embedders: Embedders{"text-embedding-3-small": small, "": fallback},
+			want:      fallback,
+		},
+		{
+			name:      "unknown model",
+			embedders: Embedders{"text-embedding-3-small": small, "": fallback},
+			model:     "text-embedding-ada-002",
+			wantErr:   "no embedder configured for model text-embedding-ada-002",
+		},
+		{
+			name:      "no default",
+			embedders: Embedders{"text-embedding-3-small": small},
+			wantErr:   "no default embedder",
+		},
+		{
+			name:      "nil entry",
+			embedders: Embedders{"text-embedding-3-small": nil},
+			model:     "text-embedding-3-small",
+			wantErr:   "no embedder configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embedder, err := tt.embedders.QueryEmbedder(sourceWithModel("src-1", tt.model))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryEmbedder: %v", err)
+			}
+			if embedder != tt.want {
+				t.Errorf("expected the %s embedder, got %v", tt.want.Name(), embedder)
+			}
+		})
+	}
+}
+
+func TestSearchSourceEmbedsWithSourceModel(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+
+	small := mock.NewMockProvider(mock.Config{Embedding: []float32{0.5}})
+	large := mock.NewMockProvider(mock.Config{Embedding: []float32{0.25, 0.75}})
+	embedders := Embedders{"text-embedding-3-small": small, "text-embedding-3-large": large}
+
+	source := sourceWithModel("src-1", "text-embedding-3-large")
+	if _, err := client.SearchSource(context.Background(), source, "refund policy", embedders, types.SearchRequest{MaxResults: 3}); err != nil {
+		t.Fatalf("SearchSource: %v", err)
+	}
+
+	if large.Calls("GenerateEmbedding") != 1 || small.Calls("GenerateEmbedding") != 0 {
+		t.Errorf("expected only the large embedder to be used, got small=%d large=%d", small.Calls("GenerateEmbedding"), large.Calls("GenerateEmbedding"))
+	}
+	if body["p_source_id"] != "src-1" {
+		t.Errorf("expected the source ID to be searched, got %v", body["p_source_id"])
+	}
+	if embedding, _ := body["query_embedding"].([]any); len(embedding) != 2 || embedding[1] != 0.75 {
+		t.Errorf("expected the large model's query embedding, got %v", body["query_embedding"])
+	}
+
+	// A source ingested with an unconfigured model is never searched
+	body = nil
+	unknown := sourceWithModel("src-2", "text-embedding-ada-002")
+	if _, err := client.SearchSource(context.Background(), unknown, "refund policy", embedders, types.SearchRequest{}); err == nil {
+		t.Error("expected an error for an unknown embedding model")
+	}
+	if body != nil {
+		t.Errorf("the search ran despite the unknown model: %v", body)
+	}
+}
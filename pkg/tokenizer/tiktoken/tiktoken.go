@@ -0,0 +1,34 @@
+package tiktoken
+
+import (
+	"fmt"
+
+	tk "github.com/pkoukk/tiktoken-go"
+)
+
+// defaultEncoding is used when the model has no known encoding
+const defaultEncoding = "cl100k_base"
+
+// Tokenizer implements tokenizer.Tokenizer using tiktoken BPE encodings
+type Tokenizer struct {
+	encoding *tk.Tiktoken
+}
+
+// New creates a tiktoken-based tokenizer for the given model.
+// Unknown models fall back to the cl100k_base encoding.
+func New(model string) (*Tokenizer, error) {
+	encoding, err := tk.EncodingForModel(model)
+	if err != nil {
+		encoding, err = tk.GetEncoding(defaultEncoding)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tiktoken encoding: %w", err)
+		}
+	}
+
+	return &Tokenizer{encoding: encoding}, nil
+}
+
+// CountTokens returns the number of tokens in the given text
+func (t *Tokenizer) CountTokens(text string) int {
+	return len(t.encoding.Encode(text, nil, nil))
+}
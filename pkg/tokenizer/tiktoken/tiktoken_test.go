@@ -0,0 +1,44 @@
+package tiktoken
+
+import "testing"
+
+// newTokenizer loads the encoding for model, skipping the test when the encoding files cannot be
+// fetched, as in an offline build
+func newTokenizer(t *testing.T, model string) *Tokenizer {
+	t.Helper()
+
+	tokenizer, err := New(model)
+	if err != nil {
+		t.Skipf("tiktoken encoding unavailable: %v", err)
+	}
+	return tokenizer
+}
+
+func TestCountTokens(t *testing.T) {
+	tokenizer := newTokenizer(t, "gpt-4")
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "", want: 0},
+		{text: "hello world", want: 2},
+		{text: "Hello, world!", want: 4},
+	}
+
+	for _, tt := range tests {
+		if got := tokenizer.CountTokens(tt.text); got != tt.want {
+			t.Errorf("CountTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestUnknownModelFallsBackToDefaultEncoding(t *testing.T) {
+	known := newTokenizer(t, "gpt-4")
+	unknown := newTokenizer(t, "not-a-model")
+
+	const text = "Tokenizers disagree about unusual words like antidisestablishmentarianism."
+	if got, want := unknown.CountTokens(text), known.CountTokens(text); got != want {
+		t.Errorf("expected the cl100k_base count %d, got %d", want, got)
+	}
+}
@@ -0,0 +1,111 @@
+package tokenizer
+
+import (
+	"unicode/utf8"
+
+	"github.com/creastat/common-go/pkg/types"
+)
+
+const (
+	// messageOverheadTokens is the per-message token overhead for role and formatting
+	messageOverheadTokens = 4
+
+	// approxCharsPerToken is the average number of characters per token for English text
+	approxCharsPerToken = 4
+)
+
+// Tokenizer counts tokens in text for context-window management
+type Tokenizer interface {
+	// CountTokens returns the number of tokens in the given text
+	CountTokens(text string) int
+}
+
+// ApproximateTokenizer estimates token counts from character length.
+// It requires no external dependencies and is used when no tokenizer is provided.
+type ApproximateTokenizer struct{}
+
+// CountTokens returns an approximate token count for the given text
+func (t *ApproximateTokenizer) CountTokens(text string) int {
+	chars := utf8.RuneCountInString(text)
+	if chars == 0 {
+		return 0
+	}
+	return (chars + approxCharsPerToken - 1) / approxCharsPerToken
+}
+
+// CountMessageTokens returns the total token count of a message list including per-message overhead
+func CountMessageTokens(messages []types.ChatMessage, tokenizer Tokenizer) int {
+	if tokenizer == nil {
+		tokenizer = &ApproximateTokenizer{}
+	}
+
+	total := 0
+	for _, msg := range messages {
+		total += tokenizer.CountTokens(msg.Content) + messageOverheadTokens
+	}
+	return total
+}
+
+// TrimMessages drops the oldest messages until the history fits within maxTokens.
+// Leading system messages are always preserved. If tokenizer is nil, an ApproximateTokenizer is used.
+func TrimMessages(messages []types.ChatMessage, maxTokens int, tokenizer Tokenizer) []types.ChatMessage {
+	if tokenizer == nil {
+		tokenizer = &ApproximateTokenizer{}
+	}
+
+	// Split off the leading system prompt
+	systemCount := 0
+	for systemCount < len(messages) && messages[systemCount].Role == "system" {
+		systemCount++
+	}
+
+	system := messages[:systemCount]
+	history := messages[systemCount:]
+
+	budget := maxTokens - CountMessageTokens(system, tokenizer)
+
+	// Walk backwards keeping the newest messages that fit
+	start := len(history)
+	used := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		cost := tokenizer.CountTokens(history[i].Content) + messageOverheadTokens
+		if used+cost > budget {
+			break
+		}
+		used += cost
+		start = i
+	}
+
+	result := make([]types.ChatMessage, 0, len(system)+len(history)-start)
+	result = append(result, system...)
+	result = append(result, history[start:]...)
+	return result
+}
+
+// TruncateToTokens returns the longest prefix of text, cut at a rune boundary, that fits within
+// maxTokens. If tokenizer is nil, an ApproximateTokenizer is used.
+func TruncateToTokens(text string, maxTokens int, tokenizer Tokenizer) string {
+	if tokenizer == nil {
+		tokenizer = &ApproximateTokenizer{}
+	}
+	if maxTokens <= 0 {
+		return ""
+	}
+	if tokenizer.CountTokens(text) <= maxTokens {
+		return text
+	}
+
+	runes := []rune(text)
+
+	// Binary search for the longest prefix that fits
+	low, high := 0, len(runes)
+	for low < high {
+		mid := (low + high + 1) / 2
+		if tokenizer.CountTokens(string(runes[:mid])) <= maxTokens {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return string(runes[:low])
+}
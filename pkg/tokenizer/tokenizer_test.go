@@ -0,0 +1,124 @@
+package tokenizer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/creastat/common-go/pkg/types"
+)
+
+// wordTokenizer counts one token per whitespace-separated word, making budgets easy to reason about
+type wordTokenizer struct{}
+
+func (wordTokenizer) CountTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+func TestApproximateTokenizer(t *testing.T) {
+	tokenizer := &ApproximateTokenizer{}
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "", want: 0},
+		{text: "abc", want: 1},
+		{text: "abcd", want: 1},
+		{text: "abcde", want: 2},
+		// Runes, not bytes
+		{text: "привет", want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := tokenizer.CountTokens(tt.text); got != tt.want {
+			t.Errorf("CountTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCountMessageTokens(t *testing.T) {
+	messages := []types.ChatMessage{
+		{Role: "system", Content: "be brief"},
+		{Role: "user", Content: "hello there world"},
+		{Role: "assistant", Content: ""},
+	}
+
+	// 2 + 3 + 0 words plus the overhead of each message
+	if got, want := CountMessageTokens(messages, wordTokenizer{}), 5+3*messageOverheadTokens; got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+	if got := CountMessageTokens(nil, nil); got != 0 {
+		t.Errorf("expected no tokens for no messages, got %d", got)
+	}
+	if got, want := CountMessageTokens(messages[:1], nil), 2+messageOverheadTokens; got != want {
+		t.Errorf("nil tokenizer: got %d, want %d", got, want)
+	}
+}
+
+func TestTrimMessages(t *testing.T) {
+	// Each message costs its word count plus the per-message overhead of 4
+	system := types.ChatMessage{Role: "system", Content: "one two"}
+	old := types.ChatMessage{Role: "user", Content: "one two three"}
+	reply := types.ChatMessage{Role: "assistant", Content: "one"}
+	latest := types.ChatMessage{Role: "user", Content: "one two"}
+	messages := []types.ChatMessage{system, old, reply, latest}
+
+	tests := []struct {
+		name      string
+		messages  []types.ChatMessage
+		maxTokens int
+		want      []types.ChatMessage
+	}{
+		{name: "everything fits", messages: messages, maxTokens: 24, want: messages},
+		{name: "oldest dropped", messages: messages, maxTokens: 23, want: []types.ChatMessage{system, reply, latest}},
+		{name: "only the latest fits", messages: messages, maxTokens: 16, want: []types.ChatMessage{system, latest}},
+		{name: "exact budget for the system prompt", messages: messages, maxTokens: 6, want: []types.ChatMessage{system}},
+		{name: "system kept over budget", messages: messages, maxTokens: 1, want: []types.ChatMessage{system}},
+		{name: "a newer message that does not fit stops trimming", messages: []types.ChatMessage{reply, old}, maxTokens: 5, want: []types.ChatMessage{}},
+		{name: "leading system messages", messages: []types.ChatMessage{system, system, latest}, maxTokens: 12, want: []types.ChatMessage{system, system}},
+		{name: "no messages", messages: nil, maxTokens: 10, want: []types.ChatMessage{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TrimMessages(tt.messages, tt.maxTokens, wordTokenizer{})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The input must not be modified
+	if len(messages) != 4 || !reflect.DeepEqual(messages[1], old) {
+		t.Error("TrimMessages modified its input")
+	}
+}
+
+func TestTruncateToTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxTokens int
+		want      string
+	}{
+		{name: "fits", text: "abcdefgh", maxTokens: 2, want: "abcdefgh"},
+		{name: "cut to the budget", text: "abcdefghij", maxTokens: 2, want: "abcdefgh"},
+		{name: "zero budget", text: "abc", maxTokens: 0, want: ""},
+		{name: "negative budget", text: "abc", maxTokens: -1, want: ""},
+		{name: "cut at a rune boundary", text: "приветмир", maxTokens: 1, want: "прив"},
+		{name: "empty", text: "", maxTokens: 3, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateToTokens(tt.text, tt.maxTokens, nil)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if tokens := (&ApproximateTokenizer{}).CountTokens(got); tokens > max(tt.maxTokens, 0) {
+				t.Errorf("%d tokens exceed the budget of %d", tokens, tt.maxTokens)
+			}
+		})
+	}
+}
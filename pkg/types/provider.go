@@ -19,6 +19,19 @@ const (
 type ChatMessage struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+	// ToolCalls holds the function calls an assistant message requested
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID links a "tool" role message carrying a function result to the call it answers
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// ToolCall is a function call requested by the model
+type ToolCall struct {
+	Index     int    `json:"index"`
+	ID        string `json:"id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
 }
 
 // Provider represents a generic AI provider that can offer one or more capabilities
@@ -15,6 +15,9 @@ type SupabaseService interface {
 
 	// SearchDocuments performs vector similarity search against documents for a source
 	SearchDocuments(ctx context.Context, req SearchRequest) ([]SearchResult, error)
+
+	// SearchDocumentsPage performs a paged vector similarity search, reporting whether more matches may follow
+	SearchDocumentsPage(ctx context.Context, req SearchRequest) (*SearchPage, error)
 }
 
 // SourceConfig represents the configuration for a source from the Supabase sources table
@@ -39,6 +42,13 @@ type SearchRequest struct {
 	QueryEmbedding []float32 // Query embedding vector
 	MaxResults     int       // Maximum number of results to return
 	Threshold      float64   // Minimum similarity threshold (0.0-1.0)
+	Offset         int       // Number of matches to skip, for paging
+}
+
+// SearchPage is a single page of search results
+type SearchPage struct {
+	Results []SearchResult
+	HasMore bool // True when the page is full, so another page may follow
 }
 
 // SearchResult represents a single document search result
@@ -99,3 +109,18 @@ func (s *SourceConfig) GetRateLimit() int {
 	}
 	return s.RateLimit
 }
+
+// GetEmbeddingModel returns the embedding model the source's documents were ingested with,
+// or an empty string when it was not recorded in Metadata["embedding_model"]
+func (s *SourceConfig) GetEmbeddingModel() string {
+	model, _ := s.Metadata["embedding_model"].(string)
+	return model
+}
+
+// SetEmbeddingModel records the embedding model used to ingest the source's documents
+func (s *SourceConfig) SetEmbeddingModel(model string) {
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]any)
+	}
+	s.Metadata["embedding_model"] = model
+}
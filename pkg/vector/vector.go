@@ -0,0 +1,64 @@
+// Package vector provides similarity helpers for embeddings, so search results can be re-ranked,
+// deduplicated or checked against a similarity threshold on the client.
+package vector
+
+import (
+	"fmt"
+	"math"
+)
+
+// DotProduct returns the dot product of a and b, accumulated in float64.
+// It returns an error when the vectors differ in length.
+func DotProduct(a, b []float32) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("vector length mismatch: %d and %d", len(a), len(b))
+	}
+
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, in [-1, 1], the measure
+// SearchRequest.Threshold is compared against. A zero vector has no direction, so its similarity
+// to anything is 0 rather than NaN. It returns an error when the vectors differ in length.
+func CosineSimilarity(a, b []float32) (float64, error) {
+	dot, err := DotProduct(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	normA, normB := norm(a), norm(b)
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+
+	// Rounding can push the ratio just outside [-1, 1]
+	return math.Max(-1, math.Min(1, dot/(normA*normB))), nil
+}
+
+// Normalize returns a copy of v scaled to unit length. A zero vector is returned as a zero copy.
+func Normalize(v []float32) []float32 {
+	out := make([]float32, len(v))
+
+	n := norm(v)
+	if n == 0 {
+		return out
+	}
+
+	for i, x := range v {
+		out[i] = float32(float64(x) / n)
+	}
+	return out
+}
+
+// norm returns the Euclidean length of v
+func norm(v []float32) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
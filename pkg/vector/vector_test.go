@@ -0,0 +1,77 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+// tolerance absorbs float32 rounding in the expected values
+const tolerance = 1e-6
+
+func TestDotProduct(t *testing.T) {
+	got, err := DotProduct([]float32{1, 2, 3}, []float32{4, -5, 6})
+	if err != nil {
+		t.Fatalf("DotProduct: %v", err)
+	}
+	if got != 12 {
+		t.Errorf("got %v, want 12", got)
+	}
+
+	if _, err := DotProduct([]float32{1, 2}, []float32{1}); err == nil {
+		t.Error("expected an error for vectors of different lengths")
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{name: "same direction", a: []float32{1, 2, 3}, b: []float32{2, 4, 6}, want: 1},
+		{name: "opposite", a: []float32{1, 2, 3}, b: []float32{-1, -2, -3}, want: -1},
+		{name: "orthogonal", a: []float32{1, 0}, b: []float32{0, 5}, want: 0},
+		{name: "45 degrees", a: []float32{1, 0}, b: []float32{1, 1}, want: math.Sqrt2 / 2},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 1}, want: 0},
+		{name: "empty", a: []float32{}, b: []float32{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CosineSimilarity(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("CosineSimilarity: %v", err)
+			}
+			if math.Abs(got-tt.want) > tolerance {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if got < -1 || got > 1 {
+				t.Errorf("similarity %v is outside [-1, 1]", got)
+			}
+		})
+	}
+
+	if _, err := CosineSimilarity([]float32{1}, []float32{1, 2}); err == nil {
+		t.Error("expected an error for vectors of different lengths")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	v := []float32{3, 4}
+	got := Normalize(v)
+
+	if math.Abs(float64(got[0])-0.6) > tolerance || math.Abs(float64(got[1])-0.8) > tolerance {
+		t.Errorf("got %v, want [0.6 0.8]", got)
+	}
+	if math.Abs(norm(got)-1) > tolerance {
+		t.Errorf("normalized length is %v", norm(got))
+	}
+	if v[0] != 3 || v[1] != 4 {
+		t.Error("the input was modified")
+	}
+
+	zero := Normalize([]float32{0, 0, 0})
+	if len(zero) != 3 || zero[0] != 0 || zero[1] != 0 || zero[2] != 0 {
+		t.Errorf("expected a zero copy of a zero vector, got %v", zero)
+	}
+}